@@ -0,0 +1,112 @@
+// Package strings collects classic string algorithms: Knuth-Morris-Pratt
+// search, Levenshtein edit distance and longest common subsequence.
+package strings
+
+// IndexKMP returns the byte index of the first instance of substr in s, or
+// -1 if substr is not present. It behaves like strings.Index but runs in
+// O(len(s)+len(substr)) using the KMP failure table.
+func IndexKMP(s, substr string) int {
+	m := len(substr)
+	if m == 0 {
+		return 0
+	}
+	if m > len(s) {
+		return -1
+	}
+
+	fail := prefixTable(substr)
+	j := 0 // matched bytes of substr
+	for i := 0; i < len(s); i++ {
+		for j > 0 && s[i] != substr[j] {
+			j = fail[j-1]
+		}
+		if s[i] == substr[j] {
+			j++
+		}
+		if j == m {
+			return i - m + 1
+		}
+	}
+	return -1
+}
+
+// prefixTable returns, for every prefix of p, the length of its longest
+// proper prefix that is also a suffix.
+func prefixTable(p string) []int {
+	fail := make([]int, len(p))
+	k := 0
+	for i := 1; i < len(p); i++ {
+		for k > 0 && p[i] != p[k] {
+			k = fail[k-1]
+		}
+		if p[i] == p[k] {
+			k++
+		}
+		fail[i] = k
+	}
+	return fail
+}
+
+// LevenshteinDistance returns the minimum number of single-rune insertions,
+// deletions and substitutions needed to turn a into b.
+func LevenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra // keep the row as short as possible
+	}
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// LongestCommonSubsequence returns a longest rune sequence that appears in
+// both a and b in the same relative order (not necessarily contiguous).
+func LongestCommonSubsequence(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	n, m := len(ra), len(rb)
+
+	// dp[i][j] is the LCS length of ra[i:] and rb[j:].
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if ra[i] == rb[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+
+	out := make([]rune, 0, dp[0][0])
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case ra[i] == rb[j]:
+			out = append(out, ra[i])
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return string(out)
+}
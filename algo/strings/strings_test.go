@@ -0,0 +1,99 @@
+package strings_test
+
+import (
+	"strings"
+	"testing"
+
+	algo "github.com/armaanepiic/Golang/algo/strings"
+)
+
+func TestIndexKMP(t *testing.T) {
+	tests := []struct {
+		s, substr string
+		want      int
+	}{
+		{"", "", 0},
+		{"abc", "", 0},
+		{"", "a", -1},
+		{"abc", "abcd", -1},
+		{"hello world", "world", 6},
+		{"aaaab", "aab", 2},
+		{"abababc", "ababc", 2},
+		{"héllo", "llo", 3},
+	}
+	for _, tt := range tests {
+		if got := algo.IndexKMP(tt.s, tt.substr); got != tt.want {
+			t.Errorf("IndexKMP(%q, %q) = %d, want %d", tt.s, tt.substr, got, tt.want)
+		}
+	}
+}
+
+func FuzzIndex(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"", ""}, {"abc", "c"}, {"aaaab", "aab"}, {"abababc", "ababc"}, {"héllo", "é"},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, s, substr string) {
+		if got, want := algo.IndexKMP(s, substr), strings.Index(s, substr); got != want {
+			t.Fatalf("IndexKMP(%q, %q) = %d, strings.Index says %d", s, substr, got, want)
+		}
+	})
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"héllo", "hello", 1}, // one rune, not two bytes
+	}
+	for _, tt := range tests {
+		if got := algo.LevenshteinDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("LevenshteinDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := algo.LevenshteinDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("LevenshteinDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestLongestCommonSubsequence(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int // length in runes; several subsequences may be longest
+	}{
+		{"", "abc", 0},
+		{"ABCBDAB", "BDCABA", 4},
+		{"AGGTAB", "GXTXAYB", 4},
+		{"日本語", "日語", 2},
+	}
+	for _, tt := range tests {
+		got := algo.LongestCommonSubsequence(tt.a, tt.b)
+		if n := len([]rune(got)); n != tt.want {
+			t.Errorf("LongestCommonSubsequence(%q, %q) = %q, want length %d", tt.a, tt.b, got, tt.want)
+		}
+		if !isSubsequence(got, tt.a) || !isSubsequence(got, tt.b) {
+			t.Errorf("LongestCommonSubsequence(%q, %q) = %q, not a subsequence of both", tt.a, tt.b, got)
+		}
+	}
+}
+
+func isSubsequence(sub, s string) bool {
+	rs := []rune(s)
+	i := 0
+	for _, r := range sub {
+		for i < len(rs) && rs[i] != r {
+			i++
+		}
+		if i == len(rs) {
+			return false
+		}
+		i++
+	}
+	return true
+}
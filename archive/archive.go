@@ -0,0 +1,95 @@
+// Package archive packs directories into zip and tar.gz files and unpacks
+// them again, refusing entries that would land outside the target
+// directory ("zip slip").
+package archive
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// MaxFileSize caps the size of a single extracted file, so a crafted
+// archive cannot fill the disk. It applies to Unzip and UntarGz.
+var MaxFileSize int64 = 1 << 30
+
+// safeJoin returns dst/name, or an error if name is absolute or climbs
+// out of dst with "..".
+func safeJoin(dst, name string) (string, error) {
+	name = filepath.FromSlash(name)
+	if filepath.IsAbs(name) || !filepath.IsLocal(name) {
+		return "", fmt.Errorf("archive: illegal path %q", name)
+	}
+	return filepath.Join(dst, name), nil
+}
+
+// walk calls fn for every regular file and directory under src with its
+// slash-separated path relative to src. Symlinks and other special files
+// are skipped, and so are the files in skip, so an archive written inside
+// src (and its temporary file) does not include itself.
+func walk(src string, skip []string, fn func(path, rel string, d fs.DirEntry) error) error {
+	skipAbs := make(map[string]bool, len(skip))
+	for _, p := range skip {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+		skipAbs[abs] = true
+	}
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if abs, err := filepath.Abs(path); err == nil && skipAbs[abs] {
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+		return fn(path, filepath.ToSlash(rel), d)
+	})
+}
+
+// writeFile copies r to path, creating parent directories and failing if
+// more than MaxFileSize bytes arrive.
+func writeFile(path string, r io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0o600)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, io.LimitReader(r, MaxFileSize+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > MaxFileSize {
+		err = fmt.Errorf("archive: %s is larger than %d bytes", path, MaxFileSize)
+	}
+	return err
+}
+
+// createAtomic writes an archive to a temporary file next to dst and
+// renames it into place only if write succeeds.
+func createAtomic(dst string, write func(tmp *os.File) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
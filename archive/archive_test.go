@@ -0,0 +1,184 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// tree creates files (name -> contents) under a new temp dir.
+func tree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// contents reads every regular file under dir.
+func contents(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	out := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		rel, _ := filepath.Rel(dir, path)
+		out[filepath.ToSlash(rel)] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+var formats = []struct {
+	ext          string
+	pack, unpack func(src, dst string) error
+}{
+	{".zip", ZipDir, Unzip},
+	{".tar.gz", TarGzDir, UntarGz},
+}
+
+func TestRoundTrip(t *testing.T) {
+	files := map[string]string{
+		"a.txt":         "alpha",
+		"sub/b.txt":     "beta",
+		"sub/deep/c.go": "package c",
+		"empty":         "",
+	}
+	for _, f := range formats {
+		t.Run(f.ext, func(t *testing.T) {
+			src := tree(t, files)
+			arc := filepath.Join(t.TempDir(), "out"+f.ext)
+			if err := f.pack(src, arc); err != nil {
+				t.Fatal(err)
+			}
+			dst := t.TempDir()
+			if err := f.unpack(arc, dst); err != nil {
+				t.Fatal(err)
+			}
+			got := contents(t, dst)
+			if len(got) != len(files) {
+				t.Fatalf("extracted %v, want %v", got, files)
+			}
+			for name, data := range files {
+				if got[name] != data {
+					t.Errorf("%s = %q, want %q", name, got[name], data)
+				}
+			}
+		})
+	}
+}
+
+// TestArchiveInsideSource writes the archive into the directory being
+// archived: the archive itself must be left out, but a sibling whose
+// name merely starts with the archive's name must not be.
+func TestArchiveInsideSource(t *testing.T) {
+	for _, f := range formats {
+		t.Run(f.ext, func(t *testing.T) {
+			src := tree(t, map[string]string{
+				"keep.txt":              "keep",
+				"backup" + f.ext + ".1": "older backup",
+			})
+			arc := filepath.Join(src, "backup"+f.ext)
+			if err := f.pack(src, arc); err != nil {
+				t.Fatal(err)
+			}
+			dst := t.TempDir()
+			if err := f.unpack(arc, dst); err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for name := range contents(t, dst) {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			want := []string{"backup" + f.ext + ".1", "keep.txt"}
+			if !slices.Equal(names, want) {
+				t.Fatalf("archive holds %v, want %v", names, want)
+			}
+		})
+	}
+}
+
+var evilNames = []string{"../evil.txt", "sub/../../evil.txt", "/tmp/evil.txt"}
+
+func TestUnzipRejectsSlip(t *testing.T) {
+	for _, name := range evilNames {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte("pwned"))
+		zw.Close()
+
+		arc := filepath.Join(t.TempDir(), "evil.zip")
+		os.WriteFile(arc, buf.Bytes(), 0o644)
+		dst := filepath.Join(t.TempDir(), "out")
+		err = Unzip(arc, dst)
+		if err == nil || !strings.Contains(err.Error(), "illegal path") {
+			t.Errorf("Unzip with entry %q: error %v, want an illegal path error", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(filepath.Dir(dst), "evil.txt")); err == nil {
+			t.Errorf("entry %q was written outside the target directory", name)
+		}
+	}
+}
+
+func TestUntarGzRejectsSlip(t *testing.T) {
+	for _, name := range evilNames {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 5, Typeflag: tar.TypeReg})
+		tw.Write([]byte("pwned"))
+		tw.Close()
+		gz.Close()
+
+		arc := filepath.Join(t.TempDir(), "evil.tar.gz")
+		os.WriteFile(arc, buf.Bytes(), 0o644)
+		dst := filepath.Join(t.TempDir(), "out")
+		err := UntarGz(arc, dst)
+		if err == nil || !strings.Contains(err.Error(), "illegal path") {
+			t.Errorf("UntarGz with entry %q: error %v, want an illegal path error", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(filepath.Dir(dst), "evil.txt")); err == nil {
+			t.Errorf("entry %q was written outside the target directory", name)
+		}
+	}
+}
+
+func TestMaxFileSize(t *testing.T) {
+	old := MaxFileSize
+	MaxFileSize = 4
+	defer func() { MaxFileSize = old }()
+
+	for _, f := range formats {
+		src := tree(t, map[string]string{"big.txt": "too large"})
+		arc := filepath.Join(t.TempDir(), "out"+f.ext)
+		if err := f.pack(src, arc); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.unpack(arc, t.TempDir()); err == nil {
+			t.Errorf("%s: extracting a file over MaxFileSize succeeded", f.ext)
+		}
+	}
+}
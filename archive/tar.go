@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// TarGzDir writes every file under src into a new gzipped tarball at dst.
+// Paths in the archive are relative to src.
+func TarGzDir(src, dst string) error {
+	return createAtomic(dst, func(tmp *os.File) error {
+		gz := gzip.NewWriter(tmp)
+		tw := tar.NewWriter(gz)
+		err := walk(src, []string{dst, tmp.Name()}, func(path, rel string, d fs.DirEntry) error {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			hdr, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			hdr.Name = rel
+			if d.IsDir() {
+				hdr.Name += "/"
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		if cerr := gz.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	})
+}
+
+// UntarGz extracts the gzipped tarball src into the directory dst.
+func UntarGz(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path, err := safeJoin(dst, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(path, tr, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		default:
+			// links and devices are skipped for the same reason as in Unzip
+		}
+	}
+}
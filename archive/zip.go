@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// ZipDir writes every file under src into a new zip file at dst. Paths in
+// the archive are relative to src.
+func ZipDir(src, dst string) error {
+	return createAtomic(dst, func(tmp *os.File) error {
+		zw := zip.NewWriter(tmp)
+		err := walk(src, []string{dst, tmp.Name()}, func(path, rel string, d fs.DirEntry) error {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			hdr, err := zip.FileInfoHeader(info)
+			if err != nil {
+				return err
+			}
+			hdr.Name = rel
+			if d.IsDir() {
+				hdr.Name += "/"
+				_, err = zw.CreateHeader(hdr)
+				return err
+			}
+			hdr.Method = zip.Deflate
+			fw, err := zw.CreateHeader(hdr)
+			if err != nil {
+				return err
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(fw, f)
+			return err
+		})
+		if err != nil {
+			zw.Close()
+			return err
+		}
+		return zw.Close()
+	})
+}
+
+// Unzip extracts the zip file src into the directory dst.
+func Unzip(src, dst string) error {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		path, err := safeJoin(dst, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue // no symlinks: they could point anywhere
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(path, rc, f.Mode())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+// Package bank is a small exercise in locking: accounts guarded by their
+// own mutex, and transfers that must lock two of them at once without
+// deadlocking.
+//
+// Two transfers in opposite directions, A→B and B→A, each locking the
+// source first, can each grab one lock and wait forever for the other.
+// Transfer avoids that by always locking the account with the lower ID
+// first, so every goroutine takes locks in the same global order and no
+// cycle of waiters can form.
+package bank
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Errors returned by Transfer.
+var (
+	ErrInsufficientFunds = errors.New("bank: insufficient funds")
+	ErrInvalidAmount     = errors.New("bank: amount must be positive")
+	ErrSameAccount       = errors.New("bank: cannot transfer to the same account")
+	ErrUnknownAccount    = errors.New("bank: account belongs to another bank")
+	ErrClosed            = errors.New("bank: closed")
+)
+
+// Account holds a balance in cents.
+type Account struct {
+	id   int
+	bank *Bank
+
+	mu      sync.Mutex
+	balance int64
+}
+
+// ID identifies the account; it also fixes its place in the lock order.
+func (a *Account) ID() int { return a.id }
+
+// Balance returns the current balance.
+func (a *Account) Balance() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.balance
+}
+
+// Event is one line of the audit log: a transfer, successful or not.
+type Event struct {
+	Time     time.Time
+	From, To int
+	Amount   int64
+	Err      error // nil if the money moved
+}
+
+func (e Event) String() string {
+	s := fmt.Sprintf("%s %d -> %d: %d", e.Time.Format("15:04:05.000"), e.From, e.To, e.Amount)
+	if e.Err != nil {
+		s += " failed: " + e.Err.Error()
+	}
+	return s
+}
+
+// Bank owns a set of accounts and the audit log of transfers between
+// them.
+type Bank struct {
+	// mu is held for reading by every transfer and for writing by Open
+	// and Close, so the audit channel is never closed under a sender
+	mu       sync.RWMutex
+	accounts []*Account
+	audit    chan Event
+	closed   bool
+}
+
+type config struct {
+	auditBuffer int
+}
+
+// Option configures a Bank.
+type Option func(*config)
+
+// WithAuditBuffer sets the capacity of the audit channel (default 64).
+func WithAuditBuffer(n int) Option { return func(c *config) { c.auditBuffer = n } }
+
+// New returns a bank with no accounts. Someone must read Audit: a
+// transfer does not return until its event has been sent, so the log
+// can't silently lose entries.
+func New(opts ...Option) *Bank {
+	cfg := config{auditBuffer: 64}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return &Bank{audit: make(chan Event, cfg.auditBuffer)}
+}
+
+// Open creates an account holding initial cents.
+func (b *Bank) Open(initial int64) (*Account, error) {
+	if initial < 0 {
+		return nil, ErrInvalidAmount
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	a := &Account{id: len(b.accounts) + 1, bank: b, balance: initial}
+	b.accounts = append(b.accounts, a)
+	return a, nil
+}
+
+// Audit returns the audit log. It is closed by Close.
+func (b *Bank) Audit() <-chan Event { return b.audit }
+
+// Transfer moves amount cents from one account to another, or returns an
+// error and moves nothing. Every attempt is logged to Audit.
+func (b *Bank) Transfer(from, to *Account, amount int64) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrClosed
+	}
+	err := b.transfer(from, to, amount)
+	// sent after the account locks are released, so a slow reader of the
+	// log holds up only this goroutine, not everyone waiting on them
+	b.audit <- Event{Time: time.Now(), From: from.id, To: to.id, Amount: amount, Err: err}
+	return err
+}
+
+func (b *Bank) transfer(from, to *Account, amount int64) error {
+	switch {
+	case from.bank != b || to.bank != b:
+		return ErrUnknownAccount
+	case from == to:
+		return ErrSameAccount
+	case amount <= 0:
+		return ErrInvalidAmount
+	}
+	first, second := from, to
+	if second.id < first.id {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	if from.balance < amount {
+		return fmt.Errorf("%w: account %d has %d, needs %d", ErrInsufficientFunds, from.id, from.balance, amount)
+	}
+	from.balance -= amount
+	to.balance += amount
+	return nil
+}
+
+// Total returns the sum of all balances at one instant. It locks every
+// account, in ID order like Transfer, so no transfer is half seen.
+func (b *Bank) Total() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, a := range b.accounts {
+		a.mu.Lock()
+	}
+	var sum int64
+	for _, a := range b.accounts {
+		sum += a.balance
+		a.mu.Unlock()
+	}
+	return sum
+}
+
+// Accounts returns the accounts in ID order.
+func (b *Bank) Accounts() []*Account {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]*Account(nil), b.accounts...)
+}
+
+// Close waits for transfers in progress, then closes Audit. Later
+// transfers return ErrClosed.
+func (b *Bank) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.audit)
+	}
+}
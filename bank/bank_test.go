@@ -0,0 +1,173 @@
+package bank_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/bank"
+)
+
+const initial = 100_000 // cents per account
+
+// open returns a bank with n accounts of initial cents and a channel that
+// yields the audit log once the bank is closed.
+func open(t *testing.T, n int) (*bank.Bank, []*bank.Account, <-chan []bank.Event) {
+	t.Helper()
+	b := bank.New()
+	for range n {
+		if _, err := b.Open(initial); err != nil {
+			t.Fatal(err)
+		}
+	}
+	log := make(chan []bank.Event, 1)
+	go func() {
+		var events []bank.Event
+		for e := range b.Audit() {
+			events = append(events, e)
+		}
+		log <- events
+	}()
+	return b, b.Accounts(), log
+}
+
+func TestTransfer(t *testing.T) {
+	b, accts, log := open(t, 2)
+	other := bank.New()
+	stranger, _ := other.Open(initial)
+
+	tests := []struct {
+		from, to *bank.Account
+		amount   int64
+		err      error
+	}{
+		{accts[0], accts[1], 400, nil},
+		{accts[0], accts[1], initial, bank.ErrInsufficientFunds},
+		{accts[0], accts[0], 1, bank.ErrSameAccount},
+		{accts[0], accts[1], 0, bank.ErrInvalidAmount},
+		{accts[0], stranger, 1, bank.ErrUnknownAccount},
+	}
+	for _, tt := range tests {
+		if err := b.Transfer(tt.from, tt.to, tt.amount); !errors.Is(err, tt.err) {
+			t.Errorf("Transfer(%d, %d, %d) = %v, want %v", tt.from.ID(), tt.to.ID(), tt.amount, err, tt.err)
+		}
+	}
+	if accts[0].Balance() != initial-400 || accts[1].Balance() != initial+400 {
+		t.Errorf("balances %d, %d", accts[0].Balance(), accts[1].Balance())
+	}
+
+	b.Close()
+	if err := b.Transfer(accts[0], accts[1], 1); !errors.Is(err, bank.ErrClosed) {
+		t.Errorf("Transfer after Close = %v", err)
+	}
+	if events := <-log; len(events) != len(tests) || events[0].Err != nil || events[1].Err == nil {
+		t.Errorf("audit log = %v", events)
+	}
+}
+
+// TestTransferConcurrent hammers the bank with transfers and checks that
+// money is neither created nor destroyed, no balance goes negative, the
+// audit log accounts for every cent, and opposite transfers between the
+// same accounts never deadlock. Run it with -race.
+func TestTransferConcurrent(t *testing.T) {
+	const (
+		accounts  = 8
+		workers   = 16
+		transfers = 1000
+	)
+	seed := rand.Uint64()
+	t.Logf("seed %d", seed)
+
+	b, accts, log := open(t, accounts)
+	want := int64(accounts) * initial
+
+	// check the total and the balances while transfers run
+	stop := make(chan struct{})
+	var snapshots, badTotals, negatives atomic.Int64
+	checkDone := make(chan struct{})
+	go func() {
+		defer close(checkDone)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if b.Total() != want {
+				badTotals.Add(1)
+			}
+			for _, a := range accts {
+				if a.Balance() < 0 {
+					negatives.Add(1)
+				}
+			}
+			snapshots.Add(1)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		r := rand.New(rand.NewPCG(seed, uint64(w)))
+		wg.Go(func() {
+			for range transfers {
+				// the first two accounts get half the traffic, in both
+				// directions: the pattern that deadlocks without lock ordering
+				var from, to *bank.Account
+				if r.IntN(2) == 0 {
+					i := r.IntN(2)
+					from, to = accts[i], accts[1-i]
+				} else {
+					from, to = accts[r.IntN(len(accts))], accts[r.IntN(len(accts))]
+				}
+				err := b.Transfer(from, to, 1+r.Int64N(initial/10))
+				if err != nil && !errors.Is(err, bank.ErrInsufficientFunds) && !errors.Is(err, bank.ErrSameAccount) {
+					t.Errorf("Transfer: %v", err)
+					return
+				}
+			}
+		})
+	}
+	finished := make(chan struct{})
+	go func() { wg.Wait(); close(finished) }()
+	select {
+	case <-finished:
+	case <-time.After(time.Minute):
+		t.Fatal("transfers still running after a minute: deadlock?")
+	}
+	close(stop)
+	<-checkDone
+	b.Close()
+	events := <-log
+
+	if n := badTotals.Load(); n > 0 {
+		t.Fatalf("total was off in %d of %d snapshots", n, snapshots.Load())
+	}
+	if got := b.Total(); got != want {
+		t.Fatalf("total is %d, want %d", got, want)
+	}
+	if n := negatives.Load(); n > 0 {
+		t.Fatalf("%d negative balances seen", n)
+	}
+	if len(events) != workers*transfers {
+		t.Fatalf("audit log has %d transfers, want %d", len(events), workers*transfers)
+	}
+	// replay the audit log into a ledger of its own
+	ledger := make(map[int]int64)
+	for _, a := range accts {
+		ledger[a.ID()] = initial
+	}
+	for _, e := range events {
+		if e.Err == nil {
+			ledger[e.From] -= e.Amount
+			ledger[e.To] += e.Amount
+		}
+	}
+	for _, a := range accts {
+		if ledger[a.ID()] != a.Balance() {
+			t.Fatalf("account %d: balance %d, audit log says %d", a.ID(), a.Balance(), ledger[a.ID()])
+		}
+	}
+}
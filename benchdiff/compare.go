@@ -0,0 +1,255 @@
+package benchdiff
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Alpha is the p-value below which a change counts as significant.
+const Alpha = 0.05
+
+// MinSamples is the fewest runs per side for which significance is
+// reported. With fewer, even a consistent change can't reach Alpha.
+const MinSamples = 4
+
+// Summary describes the samples of one metric.
+type Summary struct {
+	N      int
+	Mean   float64
+	Spread float64 // standard deviation relative to the mean, 0.03 is ±3%
+}
+
+// Summarize computes the Summary of xs.
+func Summarize(xs []float64) Summary {
+	s := Summary{N: len(xs)}
+	if s.N == 0 {
+		return s
+	}
+	for _, x := range xs {
+		s.Mean += x
+	}
+	s.Mean /= float64(s.N)
+	if s.N > 1 && s.Mean != 0 {
+		var ss float64
+		for _, x := range xs {
+			ss += (x - s.Mean) * (x - s.Mean)
+		}
+		s.Spread = math.Sqrt(ss/float64(s.N-1)) / math.Abs(s.Mean)
+	}
+	return s
+}
+
+// Row compares one metric of one benchmark.
+type Row struct {
+	Name, Unit  string
+	Old, New    Summary // N is 0 on the side the benchmark is missing from
+	Delta       float64 // (new-old)/old; NaN if either side is missing or old is 0
+	P           float64 // Mann-Whitney U p-value; NaN without enough samples
+	Significant bool
+}
+
+// Hint explains the delta: whether it can be trusted and why.
+func (r Row) Hint() string {
+	switch {
+	case r.Old.N == 0:
+		return "only in new"
+	case r.New.N == 0:
+		return "only in old"
+	case r.Old.N < MinSamples || r.New.N < MinSamples:
+		return fmt.Sprintf("too few runs (n=%d+%d); use -count=%d or more", r.Old.N, r.New.N, MinSamples+1)
+	case r.Delta == 0 && r.Old.Spread == 0 && r.New.Spread == 0:
+		return "identical"
+	case !r.Significant:
+		return fmt.Sprintf("likely noise (p=%.2f)", r.P)
+	}
+	return fmt.Sprintf("p=%.3f n=%d+%d", r.P, r.Old.N, r.New.N)
+}
+
+// Compare pairs the benchmarks of old and new by name and compares every
+// unit either side reports. Rows are ordered by unit (time, then memory,
+// then allocations, then anything else) and within a unit by the order
+// of old followed by benchmarks only in new.
+func Compare(old, new Set) []Row {
+	names := make([]string, 0, len(old)+len(new))
+	for _, b := range old {
+		names = append(names, b.Name)
+	}
+	for _, b := range new {
+		if old.Lookup(b.Name) == nil {
+			names = append(names, b.Name)
+		}
+	}
+
+	var rows []Row
+	for _, name := range names {
+		ob, nb := old.Lookup(name), new.Lookup(name)
+		for _, unit := range units(ob, nb) {
+			var xs, ys []float64
+			if ob != nil {
+				xs = ob.Samples[unit]
+			}
+			if nb != nil {
+				ys = nb.Samples[unit]
+			}
+			r := Row{Name: name, Unit: unit, Old: Summarize(xs), New: Summarize(ys), Delta: math.NaN(), P: math.NaN()}
+			if r.Old.N > 0 && r.New.N > 0 && r.Old.Mean != 0 {
+				r.Delta = (r.New.Mean - r.Old.Mean) / r.Old.Mean
+			}
+			if r.Old.N >= MinSamples && r.New.N >= MinSamples {
+				r.P = MannWhitney(xs, ys)
+				r.Significant = r.P < Alpha
+			}
+			rows = append(rows, r)
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b Row) int { return cmp.Compare(unitRank(a.Unit), unitRank(b.Unit)) })
+	return rows
+}
+
+func units(bs ...*Benchmark) []string {
+	var us []string
+	for _, b := range bs {
+		if b == nil {
+			continue
+		}
+		for u := range b.Samples {
+			if !slices.Contains(us, u) {
+				us = append(us, u)
+			}
+		}
+	}
+	slices.SortFunc(us, func(a, b string) int {
+		return cmp.Or(cmp.Compare(unitRank(a), unitRank(b)), strings.Compare(a, b))
+	})
+	return us
+}
+
+func unitRank(u string) int {
+	switch u {
+	case "ns/op":
+		return 0
+	case "B/op":
+		return 1
+	case "allocs/op":
+		return 2
+	}
+	return 3
+}
+
+// MannWhitney returns the two-sided p-value of the Mann-Whitney U test
+// that xs and ys come from the same distribution, using the normal
+// approximation with a tie correction. Unlike a t-test it doesn't assume
+// the samples are normal, which benchmark timings rarely are.
+func MannWhitney(xs, ys []float64) float64 {
+	n1, n2 := float64(len(xs)), float64(len(ys))
+	type obs struct {
+		v     float64
+		fromX bool
+	}
+	all := make([]obs, 0, len(xs)+len(ys))
+	for _, x := range xs {
+		all = append(all, obs{x, true})
+	}
+	for _, y := range ys {
+		all = append(all, obs{y, false})
+	}
+	slices.SortFunc(all, func(a, b obs) int { return cmp.Compare(a.v, b.v) })
+
+	// rank, giving tied values the average of their ranks
+	var r1, ties float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2 // ranks i+1..j
+		for k := i; k < j; k++ {
+			if all[k].fromX {
+				r1 += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	u := r1 - n1*(n1+1)/2
+	n := n1 + n2
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		return 1 // every value is the same
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	return math.Min(1, math.Erfc(math.Max(z, 0)/math.Sqrt2))
+}
+
+// Write prints rows as one table per unit.
+func Write(w io.Writer, rows []Row) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	unit := ""
+	for _, r := range rows {
+		if r.Unit != unit {
+			if unit != "" {
+				fmt.Fprintln(tw)
+			}
+			unit = r.Unit
+			fmt.Fprintf(tw, "name\told %s\tnew %s\tdelta\t\n", unit, unit)
+		}
+		delta := "-"
+		if !math.IsNaN(r.Delta) {
+			delta = fmt.Sprintf("%+.1f%%", r.Delta*100)
+			if r.Old.N >= MinSamples && r.New.N >= MinSamples && !r.Significant {
+				delta = "~"
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Name, cell(r.Old, r.Unit), cell(r.New, r.Unit), delta, r.Hint())
+	}
+	return tw.Flush()
+}
+
+func cell(s Summary, unit string) string {
+	if s.N == 0 {
+		return "-"
+	}
+	v := format(s.Mean, unit)
+	if s.N > 1 {
+		v += fmt.Sprintf(" ±%.0f%%", s.Spread*100)
+	}
+	return v
+}
+
+// format prints v in a unit-appropriate scale: 1.23µs rather than 1230
+// ns/op, 4.0KiB rather than 4096 B/op.
+func format(v float64, unit string) string {
+	switch unit {
+	case "ns/op":
+		for _, s := range []struct {
+			div  float64
+			name string
+		}{{1e9, "s"}, {1e6, "ms"}, {1e3, "µs"}} {
+			if v >= s.div {
+				return fmt.Sprintf("%.3g%s", v/s.div, s.name)
+			}
+		}
+		return fmt.Sprintf("%.3gns", v)
+	case "B/op":
+		for _, s := range []struct {
+			div  float64
+			name string
+		}{{1 << 30, "GiB"}, {1 << 20, "MiB"}, {1 << 10, "KiB"}} {
+			if v >= s.div {
+				return fmt.Sprintf("%.3g%s", v/s.div, s.name)
+			}
+		}
+		return fmt.Sprintf("%.0fB", v)
+	case "allocs/op":
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.4g", v)
+}
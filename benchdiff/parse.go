@@ -0,0 +1,98 @@
+// Package benchdiff compares two sets of go test -bench results: it
+// parses the output, pairs up benchmarks by name and reports the change
+// in every metric, with a Mann-Whitney U test to say whether the change
+// is more than noise. It is a small cousin of golang.org/x/perf's
+// benchstat with no dependencies.
+//
+// Run the benchmarks with -count of 5 or more so there are enough samples
+// to tell a change from noise.
+package benchdiff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Benchmark is every run of one benchmark.
+type Benchmark struct {
+	Name string // without the "Benchmark" prefix and -GOMAXPROCS suffix
+
+	// Samples maps unit (ns/op, B/op, allocs/op, MB/s, ...) to one value
+	// per run.
+	Samples map[string][]float64
+}
+
+// Set is the benchmarks of one go test -bench output, in the order they
+// first appeared.
+type Set []*Benchmark
+
+// Lookup returns the benchmark called name, or nil.
+func (s Set) Lookup(name string) *Benchmark {
+	for _, b := range s {
+		if b.Name == name {
+			return b
+		}
+	}
+	return nil
+}
+
+// Parse reads go test -bench output. Lines that are not benchmark results
+// (PASS, ok, goos: ...) are skipped, so the output of several packages
+// can be concatenated.
+func Parse(r io.Reader) (Set, error) {
+	var s Set
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		name, metrics, ok := parseLine(sc.Text())
+		if !ok {
+			continue
+		}
+		b := s.Lookup(name)
+		if b == nil {
+			b = &Benchmark{Name: name, Samples: map[string][]float64{}}
+			s = append(s, b)
+		}
+		for _, m := range metrics {
+			b.Samples[m.unit] = append(b.Samples[m.unit], m.value)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("benchdiff: %w", err)
+	}
+	return s, nil
+}
+
+type metric struct {
+	value float64
+	unit  string
+}
+
+// parseLine parses
+//
+//	BenchmarkDecode/small-8   	  120000	      9876 ns/op	    512 B/op	       7 allocs/op
+func parseLine(line string) (name string, metrics []metric, ok bool) {
+	f := strings.Fields(line)
+	if len(f) < 4 || !strings.HasPrefix(f[0], "Benchmark") || len(f)%2 != 0 {
+		return "", nil, false
+	}
+	if _, err := strconv.Atoi(f[1]); err != nil {
+		return "", nil, false // the iteration count
+	}
+	for i := 2; i+1 < len(f); i += 2 {
+		v, err := strconv.ParseFloat(f[i], 64)
+		if err != nil {
+			return "", nil, false
+		}
+		metrics = append(metrics, metric{v, f[i+1]})
+	}
+	name = strings.TrimPrefix(f[0], "Benchmark")
+	if i := strings.LastIndexByte(name, '-'); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			name = name[:i]
+		}
+	}
+	return name, metrics, true
+}
@@ -0,0 +1,95 @@
+package benchdiff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Options controls how the benchmarks are run.
+type Options struct {
+	Bench     string    // -bench regexp; "." if empty
+	Count     int       // -count; 1 if zero
+	Benchtime string    // -benchtime, if set
+	Log       io.Writer // if set, receives go test's output as it runs
+}
+
+// Run runs the benchmarks of pkgs with go test in dir and parses the
+// result. Memory statistics are always collected (-benchmem) and tests
+// are skipped (-run '^$').
+func Run(ctx context.Context, dir string, pkgs []string, opt Options) (Set, error) {
+	bench := opt.Bench
+	if bench == "" {
+		bench = "."
+	}
+	args := []string{"test", "-run", "^$", "-bench", bench, "-benchmem", "-count", strconv.Itoa(max(opt.Count, 1))}
+	if opt.Benchtime != "" {
+		args = append(args, "-benchtime", opt.Benchtime)
+	}
+	cmd := exec.CommandContext(ctx, "go", append(args, pkgs...)...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if opt.Log != nil {
+		cmd.Stdout = io.MultiWriter(&out, opt.Log)
+		cmd.Stderr = cmd.Stdout
+	}
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("benchdiff: go test %s: %w\n%s", strings.Join(pkgs, " "), err, lastLines(out.String(), 20))
+	}
+	return Parse(&out)
+}
+
+// RunAtRef is Run against the tree at a git ref (a branch, tag or commit)
+// of the repository containing dir. The ref is checked out into a
+// temporary worktree, so the working copy is left alone; dir keeps its
+// place relative to the repository root, so relative package paths mean
+// the same as in Run.
+func RunAtRef(ctx context.Context, dir, ref string, pkgs []string, opt Options) (Set, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	top, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(top, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "benchdiff-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	wt := filepath.Join(tmp, "tree")
+	if _, err := git(ctx, top, "worktree", "add", "--detach", "--quiet", wt, ref); err != nil {
+		return nil, err
+	}
+	defer git(context.WithoutCancel(ctx), top, "worktree", "remove", "--force", wt)
+
+	return Run(ctx, filepath.Join(wt, rel), pkgs, opt)
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("benchdiff: git %s: %w: %s", args[0], err, bytes.TrimSpace(out))
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	return strings.Join(lines[max(0, len(lines)-n):], "\n")
+}
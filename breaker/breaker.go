@@ -0,0 +1,161 @@
+// Package breaker implements the circuit breaker pattern.
+//
+// A Breaker starts Closed and lets calls through. After Threshold
+// consecutive failures it trips Open and rejects calls with ErrOpen. Once
+// CoolDown has elapsed it becomes HalfOpen and lets a single trial call
+// through: success closes the circuit, failure opens it again.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Execute while the circuit is open.
+var ErrOpen = errors.New("breaker: circuit open")
+
+// State is the state of a Breaker.
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Config holds the tuning knobs of a Breaker.
+type Config struct {
+	Threshold int           // consecutive failures that trip the circuit
+	CoolDown  time.Duration // time spent open before a trial call
+
+	// OnStateChange, if set, is called after every transition.
+	OnStateChange func(from, to State)
+
+	// Now replaces time.Now, mainly for tests.
+	Now func() time.Time
+}
+
+// Breaker guards calls to a fallible dependency. It is safe for concurrent use.
+type Breaker struct {
+	cfg Config
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial call is in flight
+}
+
+// New returns a closed Breaker. Zero values in cfg default to a threshold
+// of 5 failures and a 30 second cool-down.
+func New(cfg Config) *Breaker {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = 5
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = 30 * time.Second
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &Breaker{cfg: cfg}
+}
+
+// State returns the current state, moving from Open to HalfOpen if the
+// cool-down has elapsed.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tick()
+	return b.state
+}
+
+// Execute runs fn if the circuit allows it and records the outcome.
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.before(); err != nil {
+		return err
+	}
+	err := fn()
+	b.after(err)
+	return err
+}
+
+func (b *Breaker) before() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tick()
+	switch b.state {
+	case Open:
+		return ErrOpen
+	case HalfOpen:
+		if b.trial {
+			return ErrOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+func (b *Breaker) after(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == HalfOpen {
+		b.trial = false
+		if err != nil {
+			b.trip()
+		} else {
+			b.failures = 0
+			b.setState(Closed)
+		}
+		return
+	}
+
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.cfg.Threshold {
+		b.trip()
+	}
+}
+
+// tick moves an expired Open circuit to HalfOpen. b.mu must be held.
+func (b *Breaker) tick() {
+	if b.state == Open && b.cfg.Now().Sub(b.openedAt) >= b.cfg.CoolDown {
+		b.setState(HalfOpen)
+	}
+}
+
+func (b *Breaker) trip() {
+	b.failures = 0
+	b.openedAt = b.cfg.Now()
+	b.setState(Open)
+}
+
+func (b *Breaker) setState(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
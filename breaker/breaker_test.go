@@ -0,0 +1,124 @@
+package breaker
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("boom")
+
+// clock is a fake time source that only moves when told to.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time          { return c.now }
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newBreaker(t *testing.T) (*Breaker, *clock, *[]string) {
+	t.Helper()
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var transitions []string
+	b := New(Config{
+		Threshold: 3,
+		CoolDown:  time.Minute,
+		Now:       c.Now,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	return b, c, &transitions
+}
+
+func fail() error    { return errBoom }
+func succeed() error { return nil }
+
+func TestTransitions(t *testing.T) {
+	b, c, transitions := newBreaker(t)
+
+	// failures below the threshold keep it closed; a success resets the count
+	b.Execute(fail)
+	b.Execute(fail)
+	b.Execute(succeed)
+	b.Execute(fail)
+	b.Execute(fail)
+	if s := b.State(); s != Closed {
+		t.Fatalf("after 2 failures since a success: state %v, want closed", s)
+	}
+
+	// the third consecutive failure trips it
+	b.Execute(fail)
+	if s := b.State(); s != Open {
+		t.Fatalf("after 3 consecutive failures: state %v, want open", s)
+	}
+	called := false
+	if err := b.Execute(func() error { called = true; return nil }); !errors.Is(err, ErrOpen) || called {
+		t.Fatalf("open breaker: err %v, called %v; want ErrOpen without calling", err, called)
+	}
+
+	// still open just before the cool-down ends
+	c.Advance(time.Minute - time.Second)
+	if s := b.State(); s != Open {
+		t.Fatalf("before the cool-down: state %v, want open", s)
+	}
+	c.Advance(time.Second)
+	if s := b.State(); s != HalfOpen {
+		t.Fatalf("after the cool-down: state %v, want half-open", s)
+	}
+
+	// a failed trial opens it again for a full cool-down
+	if err := b.Execute(fail); !errors.Is(err, errBoom) {
+		t.Fatalf("trial call: err %v, want %v", err, errBoom)
+	}
+	if s := b.State(); s != Open {
+		t.Fatalf("after a failed trial: state %v, want open", s)
+	}
+
+	// a successful trial closes it
+	c.Advance(time.Minute)
+	if err := b.Execute(succeed); err != nil {
+		t.Fatalf("trial call: %v", err)
+	}
+	if s := b.State(); s != Closed {
+		t.Fatalf("after a successful trial: state %v, want closed", s)
+	}
+
+	want := []string{
+		"closed->open", "open->half-open", "half-open->open",
+		"open->half-open", "half-open->closed",
+	}
+	if !slices.Equal(*transitions, want) {
+		t.Errorf("transitions %v, want %v", *transitions, want)
+	}
+}
+
+func TestHalfOpenAllowsOneTrial(t *testing.T) {
+	b, c, _ := newBreaker(t)
+	for range 3 {
+		b.Execute(fail)
+	}
+	c.Advance(time.Minute)
+
+	// while the trial is in flight, other calls are rejected
+	var inner error
+	err := b.Execute(func() error {
+		inner = b.Execute(succeed)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("trial call: %v", err)
+	}
+	if !errors.Is(inner, ErrOpen) {
+		t.Fatalf("call during the trial: err %v, want ErrOpen", inner)
+	}
+	if s := b.State(); s != Closed {
+		t.Fatalf("after the trial: state %v, want closed", s)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	b := New(Config{})
+	if b.cfg.Threshold != 5 || b.cfg.CoolDown != 30*time.Second || b.cfg.Now == nil {
+		t.Errorf("defaults: threshold %d, cool-down %v, Now set %v", b.cfg.Threshold, b.cfg.CoolDown, b.cfg.Now != nil)
+	}
+}
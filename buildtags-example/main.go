@@ -0,0 +1,27 @@
+// Build tags: the same program compiled differently depending on the
+// platform and on -tags.
+//
+//	go run ./buildtags-example
+//	go run -tags debug ./buildtags-example     # traces every slice helper call
+//	GOOS=windows go build ./buildtags-example  # picks path_windows.go
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/armaanepiic/Golang/buildtags"
+)
+
+func main() {
+	fmt.Printf("built for %s/%s in %s mode\n", runtime.GOOS, runtime.GOARCH, buildtags.Mode)
+	fmt.Printf("path separator %q, list separator %q\n", buildtags.Separator, buildtags.ListSeparator)
+	fmt.Println("temp dir:", buildtags.TempDir())
+	fmt.Println("joined:", buildtags.Join("home", "", "gopher", "notes.txt"))
+
+	words := strings.Fields("go build go vet go test go run")
+	short := buildtags.Filter(words, func(w string) bool { return len(w) <= 3 })
+	upper := buildtags.Map(buildtags.Uniq(short), strings.ToUpper)
+	fmt.Println("commands:", upper)
+}
@@ -0,0 +1,54 @@
+// Package buildtags shows the two uses of conditional compilation: files
+// that only build on some platforms, and files chosen with -tags.
+//
+// The platform files are path_unix.go (a //go:build unix line),
+// path_windows.go (no line at all: a _windows suffix on the file name is
+// a constraint by itself) and path_other.go for everything else. Each
+// defines the same names, so exactly one must build on any GOOS.
+//
+// The slice helpers come in two builds of the same API:
+//
+//	go run ./buildtags-example               # fast: the default
+//	go run -tags debug ./buildtags-example   # debug: every call is traced
+//
+// If both tags are given, fast wins.
+package buildtags
+
+import (
+	"strings"
+
+	"github.com/armaanepiic/Golang/internal/slicesx"
+)
+
+// Join joins path elements with this platform's Separator, skipping
+// empty ones. Unlike filepath.Join it does not clean the result.
+func Join(elem ...string) string {
+	var parts []string
+	for _, e := range elem {
+		if e != "" {
+			parts = append(parts, e)
+		}
+	}
+	return strings.Join(parts, string(Separator))
+}
+
+// Map is slicesx.Map, traced in debug builds.
+func Map[S ~[]E, E, R any](s S, f func(E) R) []R {
+	out := slicesx.Map(s, f)
+	trace("Map", len(s), len(out))
+	return out
+}
+
+// Filter is slicesx.Filter, traced in debug builds.
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	out := slicesx.Filter(s, keep)
+	trace("Filter", len(s), len(out))
+	return out
+}
+
+// Uniq is slicesx.Uniq, traced in debug builds.
+func Uniq[S ~[]E, E comparable](s S) S {
+	out := slicesx.Uniq(s)
+	trace("Uniq", len(s), len(out))
+	return out
+}
@@ -0,0 +1,83 @@
+package buildtags_test
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/buildtags"
+)
+
+func TestJoin(t *testing.T) {
+	sep := string(buildtags.Separator)
+	if got, want := buildtags.Join("home", "", "gopher", "notes.txt"), "home"+sep+"gopher"+sep+"notes.txt"; got != want {
+		t.Errorf("Join = %q, want %q", got, want)
+	}
+	if got := buildtags.Join("", ""); got != "" {
+		t.Errorf("Join of empty elements = %q", got)
+	}
+}
+
+func TestSeparators(t *testing.T) {
+	if runtime.GOOS == "js" || runtime.GOOS == "wasip1" {
+		t.Skip("os uses ':' between list entries here; path_other.go follows plan9")
+	}
+	if buildtags.Separator != os.PathSeparator || buildtags.ListSeparator != os.PathListSeparator {
+		t.Errorf("separators %q %q, os has %q %q",
+			buildtags.Separator, buildtags.ListSeparator, os.PathSeparator, os.PathListSeparator)
+	}
+}
+
+func TestTempDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Setenv("TMP", `C:\tmp`)
+		if got := buildtags.TempDir(); got != `C:\tmp` {
+			t.Errorf("TempDir = %q", got)
+		}
+		return
+	}
+	t.Setenv("TMPDIR", "/var/scratch")
+	if got := buildtags.TempDir(); got != "/var/scratch" {
+		t.Errorf("TempDir = %q", got)
+	}
+}
+
+// The helpers behave the same in every build; only tracing differs.
+func TestHelpers(t *testing.T) {
+	words := strings.Fields("go build go vet go test go run")
+	short := buildtags.Filter(words, func(w string) bool { return len(w) <= 3 })
+	got := buildtags.Map(buildtags.Uniq(short), strings.ToUpper)
+	if want := []string{"GO", "VET", "RUN"}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// platforms each pick a different path_*.go file.
+var platforms = []struct{ goos, goarch string }{
+	{"linux", "amd64"},
+	{"darwin", "arm64"},
+	{"windows", "amd64"},
+	{"plan9", "amd64"},
+	{"js", "wasm"},
+}
+
+// TestPlatforms checks that exactly one path file builds on each family
+// of platforms, by vetting the package and the example for each.
+func TestPlatforms(t *testing.T) {
+	if testing.Short() {
+		t.Skip("cross-compiles for every platform")
+	}
+	for _, p := range platforms {
+		t.Run(p.goos+"/"+p.goarch, func(t *testing.T) {
+			t.Parallel()
+			cmd := exec.Command("go", "vet", ".", "../buildtags-example")
+			cmd.Env = append(os.Environ(), "GOOS="+p.goos, "GOARCH="+p.goarch)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+//go:build debug && !fast
+
+package buildtags
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// Mode is the build the helpers were compiled in: "fast" or "debug".
+const Mode = "debug"
+
+// Trace receives a line per helper call. It exists only in debug builds,
+// so code that sets it must be behind the same tag.
+var Trace io.Writer = os.Stderr
+
+var traceMu sync.Mutex
+
+// trace logs a helper call with its input and output lengths and the
+// caller's position.
+func trace(name string, in, out int) {
+	pos := "?"
+	if _, file, line, ok := runtime.Caller(2); ok {
+		pos = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	fmt.Fprintf(Trace, "buildtags: %s %d -> %d at %s\n", name, in, out, pos)
+}
@@ -0,0 +1,36 @@
+//go:build debug && !fast
+
+package buildtags_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/buildtags"
+)
+
+func TestMode(t *testing.T) {
+	if buildtags.Mode != "debug" {
+		t.Errorf("Mode = %q, want debug", buildtags.Mode)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var buf bytes.Buffer
+	old := buildtags.Trace
+	buildtags.Trace = &buf
+	defer func() { buildtags.Trace = old }()
+
+	buildtags.Uniq(buildtags.Map([]int{1, 2, 2}, func(v int) int { return v }))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d trace lines, want 2:\n%s", len(lines), buf.String())
+	}
+	for i, want := range []string{"buildtags: Map 3 -> 3 at mode_debug_test.go:", "buildtags: Uniq 3 -> 2 at mode_debug_test.go:"} {
+		if !strings.HasPrefix(lines[i], want) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build fast || !debug
+
+package buildtags
+
+// Mode is the build the helpers were compiled in: "fast" or "debug".
+const Mode = "fast"
+
+// trace does nothing; the compiler inlines it away.
+func trace(name string, in, out int) {}
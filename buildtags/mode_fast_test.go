@@ -0,0 +1,15 @@
+//go:build fast || !debug
+
+package buildtags_test
+
+import (
+	"testing"
+
+	"github.com/armaanepiic/Golang/buildtags"
+)
+
+func TestMode(t *testing.T) {
+	if buildtags.Mode != "fast" {
+		t.Errorf("Mode = %q, want fast", buildtags.Mode)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !unix && !windows
+
+package buildtags
+
+// Plan 9, js/wasm and wasip1 all use slash-separated paths.
+const (
+	Separator     = '/'
+	ListSeparator = '\000' // as os.PathListSeparator on plan9
+)
+
+// TempDir returns /tmp.
+func TempDir() string { return "/tmp" }
@@ -0,0 +1,18 @@
+//go:build unix
+
+package buildtags
+
+import "os"
+
+const (
+	Separator     = '/'
+	ListSeparator = ':' // between entries of $PATH
+)
+
+// TempDir returns $TMPDIR, or /tmp if it is unset.
+func TempDir() string {
+	if dir := os.Getenv("TMPDIR"); dir != "" {
+		return dir
+	}
+	return "/tmp"
+}
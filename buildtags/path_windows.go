@@ -0,0 +1,19 @@
+package buildtags
+
+import "os"
+
+const (
+	Separator     = '\\'
+	ListSeparator = ';' // between entries of %PATH%
+)
+
+// TempDir returns the first of %TMP%, %TEMP% and %USERPROFILE% that is
+// set, the order GetTempPath uses, falling back to the Windows directory.
+func TempDir() string {
+	for _, env := range []string{"TMP", "TEMP", "USERPROFILE"} {
+		if dir := os.Getenv(env); dir != "" {
+			return dir
+		}
+	}
+	return os.Getenv("windir")
+}
@@ -0,0 +1,32 @@
+// Package calc parses and evaluates arithmetic expressions such as
+// "2 * (x + 3) ^ 2". It has the three classic parts of a language front
+// end: a lexer that turns text into tokens (package lex), a Pratt parser
+// that turns tokens into a tree, and an evaluator that walks the tree.
+package calc
+
+import (
+	"fmt"
+
+	"github.com/armaanepiic/Golang/lex"
+)
+
+// Error is a syntax or evaluation error at a byte offset in the input.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Pos+1, e.Msg)
+}
+
+var syntax = lex.Config{Operators: []string{"+", "-", "*", "/", "^", "(", ")", "="}}
+
+// tokenize runs the shared lexer and converts its first error.
+func tokenize(src string) ([]lex.Token, error) {
+	toks, err := lex.Tokenize(src, syntax)
+	if e, ok := err.(*lex.Error); ok {
+		return nil, &Error{e.Pos.Offset, e.Msg}
+	}
+	return toks, nil
+}
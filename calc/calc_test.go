@@ -0,0 +1,110 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]float64{"x": 3, "pi": math.Pi}
+	tests := []struct {
+		src  string
+		want float64
+		tree string
+	}{
+		{"42", 42, "42"},
+		{"1 + 2 * 3", 7, "(1 + (2 * 3))"},
+		{"(1 + 2) * 3", 9, "((1 + 2) * 3)"},
+		{"10 - 4 - 3", 3, "((10 - 4) - 3)"},
+		{"8 / 4 / 2", 1, "((8 / 4) / 2)"},
+		{"-2^2", -4, "(-(2 ^ 2))"},
+		{"(-2)^2", 4, "((-2) ^ 2)"},
+		{"2^3^2", 512, "(2 ^ (3 ^ 2))"},
+		{"2^-1", 0.5, "(2 ^ (-1))"},
+		{"--3", 3, "(-(-3))"},
+		{"+x * 2", 6, "((+x) * 2)"},
+		{"2 * (x + 3) ^ 2", 72, "(2 * ((x + 3) ^ 2))"},
+		{".5 + 1e3 + 2.5E-1", 1000.75, "((0.5 + 1000) + 0.25)"},
+		{"pi", math.Pi, "pi"},
+	}
+	for _, tt := range tests {
+		n, err := Parse(tt.src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.src, err)
+			continue
+		}
+		if got := n.String(); got != tt.tree {
+			t.Errorf("Parse(%q) = %s, want %s", tt.src, got, tt.tree)
+		}
+		got, err := n.Eval(vars)
+		if err != nil || got != tt.want {
+			t.Errorf("Eval(%q) = %v, %v; want %v", tt.src, got, err, tt.want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int // byte offset
+		msg string
+	}{
+		{"", 0, "unexpected end of input"},
+		{"1 +", 3, "unexpected end of input"},
+		{"(1 + 2", 6, "expected )"},
+		{"1 + 2)", 5, `unexpected ")"`},
+		{"2 3", 2, `unexpected "3"`},
+		{"1 + * 2", 4, `unexpected "*"`},
+		{"x = 1", 2, `unexpected "="`},
+		{"1 + $", 4, "unexpected character '$'"},
+		{"1 / (x - x)", 2, "division by zero"},
+		{"2 * y", 4, `undefined variable "y"`},
+	}
+	vars := map[string]float64{"x": 1}
+	for _, tt := range tests {
+		_, err := Eval(tt.src, vars)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("Eval(%q) error = %v, want an *Error", tt.src, err)
+			continue
+		}
+		if e.Pos != tt.pos || e.Msg != tt.msg {
+			t.Errorf("Eval(%q) error at %d %q, want at %d %q", tt.src, e.Pos, e.Msg, tt.pos, tt.msg)
+		}
+	}
+}
+
+func TestAssign(t *testing.T) {
+	vars := map[string]float64{}
+	name, v, err := Assign("r = 2", vars)
+	if err != nil || name != "r" || v != 2 {
+		t.Fatalf("Assign = %q, %v, %v", name, v, err)
+	}
+	name, v, err = Assign("area = 3 * r^2", vars)
+	if err != nil || name != "area" || v != 12 || vars["area"] != 12 {
+		t.Fatalf("Assign = %q, %v, %v", name, v, err)
+	}
+	name, v, err = Assign("area / 4", vars)
+	if err != nil || name != "" || v != 3 {
+		t.Fatalf("plain expression: %q, %v, %v", name, v, err)
+	}
+
+	// positions in the right-hand side are reported in the whole line
+	_, _, err = Assign("y = 1 + * 2", vars)
+	var e *Error
+	if !errors.As(err, &e) || e.Pos != 8 {
+		t.Fatalf("error %v, want one at offset 8", err)
+	}
+	if err.Error() != `9: unexpected "*"` {
+		t.Fatalf("Error() = %q, want a 1-based column", err)
+	}
+	for _, bad := range []string{"2 = 3", "a b = 1", " = 1"} {
+		if _, _, err := Assign(bad, vars); err == nil {
+			t.Errorf("Assign(%q) succeeded", bad)
+		}
+	}
+	if _, ok := vars["y"]; ok {
+		t.Error("a failed assignment stored its variable")
+	}
+}
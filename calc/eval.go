@@ -0,0 +1,95 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/armaanepiic/Golang/lex"
+)
+
+func (n *Num) Eval(map[string]float64) (float64, error) { return n.Value, nil }
+
+func (v *Var) Eval(vars map[string]float64) (float64, error) {
+	x, ok := vars[v.Name]
+	if !ok {
+		return 0, &Error{v.Pos, fmt.Sprintf("undefined variable %q", v.Name)}
+	}
+	return x, nil
+}
+
+func (u *Unary) Eval(vars map[string]float64) (float64, error) {
+	x, err := u.X.Eval(vars)
+	if u.Op == "-" {
+		x = -x
+	}
+	return x, err
+}
+
+func (b *Binary) Eval(vars map[string]float64) (float64, error) {
+	x, err := b.X.Eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	y, err := b.Y.Eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch b.Op {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/":
+		if y == 0 {
+			return 0, &Error{b.Pos, "division by zero"}
+		}
+		return x / y, nil
+	case "^":
+		return math.Pow(x, y), nil
+	}
+	return 0, &Error{b.Pos, "unknown operator " + b.Op}
+}
+
+func (n *Num) String() string   { return strconv.FormatFloat(n.Value, 'g', -1, 64) }
+func (v *Var) String() string   { return v.Name }
+func (u *Unary) String() string { return "(" + u.Op + u.X.String() + ")" }
+func (b *Binary) String() string {
+	return "(" + b.X.String() + " " + b.Op + " " + b.Y.String() + ")"
+}
+
+// Eval parses and evaluates src. vars may be nil.
+func Eval(src string, vars map[string]float64) (float64, error) {
+	n, err := Parse(src)
+	if err != nil {
+		return 0, err
+	}
+	return n.Eval(vars)
+}
+
+// Assign handles "name = expr" lines: it evaluates expr, stores it in
+// vars and returns the name. Lines without a leading "name =" are
+// evaluated as plain expressions and name is empty.
+func Assign(src string, vars map[string]float64) (name string, value float64, err error) {
+	if lhs, rhs, ok := strings.Cut(src, "="); ok {
+		name = strings.TrimSpace(lhs)
+		toks, lerr := tokenize(name)
+		if lerr != nil || len(toks) != 2 || toks[0].Kind != lex.Ident {
+			return "", 0, &Error{0, "left of = must be a variable name"}
+		}
+		value, err = Eval(rhs, vars)
+		if e, ok := err.(*Error); ok {
+			e.Pos += len(lhs) + 1 // report positions in the whole line
+		}
+		if err != nil {
+			return "", 0, err
+		}
+		vars[name] = value
+		return name, value, nil
+	}
+	value, err = Eval(src, vars)
+	return "", value, err
+}
@@ -0,0 +1,139 @@
+package calc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/armaanepiic/Golang/lex"
+)
+
+// Node is a node of the expression tree.
+type Node interface {
+	Eval(vars map[string]float64) (float64, error)
+	String() string
+}
+
+type (
+	// Num is a number literal.
+	Num struct {
+		Value float64
+	}
+	// Var is a variable reference.
+	Var struct {
+		Name string
+		Pos  int
+	}
+	// Unary is -x or +x.
+	Unary struct {
+		Op string
+		X  Node
+	}
+	// Binary is x op y.
+	Binary struct {
+		Op   string
+		X, Y Node
+		Pos  int // of the operator
+	}
+)
+
+// binding powers: higher binds tighter. ^ is right associative, and
+// binds tighter than unary minus, so -2^2 is -(2^2).
+var infix = map[string]struct{ left, right int }{
+	"+": {10, 11},
+	"-": {10, 11},
+	"*": {20, 21},
+	"/": {20, 21},
+	"^": {41, 40},
+}
+
+const prefixPower = 30
+
+type parser struct {
+	toks []lex.Token
+	i    int
+}
+
+// Parse parses one expression.
+func Parse(src string) (Node, error) {
+	toks, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	n, err := p.expr(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.Kind != lex.EOF {
+		return nil, &Error{t.Pos.Offset, fmt.Sprintf("unexpected %q", t.Raw)}
+	}
+	return n, nil
+}
+
+func (p *parser) peek() lex.Token { return p.toks[p.i] }
+
+func (p *parser) next() lex.Token {
+	t := p.toks[p.i]
+	if t.Kind != lex.EOF {
+		p.i++
+	}
+	return t
+}
+
+// expr parses an expression whose operators bind tighter than minPower
+// (Pratt parsing).
+func (p *parser) expr(minPower int) (Node, error) {
+	left, err := p.prefix()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.Kind != lex.Op {
+			break
+		}
+		bp, ok := infix[t.Text]
+		if !ok || bp.left < minPower {
+			break
+		}
+		p.next()
+		right, err := p.expr(bp.right)
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: t.Text, X: left, Y: right, Pos: t.Pos.Offset}
+	}
+	return left, nil
+}
+
+func (p *parser) prefix() (Node, error) {
+	t := p.next()
+	switch {
+	case t.Kind == lex.Int || t.Kind == lex.Float:
+		n, err := strconv.ParseFloat(t.Text, 64)
+		if err != nil {
+			return nil, &Error{t.Pos.Offset, fmt.Sprintf("bad number %q", t.Text)}
+		}
+		return &Num{n}, nil
+	case t.Kind == lex.Ident:
+		return &Var{Name: t.Text, Pos: t.Pos.Offset}, nil
+	case t.Is("-") || t.Is("+"):
+		x, err := p.expr(prefixPower)
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: t.Text, X: x}, nil
+	case t.Is("("):
+		x, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); !c.Is(")") {
+			return nil, &Error{c.Pos.Offset, "expected )"}
+		}
+		return x, nil
+	case t.Kind == lex.EOF:
+		return nil, &Error{t.Pos.Offset, "unexpected end of input"}
+	}
+	return nil, &Error{t.Pos.Offset, fmt.Sprintf("unexpected %q", t.Raw)}
+}
@@ -0,0 +1,276 @@
+// Package catalog indexes the repository: every example program, the
+// exported functions of the library packages and the TODO stubs left
+// for the reader. cmd/learn and cmd/tui build it when they start, so it
+// is never out of date; cmd/catalog prints it as JSON for editors.
+//
+// Build loads the packages with go/packages, which follows go.work, so
+// the examples that are modules of their own are included.
+package catalog
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/armaanepiic/Golang/topics"
+)
+
+// Catalog is the index. Paths in it are slash-separated and relative to
+// the repository root.
+type Catalog struct {
+	Examples []Example `json:"examples"`
+	Packages []Package `json:"packages"`
+	TODOs    []TODO    `json:"todos"`
+}
+
+// Example is a main package outside cmd/, a topic in the topics package.
+type Example struct {
+	Name     string   `json:"name"` // as topics.Topic.Name
+	Package  string   `json:"package"`
+	Synopsis string   `json:"synopsis,omitempty"`
+	Files    []string `json:"files"`
+	TODOs    int      `json:"todos,omitempty"`
+}
+
+// Package is a library package with its exported functions.
+type Package struct {
+	Path     string `json:"path"`
+	Dir      string `json:"dir"`
+	Synopsis string `json:"synopsis,omitempty"`
+	Funcs    []Func `json:"funcs"`
+}
+
+// Func is an exported function or method of an exported type.
+type Func struct {
+	Name      string `json:"name"` // "Set.Add" for a method
+	Signature string `json:"signature"`
+	Synopsis  string `json:"synopsis,omitempty"`
+	File      string `json:"file"`
+}
+
+// TODO is a TODO comment inside a function body: a stub to fill in.
+type TODO struct {
+	Dir  string `json:"dir"`
+	Func string `json:"func"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// Build indexes the repository at root. Generated files are skipped for
+// functions, since their API is documented by whatever generated them.
+func Build(root string) (*Catalog, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	// ./... stops at nested modules; name them explicitly
+	patterns := []string{"./..."}
+	all, err := topics.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range all {
+		if t.Module {
+			patterns = append(patterns, "./"+t.Name+"/...")
+		}
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax,
+		Dir:  root,
+		Fset: token.NewFileSet(),
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: %w", err)
+	}
+
+	c := &Catalog{Examples: []Example{}, Packages: []Package{}, TODOs: []TODO{}}
+	for _, p := range pkgs {
+		if len(p.Errors) > 0 {
+			return nil, fmt.Errorf("catalog: %s: %v", p.PkgPath, p.Errors[0])
+		}
+		if len(p.GoFiles) == 0 {
+			continue
+		}
+		dir := rel(root, filepath.Dir(p.GoFiles[0]))
+		if strings.HasPrefix(dir, "testdata/") || strings.Contains(dir, "/testdata/") {
+			continue
+		}
+		todos := stubs(cfg.Fset, root, dir, p.Syntax)
+		c.TODOs = append(c.TODOs, todos...)
+
+		switch {
+		case p.Name == "main" && dir != "." && dir != "cmd" && !strings.HasPrefix(dir, "cmd/"):
+			e := Example{Name: dir, Package: p.PkgPath, Synopsis: synopsis(p.Syntax), TODOs: len(todos)}
+			for _, f := range p.GoFiles {
+				e.Files = append(e.Files, filepath.Base(f))
+			}
+			c.Examples = append(c.Examples, e)
+		case p.Name != "main":
+			c.Packages = append(c.Packages, Package{
+				Path:     p.PkgPath,
+				Dir:      dir,
+				Synopsis: synopsis(p.Syntax),
+				Funcs:    funcs(cfg.Fset, root, p.Syntax),
+			})
+		}
+	}
+	slices.SortFunc(c.Examples, func(a, b Example) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(c.Packages, func(a, b Package) int { return strings.Compare(a.Path, b.Path) })
+	slices.SortFunc(c.TODOs, func(a, b TODO) int {
+		return cmp.Or(strings.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line))
+	})
+	return c, nil
+}
+
+func rel(root, path string) string {
+	r, err := filepath.Rel(root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(r)
+}
+
+// synopsis is the first sentence of the package doc, from whichever file
+// has it.
+func synopsis(files []*ast.File) string {
+	for _, f := range files {
+		if f.Doc != nil {
+			return doc.Synopsis(f.Doc.Text())
+		}
+	}
+	return ""
+}
+
+func funcs(fset *token.FileSet, root string, files []*ast.File) []Func {
+	out := []Func{}
+	for _, f := range files {
+		if ast.IsGenerated(f) {
+			continue
+		}
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || !fd.Name.IsExported() {
+				continue
+			}
+			name := fd.Name.Name
+			if fd.Recv != nil {
+				recv := recvType(fd.Recv.List[0].Type)
+				if !ast.IsExported(recv) {
+					continue
+				}
+				name = recv + "." + name
+			}
+			var syn string
+			if fd.Doc != nil {
+				syn = doc.Synopsis(fd.Doc.Text())
+			}
+			out = append(out, Func{
+				Name:      name,
+				Signature: signature(fset, fd),
+				Synopsis:  syn,
+				File:      rel(root, fset.Position(fd.Pos()).Filename),
+			})
+		}
+	}
+	slices.SortFunc(out, func(a, b Func) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// recvType is the name of a receiver's type: T for T, *T, T[K] or *T[K].
+func recvType(e ast.Expr) string {
+	for {
+		switch t := e.(type) {
+		case *ast.StarExpr:
+			e = t.X
+		case *ast.IndexExpr:
+			e = t.X
+		case *ast.IndexListExpr:
+			e = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// signature prints fd without its body and doc, on one line.
+func signature(fset *token.FileSet, fd *ast.FuncDecl) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, &ast.FuncDecl{Recv: fd.Recv, Name: fd.Name, Type: fd.Type})
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// stubs finds the TODO comments inside function bodies.
+func stubs(fset *token.FileSet, root, dir string, files []*ast.File) []TODO {
+	var out []TODO
+	for _, f := range files {
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			for _, cg := range f.Comments {
+				if cg.Pos() < fd.Body.Lbrace || cg.End() > fd.Body.Rbrace {
+					continue
+				}
+				for _, cm := range cg.List {
+					_, text, ok := strings.Cut(cm.Text, "TODO")
+					if !ok {
+						continue
+					}
+					pos := fset.Position(cm.Pos())
+					out = append(out, TODO{
+						Dir:  dir,
+						Func: fd.Name.Name,
+						File: rel(root, pos.Filename),
+						Line: pos.Line,
+						Text: strings.TrimSpace(strings.TrimLeft(text, ":")),
+					})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Marshal returns c as indented JSON.
+func (c *Catalog) Marshal() ([]byte, error) {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// Example returns the example named name.
+func (c *Catalog) Example(name string) (Example, bool) {
+	i := slices.IndexFunc(c.Examples, func(e Example) bool { return e.Name == name })
+	if i < 0 {
+		return Example{}, false
+	}
+	return c.Examples[i], true
+}
+
+// TODOsIn returns the stubs in the package at dir.
+func (c *Catalog) TODOsIn(dir string) []TODO {
+	var out []TODO
+	for _, t := range c.TODOs {
+		if t.Dir == dir {
+			out = append(out, t)
+		}
+	}
+	return out
+}
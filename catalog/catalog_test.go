@@ -0,0 +1,51 @@
+package catalog_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/catalog"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+func TestBuild(t *testing.T) {
+	root, err := topics.FindRoot(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := catalog.Build(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a nested module is an example too
+	for _, name := range []string{"slice", "ecommerce"} {
+		if _, ok := c.Example(name); !ok {
+			t.Errorf("example %s missing", name)
+		}
+	}
+	if slices.ContainsFunc(c.Examples, func(e catalog.Example) bool { return strings.HasPrefix(e.Name, "cmd/") }) {
+		t.Error("commands listed as examples")
+	}
+
+	i := slices.IndexFunc(c.Packages, func(p catalog.Package) bool { return p.Dir == "set" })
+	if i < 0 {
+		t.Fatal("package set missing")
+	}
+	var names []string
+	for _, f := range c.Packages[i].Funcs {
+		names = append(names, f.Name)
+	}
+	if !slices.Contains(names, "Set.Union") || !slices.Contains(names, "Sorted") {
+		t.Errorf("set funcs = %v", names)
+	}
+
+	todos := c.TODOsIn("pointer")
+	if len(todos) == 0 || todos[0].Func != "Swap" || todos[0].File != "pointer/exercise.go" {
+		t.Errorf("pointer TODOs = %+v", todos)
+	}
+	if e, _ := c.Example("pointer"); e.TODOs != len(todos) {
+		t.Errorf("pointer example counts %d TODOs, index has %d", e.TODOs, len(todos))
+	}
+}
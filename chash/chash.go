@@ -0,0 +1,117 @@
+// Package chash implements a consistent hashing ring with virtual nodes.
+//
+// Every physical node is placed on the ring many times (its replicas), so
+// keys spread evenly and only about 1/N of them move when a node joins or
+// leaves.
+package chash
+
+import (
+	"hash/crc32"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultReplicas is the number of virtual nodes used per physical node
+// when NewRing is given a non-positive value.
+const DefaultReplicas = 100
+
+// Hash maps a key to a point on the ring.
+type Hash func(data []byte) uint32
+
+// Ring is a consistent hashing ring. It is safe for concurrent use.
+type Ring struct {
+	mu       sync.RWMutex
+	hash     Hash
+	replicas int
+	points   []uint32          // sorted virtual node positions
+	owners   map[uint32]string // virtual node position -> physical node
+	nodes    map[string]bool
+}
+
+// NewRing returns an empty ring with the given number of virtual nodes per
+// physical node. A nil hash defaults to crc32.ChecksumIEEE.
+func NewRing(replicas int, hash Hash) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	if hash == nil {
+		hash = crc32.ChecksumIEEE
+	}
+	return &Ring{
+		hash:     hash,
+		replicas: replicas,
+		owners:   make(map[uint32]string),
+		nodes:    make(map[string]bool),
+	}
+}
+
+// AddNode places node on the ring. Adding a node twice is a no-op.
+func (r *Ring) AddNode(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nodes[node] {
+		return
+	}
+	r.nodes[node] = true
+	for i := 0; i < r.replicas; i++ {
+		p := r.hash([]byte(strconv.Itoa(i) + "#" + node))
+		if _, taken := r.owners[p]; taken {
+			continue // collision: first owner keeps the point
+		}
+		r.owners[p] = node
+		r.points = append(r.points, p)
+	}
+	slices.Sort(r.points)
+}
+
+// RemoveNode takes node and all of its virtual nodes off the ring.
+func (r *Ring) RemoveNode(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.nodes[node] {
+		return
+	}
+	delete(r.nodes, node)
+	kept := r.points[:0]
+	for _, p := range r.points {
+		if r.owners[p] == node {
+			delete(r.owners, p)
+			continue
+		}
+		kept = append(kept, p)
+	}
+	r.points = kept
+}
+
+// Locate returns the node responsible for key, or "" if the ring is empty.
+func (r *Ring) Locate(key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := r.hash([]byte(key))
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0 // wrap around
+	}
+	return r.owners[r.points[i]]
+}
+
+// Nodes returns the physical nodes on the ring in sorted order.
+func (r *Ring) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	nodes := make([]string, 0, len(r.nodes))
+	for n := range r.nodes {
+		nodes = append(nodes, n)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
@@ -0,0 +1,90 @@
+package chash
+
+import (
+	"fmt"
+	"testing"
+)
+
+func keys(n int) []string {
+	ks := make([]string, n)
+	for i := range ks {
+		ks[i] = fmt.Sprintf("user:%d", i)
+	}
+	return ks
+}
+
+func owners(r *Ring, ks []string) map[string]string {
+	m := make(map[string]string, len(ks))
+	for _, k := range ks {
+		m[k] = r.Locate(k)
+	}
+	return m
+}
+
+func TestLocateEmpty(t *testing.T) {
+	if got := NewRing(0, nil).Locate("k"); got != "" {
+		t.Fatalf("Locate on an empty ring = %q, want \"\"", got)
+	}
+}
+
+func TestKeyMovement(t *testing.T) {
+	const n = 5
+	ks := keys(20000)
+
+	r := NewRing(0, nil)
+	for i := range n - 1 {
+		r.AddNode(fmt.Sprintf("node-%d", i))
+	}
+	before := owners(r, ks)
+
+	// adding the n-th node should take about 1/n of the keys, all of them
+	// to the new node
+	r.AddNode("node-new")
+	after := owners(r, ks)
+	moved := 0
+	for _, k := range ks {
+		if before[k] == after[k] {
+			continue
+		}
+		moved++
+		if after[k] != "node-new" {
+			t.Fatalf("key %s moved from %s to %s, not to the new node", k, before[k], after[k])
+		}
+	}
+	checkFraction(t, "add", moved, len(ks), n)
+
+	// removing it should move back exactly the keys it owned
+	r.RemoveNode("node-new")
+	back := owners(r, ks)
+	for _, k := range ks {
+		if back[k] != before[k] {
+			t.Fatalf("after removing node-new, key %s is on %s, was on %s", k, back[k], before[k])
+		}
+	}
+
+	// removing an original node moves only its keys
+	r.RemoveNode("node-0")
+	gone := owners(r, ks)
+	moved = 0
+	for _, k := range ks {
+		if before[k] == gone[k] {
+			continue
+		}
+		moved++
+		if before[k] != "node-0" {
+			t.Fatalf("key %s moved off %s, which was not removed", k, before[k])
+		}
+	}
+	checkFraction(t, "remove", moved, len(ks), n-1)
+}
+
+// checkFraction allows the moved share to be between half and twice the
+// ideal 1/n: virtual nodes even the load out, but not perfectly.
+func checkFraction(t *testing.T, what string, moved, total, n int) {
+	t.Helper()
+	got := float64(moved) / float64(total)
+	want := 1 / float64(n)
+	if got < want/2 || got > want*2 {
+		t.Errorf("%s: %.3f of keys moved, want about %.3f", what, got, want)
+	}
+}
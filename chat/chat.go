@@ -0,0 +1,162 @@
+// Package chat is a WebSocket chat room.
+//
+// A single Hub goroutine owns the set of connected clients; clients talk to
+// it only through channels, so no locks are needed. Every client has a
+// reader goroutine (socket -> hub) and a writer goroutine (hub -> socket).
+package chat
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Message kinds.
+const (
+	KindJoin    = "join"
+	KindLeave   = "leave"
+	KindMessage = "message"
+)
+
+// Message is the JSON frame exchanged with clients.
+type Message struct {
+	Kind string    `json:"kind"`
+	User string    `json:"user"`
+	Text string    `json:"text,omitempty"`
+	Time time.Time `json:"time"`
+}
+
+type client struct {
+	name string
+	send chan Message
+}
+
+// Hub broadcasts every message to every connected client.
+type Hub struct {
+	join      chan *client
+	leave     chan *client
+	broadcast chan Message
+	done      chan struct{}
+
+	// SendBuffer is the per-client queue length. A client whose queue is
+	// full is too slow and gets disconnected.
+	SendBuffer int
+}
+
+// NewHub returns a Hub; start it with Run.
+func NewHub() *Hub {
+	return &Hub{
+		join:       make(chan *client),
+		leave:      make(chan *client),
+		broadcast:  make(chan Message),
+		done:       make(chan struct{}),
+		SendBuffer: 16,
+	}
+}
+
+// Run serves the hub until ctx is done, then disconnects every client.
+func (h *Hub) Run(ctx context.Context) {
+	clients := make(map[*client]bool)
+	defer func() {
+		close(h.done)
+		for c := range clients {
+			close(c.send)
+		}
+	}()
+
+	// remove disconnects c and tells the others; fanout drops slow
+	// consumers through it too, so they also get announced as gone.
+	var remove func(c *client)
+	fanout := func(m Message) {
+		var slow []*client
+		for c := range clients {
+			select {
+			case c.send <- m:
+			default:
+				slow = append(slow, c)
+			}
+		}
+		for _, c := range slow {
+			remove(c)
+		}
+	}
+	remove = func(c *client) {
+		if !clients[c] {
+			return
+		}
+		delete(clients, c)
+		close(c.send)
+		fanout(Message{Kind: KindLeave, User: c.name, Time: time.Now()})
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case c := <-h.join:
+			clients[c] = true
+			fanout(Message{Kind: KindJoin, User: c.name, Time: time.Now()})
+		case c := <-h.leave:
+			remove(c)
+		case m := <-h.broadcast:
+			fanout(m)
+		}
+	}
+}
+
+// Handler returns the WebSocket endpoint. Clients pick their name with the
+// "name" query parameter and send Message frames with only Text set.
+func (h *Hub) Handler() http.Handler {
+	return websocket.Handler(h.serve)
+}
+
+func (h *Hub) serve(ws *websocket.Conn) {
+	defer ws.Close()
+
+	name := ws.Request().URL.Query().Get("name")
+	if name == "" {
+		name = "anonymous"
+	}
+	c := &client{name: name, send: make(chan Message, h.SendBuffer)}
+	select {
+	case h.join <- c:
+	case <-h.done:
+		return
+	}
+
+	// writer: hub -> socket, until the hub closes c.send
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		for m := range c.send {
+			if err := websocket.JSON.Send(ws, m); err != nil {
+				ws.Close() // unblocks the reader below
+				for range c.send {
+				}
+				return
+			}
+		}
+		ws.Close()
+	}()
+
+	// reader: socket -> hub, until the connection fails
+	for {
+		var in Message
+		if err := websocket.JSON.Receive(ws, &in); err != nil {
+			break
+		}
+		m := Message{Kind: KindMessage, User: name, Text: in.Text, Time: time.Now()}
+		select {
+		case h.broadcast <- m:
+		case <-h.done:
+		}
+	}
+
+	select {
+	case h.leave <- c:
+	case <-h.done:
+	}
+	<-written
+}
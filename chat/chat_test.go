@@ -0,0 +1,130 @@
+package chat
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// startHub runs a hub until the test ends.
+func startHub(t *testing.T) *Hub {
+	t.Helper()
+	h := NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		h.Run(ctx)
+		close(stopped)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return h
+}
+
+// next returns the next message queued for c, failing after a second.
+func next(t *testing.T, c *client) Message {
+	t.Helper()
+	select {
+	case m, ok := <-c.send:
+		if !ok {
+			t.Fatalf("%s was disconnected", c.name)
+		}
+		return m
+	case <-time.After(time.Second):
+		t.Fatalf("%s got no message", c.name)
+	}
+	return Message{}
+}
+
+func TestSlowConsumerIsAnnounced(t *testing.T) {
+	h := startHub(t)
+	fast := &client{name: "fast", send: make(chan Message, 16)}
+	slow := &client{name: "slow", send: make(chan Message, 1)}
+
+	h.join <- fast
+	if m := next(t, fast); m.Kind != KindJoin || m.User != "fast" {
+		t.Fatalf("got %+v, want fast's join", m)
+	}
+	h.join <- slow // fills slow's queue with its own join message
+	if m := next(t, fast); m.Kind != KindJoin || m.User != "slow" {
+		t.Fatalf("got %+v, want slow's join", m)
+	}
+
+	h.broadcast <- Message{Kind: KindMessage, User: "fast", Text: "hi"}
+	if m := next(t, fast); m.Kind != KindMessage || m.Text != "hi" {
+		t.Fatalf("got %+v, want the broadcast", m)
+	}
+	if m := next(t, fast); m.Kind != KindLeave || m.User != "slow" {
+		t.Fatalf("got %+v, want slow's leave", m)
+	}
+
+	// slow keeps its join message, then sees its queue closed
+	if m := <-slow.send; m.Kind != KindJoin {
+		t.Fatalf("slow got %+v, want its join", m)
+	}
+	if _, ok := <-slow.send; ok {
+		t.Fatal("slow's queue is still open")
+	}
+}
+
+// dial connects a WebSocket client called name to srv.
+func dial(t *testing.T, srv *httptest.Server, name string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/?name=" + name
+	ws, err := websocket.Dial(url, "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+// receive reads the next message from ws, failing after a second.
+func receive(t *testing.T, ws *websocket.Conn) Message {
+	t.Helper()
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	var m Message
+	if err := websocket.JSON.Receive(ws, &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestWebSocketRoom(t *testing.T) {
+	h := startHub(t)
+	srv := httptest.NewServer(h.Handler())
+	defer srv.Close()
+
+	alice := dial(t, srv, "alice")
+	if m := receive(t, alice); m.Kind != KindJoin || m.User != "alice" {
+		t.Fatalf("alice got %+v, want her join", m)
+	}
+	bob := dial(t, srv, "bob")
+	if m := receive(t, alice); m.Kind != KindJoin || m.User != "bob" {
+		t.Fatalf("alice got %+v, want bob's join", m)
+	}
+	if m := receive(t, bob); m.Kind != KindJoin || m.User != "bob" {
+		t.Fatalf("bob got %+v, want his join", m)
+	}
+
+	// the server fills in kind and user, whatever the client sends
+	if err := websocket.JSON.Send(bob, Message{Kind: "join", User: "mallory", Text: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, ws := range []*websocket.Conn{alice, bob} {
+		if m := receive(t, ws); m.Kind != KindMessage || m.User != "bob" || m.Text != "hello" {
+			t.Fatalf("got %+v, want bob's message", m)
+		}
+	}
+
+	bob.Close()
+	if m := receive(t, alice); m.Kind != KindLeave || m.User != "bob" {
+		t.Fatalf("alice got %+v, want bob's leave", m)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/armaanepiic/Golang/breaker"
+)
+
+func main() {
+	// the handler runs on the server's goroutines, so the flag it reads
+	// must be safe to share
+	var healthy atomic.Bool
+
+	// a fake backend that fails until we flip it to healthy
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	}))
+	defer srv.Close()
+
+	// a clock we move by hand instead of sleeping through the cool-down,
+	// so the run is the same every time
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := breaker.New(breaker.Config{
+		Threshold: 3,
+		CoolDown:  200 * time.Millisecond,
+		Now:       func() time.Time { return now },
+		OnStateChange: func(from, to breaker.State) {
+			fmt.Println("  state:", from, "->", to)
+		},
+	})
+
+	call := func() error {
+		res, err := http.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode >= 500 {
+			return errors.New(res.Status)
+		}
+		return nil
+	}
+
+	for i := 1; i <= 5; i++ {
+		fmt.Println("call", i, "=>", b.Execute(call))
+	}
+
+	now = now.Add(250 * time.Millisecond) // past the cool-down
+	healthy.Store(true)
+
+	fmt.Println("trial call =>", b.Execute(call))
+	fmt.Println("state =", b.State())
+}
+
+/*
+
+	closed --(3 failures)--> open --(cool-down)--> half-open
+	  ^                                               |
+	  +------------------(trial succeeds)-------------+
+
+	while open, calls fail fast with ErrOpen and the backend is not touched
+
+*/
@@ -0,0 +1,120 @@
+// Command backup archives the userstore data directory on a schedule and
+// keeps the newest few archives.
+//
+//	backup -dir ./data -out ./backups -cron "0 3 * * *" -keep 7
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/archive"
+	"github.com/armaanepiic/Golang/sched"
+	"github.com/armaanepiic/Golang/shutdown"
+)
+
+func main() {
+	dir := flag.String("dir", "data", "userstore data directory to back up")
+	out := flag.String("out", "backups", "directory the archives are written to")
+	format := flag.String("format", "tgz", "archive format: tgz or zip")
+	every := flag.Duration("every", time.Hour, "back up at this interval")
+	cronExpr := flag.String("cron", "", "cron expression; overrides -every")
+	keep := flag.Int("keep", 10, "number of archives to keep (0 = all)")
+	once := flag.Bool("once", false, "make one backup and exit")
+	flag.Parse()
+
+	if *format != "tgz" && *format != "zip" {
+		fmt.Fprintln(os.Stderr, "backup: -format must be tgz or zip")
+		os.Exit(2)
+	}
+	if rel, err := filepath.Rel(*dir, *out); err == nil && filepath.IsLocal(rel) {
+		fmt.Fprintln(os.Stderr, "backup: -out must not be inside -dir, every backup would contain the previous ones")
+		os.Exit(2)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		slog.Error("backup", "err", err)
+		os.Exit(1)
+	}
+
+	job := func(ctx context.Context) error {
+		path, err := backup(*dir, *out, *format, time.Now())
+		if err != nil {
+			return err
+		}
+		slog.Info("backup written", "path", path)
+		return prune(*out, *keep)
+	}
+
+	if *once {
+		if err := job(context.Background()); err != nil {
+			slog.Error("backup failed", "err", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	s := sched.New(nil) // failures are logged and retried at the next run
+	var err error
+	if *cronExpr != "" {
+		err = s.Cron("backup", *cronExpr, job)
+	} else {
+		err = s.Every("backup", *every, job)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "backup:", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := shutdown.OnSignal(context.Background())
+	defer stop()
+	slog.Info("backup scheduler running", "dir", *dir, "out", *out)
+	s.Run(ctx)
+}
+
+const prefix = "users-"
+
+// backup archives dir into out under a timestamped name.
+func backup(dir, out, format string, now time.Time) (string, error) {
+	name := prefix + now.UTC().Format("20060102T150405Z")
+	if format == "zip" {
+		path := filepath.Join(out, name+".zip")
+		return path, archive.ZipDir(dir, path)
+	}
+	path := filepath.Join(out, name+".tar.gz")
+	return path, archive.TarGzDir(dir, path)
+}
+
+// prune deletes all but the newest keep archives. The timestamp in the
+// name sorts in time order.
+func prune(out string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, e := range entries {
+		n := e.Name()
+		if strings.HasPrefix(n, prefix) && (strings.HasSuffix(n, ".zip") || strings.HasSuffix(n, ".tar.gz")) {
+			names = append(names, n)
+		}
+	}
+	slices.Sort(names)
+	for len(names) > keep {
+		if err := os.Remove(filepath.Join(out, names[0])); err != nil {
+			return err
+		}
+		slog.Info("old backup removed", "name", names[0])
+		names = names[1:]
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Command benchdiff runs benchmarks twice and prints what changed, with a
+// hint for each delta on whether it is real or noise.
+//
+//	benchdiff old.txt new.txt                   # saved go test -bench output
+//	benchdiff ./v1 ./v2                         # two packages with the same benchmarks
+//	benchdiff -git main HEAD ./slice ./lex      # the same packages at two git refs
+//	benchdiff -git HEAD~1 HEAD                  # every package (./...)
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/armaanepiic/Golang/benchdiff"
+	"github.com/armaanepiic/Golang/shutdown"
+)
+
+func main() {
+	useGit := flag.Bool("git", false, "the first two arguments are git refs; the rest are packages")
+	opt := benchdiff.Options{}
+	flag.StringVar(&opt.Bench, "bench", ".", "run only benchmarks matching `regexp`")
+	flag.IntVar(&opt.Count, "count", 6, "runs of each benchmark per side")
+	flag.StringVar(&opt.Benchtime, "benchtime", "", "go test -benchtime, e.g. 200ms or 1000x")
+	verbose := flag.Bool("v", false, "show go test output while it runs")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: benchdiff [flags] old new | -git oldref newref [packages]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 2 || (!*useGit && flag.NArg() != 2) {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *verbose {
+		opt.Log = os.Stderr
+	}
+
+	ctx, stop := shutdown.OnSignal(context.Background())
+	defer stop()
+
+	a, b := flag.Arg(0), flag.Arg(1)
+	var old, new benchdiff.Set
+	var err error
+	switch {
+	case *useGit:
+		pkgs := flag.Args()[2:]
+		if len(pkgs) == 0 {
+			pkgs = []string{"./..."}
+		}
+		fmt.Fprintf(os.Stderr, "benchmarking %s…\n", a)
+		if old, err = benchdiff.RunAtRef(ctx, ".", a, pkgs, opt); err != nil {
+			fail(err)
+		}
+		fmt.Fprintf(os.Stderr, "benchmarking %s…\n", b)
+		if new, err = benchdiff.RunAtRef(ctx, ".", b, pkgs, opt); err != nil {
+			fail(err)
+		}
+	case isFile(a) && isFile(b):
+		if old, err = parseFile(a); err != nil {
+			fail(err)
+		}
+		if new, err = parseFile(b); err != nil {
+			fail(err)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "benchmarking %s…\n", a)
+		if old, err = benchdiff.Run(ctx, ".", []string{a}, opt); err != nil {
+			fail(err)
+		}
+		fmt.Fprintf(os.Stderr, "benchmarking %s…\n", b)
+		if new, err = benchdiff.Run(ctx, ".", []string{b}, opt); err != nil {
+			fail(err)
+		}
+	}
+
+	if len(old) == 0 && len(new) == 0 {
+		fail(fmt.Errorf("no benchmarks matched %q", opt.Bench))
+	}
+	if err := benchdiff.Write(os.Stdout, benchdiff.Compare(old, new)); err != nil {
+		fail(err)
+	}
+}
+
+func isFile(name string) bool {
+	fi, err := os.Stat(name)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func parseFile(name string) (benchdiff.Set, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return benchdiff.Parse(f)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "benchdiff:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,57 @@
+// Command calc is a calculator REPL.
+//
+//	> r = 2.5
+//	r = 2.5
+//	> pi * r ^ 2
+//	19.634954084936208
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/armaanepiic/Golang/calc"
+)
+
+func main() {
+	vars := map[string]float64{"pi": math.Pi, "e": math.E}
+	in := bufio.NewScanner(os.Stdin)
+	fmt.Println(`calc: + - * / ^ ( ), "x = expr" to assign, "ans" is the last result, Ctrl+D to quit`)
+	for {
+		fmt.Print("> ")
+		if !in.Scan() {
+			fmt.Println()
+			return
+		}
+		line := strings.TrimSpace(in.Text())
+		if line == "" {
+			continue
+		}
+		name, v, err := calc.Assign(line, vars)
+		if err != nil {
+			var ce *calc.Error
+			if errors.As(err, &ce) {
+				// point at the problem under the input line
+				fmt.Printf("  %s^ %s\n", strings.Repeat(" ", ce.Pos), ce.Msg)
+			} else {
+				fmt.Println("error:", err)
+			}
+			continue
+		}
+		vars["ans"] = v
+		if name != "" {
+			fmt.Println(name, "=", format(v))
+		} else {
+			fmt.Println(format(v))
+		}
+	}
+}
+
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,48 @@
+// Command catalog prints the index of examples, exported functions and
+// TODO stubs as JSON, for editors and scripts. cmd/learn and cmd/tui
+// build the same index themselves.
+//
+//	catalog              # print it
+//	catalog -o idx.json  # write it to a file
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/armaanepiic/Golang/catalog"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+func main() {
+	out := flag.String("o", "", "write the index to `file` instead of stdout")
+	flag.Parse()
+
+	root, err := topics.FindRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	c, err := catalog.Build(root)
+	if err != nil {
+		fail(err)
+	}
+	b, err := c.Marshal()
+	if err != nil {
+		fail(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(b)
+		return
+	}
+	if err := os.WriteFile(*out, b, 0o644); err != nil {
+		fail(err)
+	}
+	fmt.Printf("wrote %s: %d examples, %d packages, %d TODOs\n", *out, len(c.Examples), len(c.Packages), len(c.TODOs))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "catalog:", err)
+	os.Exit(1)
+}
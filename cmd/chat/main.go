@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/armaanepiic/Golang/chat"
+)
+
+func main() {
+	addr := flag.String("addr", ":3000", "listen address")
+	flag.Parse()
+
+	hub := chat.NewHub()
+	go hub.Run(context.Background())
+
+	mux := http.NewServeMux()
+	mux.Handle("/ws", hub.Handler()) // ws://localhost:3000/ws?name=arman
+
+	fmt.Println("Chat running on", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
@@ -0,0 +1,123 @@
+// Command checksum hashes single files or whole directory trees and
+// verifies checksum manifests.
+//
+//	checksum file.iso                   print the SHA-256 of a file
+//	checksum -r dir > SHA256SUMS        write a manifest for a tree
+//	checksum -c SHA256SUMS              verify it (paths relative to the manifest)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/armaanepiic/Golang/hashx"
+)
+
+func main() {
+	algoName := flag.String("a", "sha256", "algorithm: md5, sha1, sha256, sha512")
+	tree := flag.Bool("r", false, "hash every file under the given directory")
+	check := flag.Bool("c", false, "verify the given manifest files")
+	quiet := flag.Bool("q", false, "with -c, only print failures")
+	flag.Parse()
+
+	algo, err := hashx.ParseAlgorithm(*algoName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: checksum [-a sha256] [-r | -c] path...")
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, arg := range flag.Args() {
+		var ok bool
+		switch {
+		case *check:
+			ok = verify(arg, *quiet)
+		case *tree:
+			ok = hashTree(arg, algo)
+		default:
+			ok = hashFile(arg, algo)
+		}
+		failed = failed || !ok
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func hashFile(file string, algo hashx.Algorithm) bool {
+	info, err := os.Stat(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	var progress hashx.Progress
+	if info.Size() > 64<<20 { // show progress for big files only
+		progress = func(done int64) {
+			fmt.Fprintf(os.Stderr, "\r%s: %3d%%", file, done*100/info.Size())
+		}
+	}
+	sum, err := hashx.SumFile(file, algo, progress)
+	if progress != nil {
+		fmt.Fprintln(os.Stderr)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	hashx.WriteManifest(os.Stdout, []hashx.Entry{{Sum: sum, Path: filepath.ToSlash(file)}})
+	return true
+}
+
+func hashTree(root string, algo hashx.Algorithm) bool {
+	entries, err := hashx.HashTree(root, algo, nil, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	for i := range entries {
+		// keep the root in the path, like sha256sum run on each file
+		entries[i].Path = path.Join(filepath.ToSlash(root), entries[i].Path)
+	}
+	hashx.WriteManifest(os.Stdout, entries)
+	return true
+}
+
+func verify(manifest string, quiet bool) bool {
+	f, err := os.Open(manifest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	entries, err := hashx.ReadManifest(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, manifest+":", err)
+		return false
+	}
+
+	bad := 0
+	for _, r := range hashx.Verify(filepath.Dir(manifest), entries) {
+		if r.Status == hashx.OK && quiet {
+			continue
+		}
+		if r.Status != hashx.OK {
+			bad++
+		}
+		if r.Err != nil {
+			fmt.Printf("%s: %s (%v)\n", r.Path, r.Status, r.Err)
+		} else {
+			fmt.Printf("%s: %s\n", r.Path, r.Status)
+		}
+	}
+	if bad > 0 {
+		fmt.Fprintf(os.Stderr, "checksum: %d of %d files did not match\n", bad, len(entries))
+	}
+	return bad == 0
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The tests run the command by re-executing the test binary with
+// CHECKSUM_MAIN set, so main can call os.Exit.
+func TestMain(m *testing.M) {
+	if os.Getenv("CHECKSUM_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func checksum(t *testing.T, dir string, args ...string) (stdout string, code int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "CHECKSUM_MAIN=1")
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+	err := cmd.Run()
+	var exit *exec.ExitError
+	switch {
+	case errors.As(err, &exit):
+		code = exit.ExitCode()
+	case err != nil:
+		t.Fatal(err)
+	}
+	return out.String(), code
+}
+
+// fixture copies hashx's fixture tree, with its sha256sum manifest as
+// tree/SHA256SUMS, into a temporary directory.
+func fixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	tree := filepath.Join(dir, "tree")
+	if err := os.CopyFS(tree, os.DirFS("../../hashx/testdata/tree")); err != nil {
+		t.Fatal(err)
+	}
+	sums, err := os.ReadFile("../../hashx/testdata/tree.sha256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tree, "SHA256SUMS"), sums, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestHashFile(t *testing.T) {
+	out, code := checksum(t, "../../hashx/testdata", "-a", "sha-1", "abc.txt")
+	if code != 0 || out != "a9993e364706816aba3e25717850c26c9cd0d89d  abc.txt\n" {
+		t.Fatalf("exit %d, output %q", code, out)
+	}
+	if _, code := checksum(t, ".", "missing.txt"); code != 1 {
+		t.Errorf("missing file: exit %d", code)
+	}
+	if _, code := checksum(t, ".", "-a", "crc32", "main.go"); code != 2 {
+		t.Errorf("unknown algorithm: exit %d", code)
+	}
+}
+
+func TestManifest(t *testing.T) {
+	dir := fixture(t)
+	os.Remove(filepath.Join(dir, "tree", "SHA256SUMS"))
+	out, code := checksum(t, dir, "-r", "tree")
+	want := `853ff93762a06ddbf722c4ebe9ddd66d8f63ddaea97f521c3ecc20da7c976020  tree/hello.txt
+98bdbd3fb298c89cc8ad98fa42c6ea1b819701cd3e5869bc09d1498d333d587c  tree/sub/lorem.txt
+55cb1054740f734d0bc5e7ada04ac9126c6b4ca66d9a0d55cb24fb1217a11e97  tree/users.csv
+`
+	if code != 0 || out != want {
+		t.Fatalf("exit %d, manifest:\n%s", code, out)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	dir := fixture(t)
+	out, code := checksum(t, dir, "-c", "tree/SHA256SUMS")
+	if code != 0 || out != "hello.txt: OK\nsub/lorem.txt: OK\nusers.csv: OK\n" {
+		t.Fatalf("pristine: exit %d\n%s", code, out)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "tree", "hello.txt"), []byte("goodbye\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "tree", "users.csv")); err != nil {
+		t.Fatal(err)
+	}
+	out, code = checksum(t, dir, "-q", "-c", "tree/SHA256SUMS")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if code != 1 || len(lines) != 2 || lines[0] != "hello.txt: FAILED" || !strings.HasPrefix(lines[1], "users.csv: MISSING") {
+		t.Fatalf("tampered: exit %d\n%s", code, out)
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// pkg is a type-checked package.
+type pkg struct {
+	name  string
+	types *types.Package
+}
+
+// load parses and type-checks the package in dir, leaving out skip, the
+// file about to be regenerated. Type errors elsewhere, such as imports
+// the default importer cannot find, are ignored: only the constants
+// matter, and they rarely depend on other packages.
+func load(dir, skip string) (*pkg, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if name == skip {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.Default(), Error: func(error) {}}
+	tp, _ := conf.Check(bp.Name, fset, files, nil)
+	return &pkg{name: bp.Name, types: tp}, nil
+}
+
+// value is one named constant of an enum type.
+type value struct {
+	Name string // identifier
+	Text string // Name without the trimmed prefix
+	n    constant.Value
+}
+
+// enum is a type and its constants, in value order.
+type enum struct {
+	Type   string
+	Recv   string
+	Values []value
+}
+
+// enum collects the constants of type name. Where several constants
+// share a value, the first declared gives the text.
+func (p *pkg) enum(name, trim string) (enum, error) {
+	obj, ok := p.types.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return enum{}, fmt.Errorf("no type %s in package %s", name, p.name)
+	}
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return enum{}, fmt.Errorf("%s is not an integer type", name)
+	}
+	e := enum{Type: name, Recv: strings.ToLower(name[:1])}
+
+	var consts []*types.Const
+	for _, n := range p.types.Scope().Names() {
+		if c, ok := p.types.Scope().Lookup(n).(*types.Const); ok && types.Identical(c.Type(), obj.Type()) && n != "_" {
+			consts = append(consts, c)
+		}
+	}
+	slices.SortStableFunc(consts, func(a, b *types.Const) int { return int(a.Pos() - b.Pos()) })
+	for _, c := range consts {
+		if slices.ContainsFunc(e.Values, func(v value) bool { return constant.Compare(v.n, token.EQL, c.Val()) }) {
+			continue
+		}
+		text := strings.TrimPrefix(c.Name(), trim)
+		if text == "" {
+			text = c.Name()
+		}
+		e.Values = append(e.Values, value{Name: c.Name(), Text: text, n: c.Val()})
+	}
+	if len(e.Values) == 0 {
+		return enum{}, fmt.Errorf("no constants of type %s", name)
+	}
+	slices.SortStableFunc(e.Values, func(a, b value) int {
+		if constant.Compare(a.n, token.LSS, b.n) {
+			return -1
+		}
+		if constant.Compare(a.n, token.GTR, b.n) {
+			return 1
+		}
+		return 0
+	})
+	return e, nil
+}
+
+// Texts is the list of valid texts for error messages.
+func (e enum) Texts() string {
+	texts := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		texts[i] = v.Text
+	}
+	return strings.Join(texts, ", ")
+}
+
+var tmpl = template.Must(template.New("enum").Parse(`// Code generated by "{{.Command}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+{{range .Enums}}
+// String returns the name of {{.Recv}}, or {{.Type}}(n) if it has none.
+func ({{.Recv}} {{.Type}}) String() string {
+	switch {{.Recv}} {
+{{- range .Values}}
+	case {{.Name}}:
+		return {{printf "%q" .Text}}
+{{- end}}
+	}
+	return "{{.Type}}(" + strconv.FormatInt(int64({{.Recv}}), 10) + ")"
+}
+
+// MarshalText returns the name of {{.Recv}}. Values without a name are an
+// error, so they never reach JSON or a config file.
+func ({{.Recv}} {{.Type}}) MarshalText() ([]byte, error) {
+	s := {{.Recv}}.String()
+	if strings.HasPrefix(s, "{{.Type}}(") {
+		return nil, fmt.Errorf("invalid {{.Type}} %d", int64({{.Recv}}))
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText sets {{.Recv}} from its name, as Parse{{.Type}} does.
+func ({{.Recv}} *{{.Type}}) UnmarshalText(text []byte) error {
+	v, err := Parse{{.Type}}(string(text))
+	if err != nil {
+		return err
+	}
+	*{{.Recv}} = v
+	return nil
+}
+
+// Parse{{.Type}} returns the {{.Type}} named s, ignoring case.
+func Parse{{.Type}}(s string) ({{.Type}}, error) {
+	for _, v := range {{.Type}}Values() {
+		if strings.EqualFold(s, v.String()) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid {{.Type}} %q (want one of {{.Texts}})", s)
+}
+
+// {{.Type}}Values returns every named {{.Type}} in increasing order.
+func {{.Type}}Values() []{{.Type}} {
+	return []{{.Type}}{ {{- range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Name}}{{end -}} }
+}
+{{end}}`))
+
+// generate returns the formatted source for enums.
+func generate(pkgName, command string, enums []enum) ([]byte, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]any{"Command": command, "Package": pkgName, "Enums": enums})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
@@ -0,0 +1,81 @@
+// Command enumgen writes String, MarshalText, UnmarshalText and ParseX
+// for integer types whose values are a block of named constants, in the
+// manner of stringer. Run it from go:generate next to the type:
+//
+//	//go:generate go run github.com/armaanepiic/Golang/cmd/enumgen -type Role -trimprefix Role
+//
+// which writes role_enum.go in the same package. With -check nothing is
+// written: enumgen exits 1 if the file is missing or out of date, so a
+// stale generated file can be caught without running go generate.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of type `names`; required")
+	trim := flag.String("trimprefix", "", "`prefix` to remove from constant names to get their text")
+	output := flag.String("output", "", "output `file`; default <type>_enum.go in the package directory")
+	check := flag.Bool("check", false, "report whether the output is up to date instead of writing it")
+	flag.Parse()
+	if *typeNames == "" {
+		fail(errors.New("-type is required"))
+	}
+	dir := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		dir = flag.Arg(0)
+	default:
+		fail(errors.New("at most one package directory"))
+	}
+	types := strings.Split(*typeNames, ",")
+	out := *output
+	if out == "" {
+		out = filepath.Join(dir, strings.ToLower(types[0])+"_enum.go")
+	}
+
+	pkg, err := load(dir, filepath.Base(out))
+	if err != nil {
+		fail(err)
+	}
+	var enums []enum
+	for _, name := range types {
+		e, err := pkg.enum(name, *trim)
+		if err != nil {
+			fail(err)
+		}
+		enums = append(enums, e)
+	}
+	command := "enumgen -type " + *typeNames
+	if *trim != "" {
+		command += " -trimprefix " + *trim
+	}
+	src, err := generate(pkg.name, command, enums)
+	if err != nil {
+		fail(err)
+	}
+
+	if *check {
+		old, err := os.ReadFile(out)
+		if err != nil || !bytes.Equal(old, src) {
+			fail(fmt.Errorf("%s is out of date; run go generate", out))
+		}
+		return
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "enumgen:", err)
+	os.Exit(1)
+}
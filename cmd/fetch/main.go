@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/armaanepiic/Golang/download"
+	"github.com/armaanepiic/Golang/shutdown"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory to save files in")
+	concurrency := flag.Int("c", 4, "parallel downloads")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: fetch [-dir DIR] [-c N] URL[#sha256=HEX]...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var jobs []download.Job
+	for _, arg := range flag.Args() {
+		job, err := parseJob(arg, *dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		jobs = append(jobs, job)
+	}
+
+	// print a line every time a file crosses another 10%
+	var mu sync.Mutex
+	reported := make(map[string]int64)
+	m := &download.Manager{
+		Concurrency: *concurrency,
+		Progress: func(job download.Job, done, total int64) {
+			mu.Lock()
+			defer mu.Unlock()
+			if total <= 0 {
+				return
+			}
+			step := done * 10 / total
+			if step == reported[job.Dest] {
+				return
+			}
+			reported[job.Dest] = step
+			fmt.Fprintf(os.Stderr, "%-30s %3d%%  %s / %s\n", filepath.Base(job.Dest),
+				done*100/total, download.HumanBytes(done), download.HumanBytes(total))
+		},
+	}
+
+	ctx, stop := shutdown.OnSignal(context.Background()) // Ctrl+C keeps the .part files
+	defer stop()
+
+	results := m.Fetch(ctx, jobs)
+	fmt.Println()
+	download.WriteSummary(os.Stdout, results)
+
+	for _, r := range results {
+		if r.Err != nil {
+			os.Exit(1)
+		}
+	}
+}
+
+func parseJob(arg, dir string) (download.Job, error) {
+	u, err := url.Parse(arg)
+	if err != nil {
+		return download.Job{}, err
+	}
+	job := download.Job{}
+	if sum, ok := strings.CutPrefix(u.Fragment, "sha256="); ok {
+		job.SHA256 = sum
+		u.Fragment = ""
+	}
+	job.URL = u.String()
+
+	name := path.Base(u.Path)
+	if name == "/" || name == "." || name == "" {
+		name = "index.html"
+	}
+	job.Dest = filepath.Join(dir, name)
+	return job, nil
+}
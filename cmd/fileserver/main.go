@@ -0,0 +1,23 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/armaanepiic/Golang/fileserver"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	dir := flag.String("dir", ".", "directory to serve")
+	listing := flag.Bool("listing", false, "show directory listings")
+	flag.Parse()
+
+	h := fileserver.New(os.DirFS(*dir), fileserver.Options{Listing: *listing})
+
+	fmt.Println("Serving", *dir, "on", *addr)
+	log.Fatal(http.ListenAndServe(*addr, h))
+}
@@ -0,0 +1,99 @@
+// Command golden runs the example topics that have golden files and
+// compares their output with the recorded one.
+//
+//	golden                    # check every topic with a golden file
+//	golden slice pointer      # check just these
+//	golden -update slice      # record (or re-record) slice's output
+//	golden -update            # re-record every existing golden file
+//
+// Review the diff before running -update: the point is that output
+// changes are deliberate.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/armaanepiic/Golang/golden"
+	"github.com/armaanepiic/Golang/shutdown"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+func main() {
+	update := flag.Bool("update", false, "write the current output as the golden files")
+	timeout := flag.Duration("timeout", 30*time.Second, "how long each example may run")
+	flag.Parse()
+
+	root, err := topics.FindRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	all, err := topics.Discover(root)
+	if err != nil {
+		fail(err)
+	}
+	var picked []topics.Topic
+	if flag.NArg() > 0 {
+		for _, name := range flag.Args() {
+			t, err := topics.Lookup(all, name)
+			if err != nil {
+				fail(err)
+			}
+			if !*update && !golden.Has(root, t) {
+				fail(fmt.Errorf("%s has no golden file; record one with -update", t.Name))
+			}
+			picked = append(picked, t)
+		}
+	} else {
+		for _, t := range all {
+			if golden.Has(root, t) {
+				picked = append(picked, t)
+			}
+		}
+		if len(picked) == 0 {
+			fail(fmt.Errorf("no golden files yet; record one with golden -update <topic>"))
+		}
+	}
+
+	ctx, stop := shutdown.OnSignal(context.Background())
+	defer stop()
+
+	failed := 0
+	for _, t := range picked {
+		got, err := golden.Capture(ctx, t, *timeout)
+		if err != nil {
+			fmt.Printf("FAIL %s\n%v\n", t.Name, err)
+			failed++
+			continue
+		}
+		if *update {
+			if err := golden.Update(root, t, got); err != nil {
+				fail(err)
+			}
+			fmt.Println("wrote", t.Name)
+			continue
+		}
+		diff, err := golden.Compare(root, t, got)
+		if err != nil {
+			fail(err)
+		}
+		if diff != "" {
+			fmt.Printf("FAIL %s: output differs from the golden file (- golden, + now)\n%s", t.Name, diff)
+			failed++
+			continue
+		}
+		fmt.Println("ok  ", t.Name)
+	}
+	if failed > 0 {
+		stop()
+		os.Exit(1)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "golden:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net"
+	"os"
+	"time"
+
+	"github.com/armaanepiic/Golang/kv"
+	"github.com/armaanepiic/Golang/shutdown"
+)
+
+func main() {
+	addr := flag.String("addr", ":6380", "listen address")
+	shards := flag.Int("shards", 16, "number of map shards")
+	sweep := flag.Duration("sweep", time.Second, "how often expired keys are removed")
+	flag.Parse()
+
+	ctx, stop := shutdown.OnSignal(context.Background())
+	defer stop()
+
+	store := kv.NewStore(*shards)
+	go store.RunSweeper(ctx, *sweep)
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		slog.Error("listen", "err", err)
+		os.Exit(1)
+	}
+	srv := kv.NewServer(store)
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	// try it: printf 'SET name arman\nGET name\n' | nc localhost 6380
+	slog.Info("kv server running", "addr", ln.Addr().String())
+	if err := srv.Serve(ln); err != nil {
+		slog.Error("serve", "err", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/armaanepiic/Golang/exercise"
+	"github.com/armaanepiic/Golang/progress"
+	"github.com/armaanepiic/Golang/registry"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+func init() {
+	registry.Register(registry.Command{
+		Name:    "check",
+		Args:    "[topic]",
+		Summary: "list the exercises, or run the hidden tests of a topic's exercises",
+		Run:     checkCmd,
+	})
+}
+
+// checkCmd is "learn check [topic | topic/exercise]". Without arguments it
+// lists the exercises.
+func checkCmd(ctx context.Context, args []string) error {
+	_, all, err := discover()
+	if err != nil {
+		return err
+	}
+	exs, err := exercise.All()
+	if err != nil {
+		return err
+	}
+	tr, err := openProgress()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return listExercises(tr, all, exs)
+	}
+	picked, err := exercise.Lookup(exs, args[0])
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, e := range picked {
+		t, err := topics.Lookup(all, e.Topic)
+		if err != nil {
+			return err
+		}
+		r, err := exercise.Check(ctx, e, t.Dir)
+		if err != nil {
+			return err
+		}
+		report(e, t, r)
+		if !r.Passed {
+			failed++
+			continue
+		}
+		if _, err := tr.MarkDone(progress.ExerciseKey(e.Topic, e.Name)); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d exercises failed", failed, len(picked))
+	}
+	return nil
+}
+
+func report(e *exercise.Exercise, t topics.Topic, r *exercise.Result) {
+	if r.Passed {
+		fmt.Printf("✓ %s: all %d cases pass\n", e.ID(), len(r.Cases))
+		return
+	}
+	if r.BuildOutput != "" {
+		fmt.Printf("✗ %s: does not build\n", e.ID())
+		for _, line := range strings.Split(r.BuildOutput, "\n") {
+			fmt.Println("    " + line)
+		}
+		fmt.Printf("  fix %s in %s first\n", e.Func, t.Name+"/"+e.File)
+		return
+	}
+	failed := r.Failed()
+	fmt.Printf("✗ %s: %d of %d cases fail\n", e.ID(), len(failed), len(r.Cases))
+	for _, c := range failed {
+		fmt.Printf("  ✗ %s\n", strings.ReplaceAll(c.Name, "_", " "))
+		for _, line := range c.Output {
+			fmt.Println("      " + line)
+		}
+		if h := e.HintFor(c.Name); h != "" {
+			fmt.Println("      hint: " + h)
+		}
+	}
+}
+
+func listExercises(tr *progress.Tracker, all []topics.Topic, exs []*exercise.Exercise) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, e := range exs {
+		mark := " "
+		if _, ok := tr.IsDone(progress.ExerciseKey(e.Topic, e.Name)); ok {
+			mark = "✓"
+		}
+		where := e.File
+		if t, err := topics.Lookup(all, e.Topic); err == nil {
+			where = t.Name + "/" + e.File
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\n", mark, e.ID(), where, e.Prompt)
+	}
+	return tw.Flush()
+}
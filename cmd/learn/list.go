@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/armaanepiic/Golang/catalog"
+	"github.com/armaanepiic/Golang/progress"
+	"github.com/armaanepiic/Golang/registry"
+)
+
+func init() {
+	registry.Register(registry.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Summary: "list the example topics",
+		Run:     listCmd,
+	})
+}
+
+func listCmd(ctx context.Context, args []string) error {
+	root, all, err := discover()
+	if err != nil {
+		return err
+	}
+	tr, err := openProgress()
+	if err != nil {
+		return err
+	}
+	// the catalog only adds detail: if a package doesn't parse (an
+	// exercise half done, say) the list is just shorter on notes
+	cat, _ := catalog.Build(root)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, t := range all {
+		note := t.Synopsis
+		if t.Module {
+			note = strings.TrimSpace("(own module) " + note)
+		}
+		if cat != nil {
+			if e, ok := cat.Example(t.Name); ok && e.TODOs > 0 {
+				note = strings.TrimSpace(fmt.Sprintf("[%d TODO] %s", e.TODOs, note))
+			}
+		}
+		mark := " "
+		if _, ok := tr.IsDone(progress.TopicKey(t.Name)); ok {
+			mark = "✓"
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\n", mark, t.Name, note)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,74 @@
+// Command learn lists and runs the example topics in this repository from
+// anywhere inside it.
+//
+//	learn list
+//	learn run slice
+//	learn run ecommerce -- -h
+//	learn progress done slice
+//	learn check slice
+//
+// Each command lives in its own file and registers itself with the
+// registry package from init; main only dispatches.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/armaanepiic/Golang/registry"
+	"github.com/armaanepiic/Golang/shutdown"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		registry.Default.Usage(os.Stderr, "learn")
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "help", "-h", "-help", "--help":
+		registry.Default.Usage(os.Stdout, "learn")
+		return
+	}
+
+	// Ctrl+C goes to a running topic too (same process group); the
+	// context just makes sure it doesn't outlive us
+	ctx, stop := shutdown.OnSignal(context.Background())
+	defer stop()
+
+	err := registry.Default.Run(ctx, os.Args[1:])
+	var exit *exec.ExitError
+	switch {
+	case err == nil:
+		return
+	case errors.Is(err, registry.ErrUnknown):
+		fmt.Fprintf(os.Stderr, "learn: unknown command %q\n\n", os.Args[1])
+		registry.Default.Usage(os.Stderr, "learn")
+		stop()
+		os.Exit(2)
+	case errors.As(err, &exit):
+		// a topic failed; it has said why itself
+		stop()
+		os.Exit(exit.ExitCode())
+	}
+	stop()
+	fail(err)
+}
+
+// discover finds the repository root and its topics.
+func discover() (root string, all []topics.Topic, err error) {
+	root, err = topics.FindRoot(".")
+	if err != nil {
+		return "", nil, err
+	}
+	all, err = topics.Discover(root)
+	return root, all, err
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "learn:", err)
+	os.Exit(1)
+}
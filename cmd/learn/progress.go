@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/armaanepiic/Golang/progress"
+	"github.com/armaanepiic/Golang/registry"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+func init() {
+	registry.Register(registry.Command{
+		Name:    "progress",
+		Args:    "[done|reset <topic>]",
+		Summary: "show completed topics and your practice streak, or mark a topic done or not done",
+		Run:     progressCmd,
+	})
+}
+
+func openProgress() (*progress.Tracker, error) {
+	path, err := progress.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return progress.Open(path)
+}
+
+// progressCmd is "learn progress [done|reset <topic>]".
+func progressCmd(ctx context.Context, args []string) error {
+	_, all, err := discover()
+	if err != nil {
+		return err
+	}
+	tr, err := openProgress()
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return showProgress(tr, all)
+	}
+	if len(args) != 2 {
+		return errors.New("usage: learn progress [done|reset <topic>]")
+	}
+	t, err := topics.Lookup(all, args[1])
+	if err != nil {
+		return err
+	}
+	key := progress.TopicKey(t.Name)
+	switch args[0] {
+	case "done":
+		first, err := tr.MarkDone(key)
+		if err != nil {
+			return err
+		}
+		if first {
+			fmt.Println("✓", t.Name, "done")
+		} else {
+			fmt.Println(t.Name, "was already done; practice counted for today")
+		}
+	case "reset":
+		if err := tr.Reset(key); err != nil {
+			return err
+		}
+		fmt.Println(t.Name, "marked as not done")
+	default:
+		return fmt.Errorf("progress: unknown action %q (want done or reset)", args[0])
+	}
+	return nil
+}
+
+func showProgress(tr *progress.Tracker, all []topics.Topic) error {
+	keys := make([]string, len(all))
+	for i, t := range all {
+		keys[i] = progress.TopicKey(t.Name)
+	}
+	s := tr.Stats(keys)
+	fmt.Printf("topics:  %d/%d done (%d%%)\n", s.Done, s.Total, s.Percent())
+	fmt.Printf("streak:  %d day(s), longest %d, %d practice day(s) in all\n", s.CurrentStreak, s.LongestStreak, s.Days)
+	if !s.LastActive.IsZero() {
+		fmt.Println("last:   ", s.LastActive.Format("2006-01-02 15:04"))
+	}
+	fmt.Println()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, t := range all {
+		if at, ok := tr.IsDone(progress.TopicKey(t.Name)); ok {
+			fmt.Fprintf(tw, "✓ %s\t%s\n", t.Name, at.Format(time.DateOnly))
+		} else {
+			fmt.Fprintf(tw, "  %s\t\n", t.Name)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Println("\nmark a topic with: learn progress done <topic>")
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/armaanepiic/Golang/registry"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+func init() {
+	registry.Register(registry.Command{
+		Name:    "run",
+		Args:    "<topic> [args]",
+		Summary: "build and run a topic, passing args to it",
+		Run:     runCmd,
+	})
+}
+
+// runCmd is "learn run <topic> [--] [args]". A non-zero exit of the topic
+// is returned as an *exec.ExitError.
+func runCmd(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("run: which topic? see learn list")
+	}
+	_, all, err := discover()
+	if err != nil {
+		return err
+	}
+	t, err := topics.Lookup(all, args[0])
+	if err != nil {
+		return err
+	}
+	args = args[1:]
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	fmt.Fprintf(os.Stderr, "── %s ──\n", t.Name)
+	return t.Run(ctx, os.Stdin, os.Stdout, os.Stderr, args...)
+}
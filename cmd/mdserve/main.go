@@ -0,0 +1,140 @@
+// Command mdserve serves a directory over HTTP, rendering .md files as
+// HTML pages. Other files are served as they are; a directory shows its
+// README.md if it has one, or a listing.
+//
+//	mdserve -addr :8000 -dir .
+package main
+
+import (
+	"context"
+	"flag"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/markdown"
+	"github.com/armaanepiic/Golang/shutdown"
+)
+
+var page = template.Must(template.New("page").Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 46rem; margin: 2rem auto; padding: 0 1rem; font: 16px/1.6 system-ui, sans-serif; }
+pre { background: #f4f4f4; padding: .8rem; overflow-x: auto; }
+code { font-size: .9em; }
+</style>
+</head>
+<body>
+{{.Body}}
+</body>
+</html>
+`))
+
+type pageData struct {
+	Title string
+	Body  template.HTML
+}
+
+type server struct {
+	fsys fs.FS
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+	if !fs.ValidPath(name) {
+		http.NotFound(w, r)
+		return
+	}
+	info, err := fs.Stat(s.fsys, name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if info.IsDir() {
+		if _, err := fs.Stat(s.fsys, path.Join(name, "README.md")); err == nil {
+			s.render(w, r, path.Join(name, "README.md"))
+			return
+		}
+		s.listing(w, name)
+		return
+	}
+	if strings.HasSuffix(name, ".md") && r.URL.Query().Get("raw") == "" {
+		s.render(w, r, name)
+		return
+	}
+	http.ServeFileFS(w, r, s.fsys, name)
+}
+
+func (s *server) render(w http.ResponseWriter, r *http.Request, name string) {
+	src, err := fs.ReadFile(s.fsys, name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blocks := markdown.Parse(string(src))
+	title := name
+	for _, b := range blocks {
+		if b.Kind == markdown.Heading {
+			title = b.Text
+			break
+		}
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// the renderer escapes all source text, so its output is safe HTML
+	page.Execute(w, pageData{Title: title, Body: template.HTML(markdown.Render(blocks))})
+}
+
+func (s *server) listing(w http.ResponseWriter, dir string) {
+	entries, err := fs.ReadDir(s.fsys, dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var b strings.Builder
+	b.WriteString("# " + dir + "\n\n")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		n := e.Name()
+		if e.IsDir() {
+			n += "/"
+		}
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	for _, n := range names {
+		b.WriteString("- [" + n + "](/" + path.Join(dir, n) + ")\n")
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	page.Execute(w, pageData{Title: dir, Body: template.HTML(markdown.ToHTML(b.String()))})
+}
+
+func main() {
+	addr := flag.String("addr", ":8000", "listen address")
+	dir := flag.String("dir", ".", "directory to serve")
+	flag.Parse()
+
+	srv := &http.Server{Addr: *addr, Handler: &server{fsys: os.DirFS(*dir)}}
+	ctx, stop := shutdown.OnSignal(context.Background())
+	defer stop()
+
+	slog.Info("serving markdown", "dir", *dir, "addr", *addr)
+	if err := shutdown.ListenAndServe(ctx, srv, 5*time.Second, nil); err != nil {
+		slog.Error("server error", "err", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,110 @@
+// Command mini runs mini scripts, or starts a REPL with no arguments.
+//
+//	mini fib.mini
+//	mini -e 'print(1 + 2)'
+//	mini
+//	> let sq = fn(x) { x * x };
+//	> sq(12)
+//	144
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/armaanepiic/Golang/mini"
+)
+
+func main() {
+	expr := flag.String("e", "", "evaluate `source` instead of a file")
+	flag.Parse()
+
+	in := mini.New(os.Stdout)
+	switch {
+	case *expr != "":
+		run(in, *expr)
+	case flag.NArg() > 0:
+		src, err := os.ReadFile(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		run(in, string(src))
+	default:
+		repl(in)
+	}
+}
+
+func run(in *mini.Interpreter, src string) {
+	if _, err := in.Run(src); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// repl reads statements, continuing onto further lines while braces or
+// parentheses are open.
+func repl(in *mini.Interpreter) {
+	sc := bufio.NewScanner(os.Stdin)
+	fmt.Println("mini: let, fn, if/else, return; print, len, str, int builtins; Ctrl+D to quit")
+	var buf strings.Builder
+	for {
+		if buf.Len() == 0 {
+			fmt.Print("> ")
+		} else {
+			fmt.Print(". ")
+		}
+		if !sc.Scan() {
+			fmt.Println()
+			return
+		}
+		buf.WriteString(sc.Text())
+		buf.WriteByte('\n')
+		src := buf.String()
+		if strings.TrimSpace(src) == "" {
+			buf.Reset()
+			continue
+		}
+		if open(src) > 0 {
+			continue
+		}
+		buf.Reset()
+
+		v, err := in.Run(src)
+		switch {
+		case err != nil:
+			fmt.Println("error:", err)
+		case v != mini.Nil:
+			fmt.Println(mini.Inspect(v))
+		}
+	}
+}
+
+// open counts unclosed ( and { in src, ignoring strings and comments
+// only roughly: it is a prompt hint, the parser has the final word.
+func open(src string) int {
+	n := 0
+	inStr := false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case inStr && c == '\\':
+			i++
+		case c == '"':
+			inStr = !inStr
+		case inStr:
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '(' || c == '{':
+			n++
+		case c == ')' || c == '}':
+			n--
+		}
+	}
+	return n
+}
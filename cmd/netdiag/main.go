@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/armaanepiic/Golang/netdiag"
+)
+
+func main() {
+	port := flag.Int("port", 443, "port to dial")
+	useTLS := flag.Bool("tls", true, "also time the TLS handshake")
+	timeout := flag.Duration("timeout", 5*time.Second, "timeout per lookup or dial")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: netdiag [-port 443] [-tls=false] host")
+		os.Exit(2)
+	}
+	host := flag.Arg(0)
+	ctx := context.Background()
+	d := &netdiag.Diag{Timeout: *timeout}
+
+	recs := d.Lookup(ctx, host)
+	fmt.Println("DNS", host)
+	for _, ip := range recs.A {
+		fmt.Println("  A    ", ip)
+	}
+	for _, ip := range recs.AAAA {
+		fmt.Println("  AAAA ", ip)
+	}
+	for _, mx := range recs.MX {
+		fmt.Println("  MX   ", mx.Pref, mx.Host)
+	}
+	for _, txt := range recs.TXT {
+		fmt.Printf("  TXT   %q\n", txt)
+	}
+	for _, kind := range []string{"A", "AAAA", "MX", "TXT"} {
+		if err := recs.Errors[kind]; err != nil {
+			fmt.Println("  error", kind, err)
+		}
+	}
+
+	probes, err := d.ProbeHost(ctx, host, *port, *useTLS)
+	if err != nil {
+		fmt.Println("resolve:", err)
+		os.Exit(1)
+	}
+	fmt.Println("\nDial port", *port)
+	failed := 0
+	for _, p := range probes {
+		switch {
+		case p.Err != nil:
+			failed++
+			fmt.Printf("  %-40s FAIL %v\n", p.Addr, p.Err)
+		case *useTLS:
+			fmt.Printf("  %-40s tcp %-10v tls %v\n", p.Addr, p.Connect.Round(time.Microsecond), p.TLS.Round(time.Microsecond))
+		default:
+			fmt.Printf("  %-40s tcp %v\n", p.Addr, p.Connect.Round(time.Microsecond))
+		}
+	}
+	if failed == len(probes) {
+		os.Exit(1)
+	}
+}
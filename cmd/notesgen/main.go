@@ -0,0 +1,125 @@
+// Command notesgen turns the /* ... */ notes in the example topics into
+// study pages: one page per topic that has notes, plus an index.
+//
+//	notesgen                     # all notes as one Markdown document on stdout
+//	notesgen slice pointer       # only these topics
+//	notesgen -o study            # study/index.md, study/slice.md, ...
+//	notesgen -o study -html      # the same as standalone HTML pages
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/armaanepiic/Golang/markdown"
+	"github.com/armaanepiic/Golang/notes"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+var page = template.Must(template.New("page").Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 46rem; margin: 2rem auto; padding: 0 1rem; font: 16px/1.6 system-ui, sans-serif; }
+pre { background: #f4f4f4; padding: .8rem; overflow-x: auto; tab-size: 4; }
+code { font-size: .9em; }
+</style>
+</head>
+<body>
+{{.Body}}
+</body>
+</html>
+`))
+
+func main() {
+	out := flag.String("o", "", "write one page per topic into this `dir` instead of stdout")
+	asHTML := flag.Bool("html", false, "with -o, write HTML pages instead of Markdown")
+	flag.Parse()
+
+	root, err := topics.FindRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	all, err := topics.Discover(root)
+	if err != nil {
+		fail(err)
+	}
+	if flag.NArg() > 0 {
+		var picked []topics.Topic
+		for _, name := range flag.Args() {
+			t, err := topics.Lookup(all, name)
+			if err != nil {
+				fail(err)
+			}
+			picked = append(picked, t)
+		}
+		all = picked
+	}
+
+	var pages []notes.Page
+	for _, t := range all {
+		ns, err := notes.ExtractDir(t.Dir)
+		if err != nil {
+			fail(err)
+		}
+		if len(ns) > 0 {
+			pages = append(pages, notes.Page{Title: t.Name, Synopsis: t.Synopsis, Notes: ns})
+		}
+	}
+	if len(pages) == 0 {
+		fail(fmt.Errorf("no /* */ notes in %d topics", len(all)))
+	}
+
+	if *out == "" {
+		for i, p := range pages {
+			if i > 0 {
+				fmt.Println("---")
+				fmt.Println()
+			}
+			fmt.Print(p.Markdown())
+		}
+		return
+	}
+
+	ext := ".md"
+	if *asHTML {
+		ext = ".html"
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		fail(err)
+	}
+	fileName := func(p notes.Page) string { return strings.ReplaceAll(p.Title, "/", "-") + ext }
+	write := func(name, title, md string) {
+		data := []byte(md)
+		if *asHTML {
+			var b strings.Builder
+			err := page.Execute(&b, struct {
+				Title string
+				Body  template.HTML
+			}{title, template.HTML(markdown.ToHTML(md))})
+			if err != nil {
+				fail(err)
+			}
+			data = []byte(b.String())
+		}
+		if err := os.WriteFile(filepath.Join(*out, name), data, 0o644); err != nil {
+			fail(err)
+		}
+	}
+	for _, p := range pages {
+		write(fileName(p), p.Title, p.Markdown())
+	}
+	write("index"+ext, "Study notes", notes.Index("Study notes", pages, fileName))
+	fmt.Printf("wrote %d pages and index%s to %s\n", len(pages), ext, *out)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "notesgen:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/slice_pointer.md")
+
+// The tests run the command by re-executing the test binary with
+// NOTESGEN_MAIN set, so main can call os.Exit.
+func TestMain(m *testing.M) {
+	if os.Getenv("NOTESGEN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func notesgen(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "NOTESGEN_MAIN=1")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("notesgen %s: %v\n%s", strings.Join(args, " "), err, stderr.Bytes())
+	}
+	return string(out)
+}
+
+func TestSliceAndPointer(t *testing.T) {
+	got := notesgen(t, "slice", "pointer")
+
+	// the notes at the end of slice/main.go and pointer/main.go
+	for _, want := range []string{
+		"# slice\n",
+		"### slice type => 6\n\n*main.go:95, main*\n",
+		"5. make func with len and capacity\t\t=> s := make([]int, 3, 5)",
+		"rule of expanding space: slice underlying array rule",
+		"# pointer\n",
+		"*main.go:50, main*",
+		"\tprint = func() {...}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output lacks %q", want)
+		}
+	}
+
+	golden := filepath.Join("testdata", "slice_pointer.md")
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s; rerun with -update if the notes changed on purpose\n%s", golden, got)
+	}
+}
+
+func TestWritePages(t *testing.T) {
+	dir := t.TempDir()
+	notesgen(t, "-o", dir, "-html", "slice", "pointer")
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(index), `<a href="slice.html">slice</a>`) {
+		t.Errorf("index.html:\n%s", index)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "slice.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(page), "<title>slice</title>") || !strings.Contains(string(page), "slice type =&gt; 6") {
+		t.Errorf("slice.html:\n%s", page)
+	}
+}
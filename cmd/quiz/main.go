@@ -0,0 +1,254 @@
+// Command quiz asks Go interview questions from the built-in banks (or a
+// directory of JSON/YAML banks), scores the session and records the
+// result.
+//
+//	quiz -list
+//	quiz -topic slices -n 5
+//	quiz -history
+//	quiz -verify      # run every output question's code, to check a bank
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/armaanepiic/Golang/quiz"
+	"github.com/armaanepiic/Golang/snippet"
+)
+
+func main() {
+	topic := flag.String("topic", "", "topic to practise; empty = all topics")
+	dir := flag.String("dir", "", "load banks from this directory instead of the built-in ones")
+	n := flag.Int("n", 0, "ask at most this many questions; 0 = all")
+	shuffle := flag.Bool("shuffle", true, "ask in random order")
+	list := flag.Bool("list", false, "list topics and exit")
+	history := flag.Bool("history", false, "show recorded results and exit")
+	verifyBanks := flag.Bool("verify", false, "check that every output question builds and runs, and exit")
+	results := flag.String("results", "", "results file (default under the user config dir)")
+	flag.Parse()
+
+	if *results == "" {
+		p, err := quiz.DefaultResultsPath()
+		if err != nil {
+			fail(err)
+		}
+		*results = p
+	}
+	if *history {
+		showHistory(*results)
+		return
+	}
+
+	var banks map[string]*quiz.Bank
+	var err error
+	if *dir != "" {
+		banks, err = quiz.LoadFS(os.DirFS(*dir), ".")
+	} else {
+		banks, err = quiz.Builtin()
+	}
+	if err != nil {
+		fail(err)
+	}
+	if *verifyBanks {
+		if !verify(banks) {
+			os.Exit(1)
+		}
+		return
+	}
+	if *list {
+		for _, t := range quiz.Topics(banks) {
+			fmt.Printf("%-12s %d questions\n", t, len(banks[t].Questions))
+		}
+		return
+	}
+
+	name, qs := pick(banks, *topic)
+	if *shuffle {
+		rand.Shuffle(len(qs), func(i, j int) { qs[i], qs[j] = qs[j], qs[i] })
+	}
+	if *n > 0 && *n < len(qs) {
+		qs = qs[:*n]
+	}
+
+	s := quiz.NewSession(name, qs)
+	s.Run = warm(qs)
+	ask(s)
+
+	r := s.Result()
+	fmt.Printf("\nscore: %d/%d (%d%%)\n", r.Correct, r.Total, r.Percent())
+	if r.Total > 0 {
+		if err := quiz.Record(*results, r); err != nil {
+			fmt.Fprintln(os.Stderr, "quiz: recording result:", err)
+		}
+	}
+}
+
+// pick returns the questions of one topic, or of all topics.
+func pick(banks map[string]*quiz.Bank, topic string) (string, []quiz.Question) {
+	if topic != "" {
+		b, ok := banks[topic]
+		if !ok {
+			fail(fmt.Errorf("no topic %q; have %s", topic, strings.Join(quiz.Topics(banks), ", ")))
+		}
+		return topic, append([]quiz.Question(nil), b.Questions...)
+	}
+	var qs []quiz.Question
+	for _, t := range quiz.Topics(banks) {
+		qs = append(qs, banks[t].Questions...)
+	}
+	return "all", qs
+}
+
+func ask(s *quiz.Session) {
+	in := bufio.NewScanner(os.Stdin)
+	ctx := context.Background()
+	for i, q := range s.Questions {
+		fmt.Printf("\n[%d/%d] %s\n", i+1, len(s.Questions), q.Prompt)
+		if q.Code != "" {
+			fmt.Println()
+			for _, line := range strings.Split(strings.TrimRight(q.Code, "\n"), "\n") {
+				fmt.Println("    " + line)
+			}
+			fmt.Println()
+		}
+		for j, c := range q.Choices {
+			fmt.Printf("  %s) %s\n", quiz.Letter(j), c)
+		}
+		if q.Kind == quiz.Output {
+			fmt.Print("output (line breaks as spaces)> ")
+		} else {
+			fmt.Print("answer> ")
+		}
+		if !in.Scan() {
+			fmt.Println()
+			return // Ctrl+D ends the session early; it is still scored
+		}
+
+		v, err := s.Answer(ctx, i, in.Text())
+		switch {
+		case err != nil:
+			fmt.Println("could not check this one:", err)
+			continue
+		case v.Correct:
+			fmt.Println("✓ correct")
+		default:
+			fmt.Printf("✗ the answer is:\n%s\n", indent(v.Want))
+		}
+		if q.Explain != "" {
+			fmt.Println("  " + q.Explain)
+		}
+	}
+}
+
+// warm starts running every output snippet in the background, so the
+// answer is usually ready by the time the learner has typed theirs.
+func warm(qs []quiz.Question) quiz.Runner {
+	type result struct {
+		out string
+		err error
+	}
+	var mu sync.Mutex
+	pending := map[string]chan result{}
+	sem := make(chan struct{}, 4) // go run is heavy; a few at a time
+	start := func(code string) chan result {
+		mu.Lock()
+		defer mu.Unlock()
+		if ch, ok := pending[code]; ok {
+			return ch
+		}
+		ch := make(chan result, 1)
+		pending[code] = ch
+		go func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			out, err := quiz.GoRun(context.Background(), code)
+			ch <- result{out, err}
+		}()
+		return ch
+	}
+	for _, q := range qs {
+		if q.Kind == quiz.Output {
+			start(q.Code)
+		}
+	}
+	return func(ctx context.Context, code string) (string, error) {
+		ch := start(code)
+		select {
+		case r := <-ch:
+			ch <- r // leave it for a repeated question
+			return r.out, r.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// verify runs the code of every output question, a few at a time, and
+// reports the ones that do not build, fail or time out.
+func verify(banks map[string]*quiz.Bank) bool {
+	type job struct {
+		id  string
+		err error
+	}
+	var jobs []*job
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4)
+	for _, t := range quiz.Topics(banks) {
+		for _, q := range banks[t].Questions {
+			if q.Kind != quiz.Output {
+				continue
+			}
+			j := &job{id: t + "/" + q.ID}
+			jobs = append(jobs, j)
+			wg.Go(func() {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				r, err := snippet.Run(context.Background(), q.Code)
+				if err == nil {
+					err = r.Err()
+				}
+				j.err = err
+			})
+		}
+	}
+	wg.Wait()
+	ok := true
+	for _, j := range jobs {
+		if j.err != nil {
+			fmt.Printf("FAIL %s\n%s\n", j.id, indent(j.err.Error()))
+			ok = false
+			continue
+		}
+		fmt.Println("ok  ", j.id)
+	}
+	return ok
+}
+
+func showHistory(path string) {
+	rs, err := quiz.History(path)
+	if err != nil {
+		fail(err)
+	}
+	if len(rs) == 0 {
+		fmt.Println("no results yet in", path)
+		return
+	}
+	for _, r := range rs {
+		fmt.Printf("%s  %-10s %2d/%-2d %3d%%\n", r.Time.Format("2006-01-02 15:04"), r.Topic, r.Correct, r.Total, r.Percent())
+	}
+}
+
+func indent(s string) string {
+	return "    " + strings.ReplaceAll(s, "\n", "\n    ")
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "quiz:", err)
+	os.Exit(1)
+}
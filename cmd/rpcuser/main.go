@@ -0,0 +1,86 @@
+// Command rpcuser runs the net/rpc user service, or a client against it.
+//
+//	go run ./cmd/rpcuser                 # server on localhost:50052
+//	go run ./cmd/rpcuser -client         # client against it
+//
+// The package tests run the same calls in memory over net.Pipe.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/retry"
+	"github.com/armaanepiic/Golang/rpcuser"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50052", "server address")
+	client := flag.Bool("client", false, "run as a client against -addr")
+	flag.Parse()
+
+	store := userstore.New()
+	store.Create(userstore.User{Name: "Arman", Age: 30})
+
+	switch {
+	case *client:
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		c, err := rpcuser.Dial(ctx, *addr, retry.WithMaxAttempts(5))
+		if err != nil {
+			log.Fatal(err)
+		}
+		runClient(c)
+	default:
+		ln, err := net.Listen("tcp", *addr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("net/rpc user service running on", *addr)
+		rpcuser.NewServer(store).Accept(ln)
+	}
+}
+
+func runClient(c *rpcuser.Client) {
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	u, err := c.Create(ctx, userstore.User{Name: "Nusrat", Age: 28})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("created:", u)
+
+	u.Age++
+	if err := c.Update(ctx, u); err != nil {
+		log.Fatal(err)
+	}
+
+	users, err := c.List(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, u := range users {
+		fmt.Println("user:", u.ID, u.Name, u.Age)
+	}
+
+	// service errors come back as the same sentinel values
+	_, err = c.Get(ctx, 999)
+	fmt.Println("get 999:", err, "| is ErrNotFound:", errors.Is(err, userstore.ErrNotFound))
+	_, err = c.Create(ctx, userstore.User{Name: "  "})
+	fmt.Println("create blank:", err, "| is ErrInvalid:", errors.Is(err, rpcuser.ErrInvalid))
+
+	// the next call after the connection is gone dials a new one
+	c.Close()
+	if _, err := c.Get(ctx, 1); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("get after redial: ok")
+}
@@ -0,0 +1,131 @@
+// Command serbench compares protobuf, JSON and gob for a list of users:
+// payload size, encode time and decode time.
+//
+// The same comparison runs as ordinary benchmarks, for benchstat and
+// friends:
+//
+//	go test -bench . -benchmem ./cmd/serbench
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/usergrpc"
+	"github.com/armaanepiic/Golang/userpb"
+)
+
+type codec struct {
+	name   string
+	encode func([]userstore.User) ([]byte, error)
+	decode func([]byte) ([]userstore.User, error)
+}
+
+var codecs = []codec{
+	{
+		name: "protobuf",
+		encode: func(users []userstore.User) ([]byte, error) {
+			msg := &userpb.ListUsersResponse{Users: make([]*userpb.User, len(users))}
+			for i, u := range users {
+				msg.Users[i] = usergrpc.ToProto(u)
+			}
+			return proto.Marshal(msg)
+		},
+		decode: func(data []byte) ([]userstore.User, error) {
+			var msg userpb.ListUsersResponse
+			if err := proto.Unmarshal(data, &msg); err != nil {
+				return nil, err
+			}
+			users := make([]userstore.User, len(msg.Users))
+			for i, p := range msg.Users {
+				users[i] = usergrpc.FromProto(p)
+			}
+			return users, nil
+		},
+	},
+	{
+		name:   "json",
+		encode: func(users []userstore.User) ([]byte, error) { return json.Marshal(users) },
+		decode: func(data []byte) ([]userstore.User, error) {
+			var users []userstore.User
+			err := json.Unmarshal(data, &users)
+			return users, err
+		},
+	},
+	{
+		name: "gob",
+		encode: func(users []userstore.User) ([]byte, error) {
+			var buf bytes.Buffer
+			err := gob.NewEncoder(&buf).Encode(users)
+			return buf.Bytes(), err
+		},
+		decode: func(data []byte) ([]userstore.User, error) {
+			var users []userstore.User
+			err := gob.NewDecoder(bytes.NewReader(data)).Decode(&users)
+			return users, err
+		},
+	},
+}
+
+func main() {
+	n := flag.Int("n", 10000, "number of users")
+	flag.Parse()
+
+	users := makeUsers(*n)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "format\tbytes\tbytes/user\tencode\tdecode\tencode allocs\tdecode allocs\t")
+	for _, c := range codecs {
+		data, err := c.encode(users)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, c.name, err)
+			os.Exit(1)
+		}
+		back, err := c.decode(data)
+		if err != nil || len(back) != len(users) || back[len(back)-1] != users[len(users)-1] {
+			fmt.Fprintln(os.Stderr, c.name, "round trip failed", err)
+			os.Exit(1)
+		}
+
+		// testing.Benchmark runs the function with a growing b.N until the
+		// timing is stable, just like go test -bench
+		enc := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				c.encode(users)
+			}
+		})
+		dec := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				c.decode(data)
+			}
+		})
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%v\t%v\t%d\t%d\t\n",
+			c.name, len(data), float64(len(data))/float64(len(users)),
+			nsPerOp(enc), nsPerOp(dec), enc.AllocsPerOp(), dec.AllocsPerOp())
+	}
+	tw.Flush()
+}
+
+// makeUsers returns n users with distinct IDs and names.
+func makeUsers(n int) []userstore.User {
+	users := make([]userstore.User, n)
+	for i := range users {
+		users[i] = userstore.User{ID: i + 1, Name: fmt.Sprintf("user-%d", i+1), Age: 18 + i%60}
+	}
+	return users
+}
+
+func nsPerOp(r testing.BenchmarkResult) string {
+	return fmt.Sprintf("%.2fms", float64(r.NsPerOp())/1e6)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+)
+
+// benchUsers matches the command's default -n.
+const benchUsers = 10000
+
+func TestRoundTrip(t *testing.T) {
+	users := makeUsers(100)
+	for _, c := range codecs {
+		data, err := c.encode(users)
+		if err != nil {
+			t.Fatalf("%s: encode: %v", c.name, err)
+		}
+		back, err := c.decode(data)
+		if err != nil {
+			t.Fatalf("%s: decode: %v", c.name, err)
+		}
+		if len(back) != len(users) {
+			t.Fatalf("%s: %d users back, want %d", c.name, len(back), len(users))
+		}
+		for i := range users {
+			if back[i] != users[i] {
+				t.Fatalf("%s: user %d = %+v, want %+v", c.name, i, back[i], users[i])
+			}
+		}
+	}
+}
+
+func BenchmarkJSON(b *testing.B)  { benchCodec(b, "json") }
+func BenchmarkGob(b *testing.B)   { benchCodec(b, "gob") }
+func BenchmarkProto(b *testing.B) { benchCodec(b, "protobuf") }
+
+// benchCodec runs Encode and Decode sub-benchmarks for the named codec
+// and reports the payload size per user.
+func benchCodec(b *testing.B, name string) {
+	var c codec
+	for _, cc := range codecs {
+		if cc.name == name {
+			c = cc
+		}
+	}
+	users := makeUsers(benchUsers)
+	data, err := c.encode(users)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Encode", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		for b.Loop() {
+			if _, err := c.encode(users); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(len(data))/benchUsers, "bytes/user")
+	})
+	b.Run("Decode", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(data)))
+		var back []userstore.User
+		for b.Loop() {
+			if back, err = c.decode(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if len(back) != benchUsers {
+			b.Fatalf("decoded %d users", len(back))
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/armaanepiic/Golang/shortener"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	base := flag.String("base", "http://localhost:8080", "public base URL of short links")
+	file := flag.String("file", "", "JSON file to persist links in (default: memory only)")
+	flag.Parse()
+
+	var store shortener.Store = shortener.NewMemoryStore()
+	if *file != "" {
+		fs, err := shortener.OpenFileStore(*file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		store = fs
+	}
+
+	fmt.Println("Shortener running on", *addr)
+	log.Fatal(http.ListenAndServe(*addr, shortener.NewServer(store, *base)))
+}
@@ -0,0 +1,366 @@
+// Command tui is a terminal browser for the example topics: pick a topic
+// in the sidebar, press Enter to build and run it, and scroll through its
+// output on the right. It uses plain ANSI escapes and stty, so it needs a
+// Unix-like terminal but no extra modules.
+//
+//	go run ./cmd/tui
+//
+// Keys: ↑/↓ or j/k move, Tab switches pane, Enter runs, s stops, d marks
+// the topic done, c clears, PgUp/PgDn/g/G scroll, q quits.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/catalog"
+	"github.com/armaanepiic/Golang/progress"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+// maxLines caps the output kept per topic.
+const maxLines = 10000
+
+type pane int
+
+const (
+	sidebarPane pane = iota
+	outputPane
+)
+
+// run is the output of one topic's latest run.
+type run struct {
+	lines   []string
+	status  string
+	started time.Time
+	cancel  context.CancelFunc // nil once finished
+}
+
+// Events from the runner goroutines.
+type (
+	lineEvent struct{ topic, line string }
+	doneEvent struct {
+		topic string
+		err   error
+	}
+	statusEvent struct{ topic, status string }
+)
+
+type ui struct {
+	topics  []topics.Topic
+	tracker *progress.Tracker // nil if progress can't be loaded
+	catalog *catalog.Catalog  // nil if the repository didn't load
+	binDir  string
+
+	sel, top int // selected topic and first visible sidebar row
+	focus    pane
+	runs     map[string]*run
+	scroll   int  // first visible output line
+	follow   bool // keep the newest output in view
+	w, h     int
+	msg      string
+
+	events chan any
+}
+
+func main() {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		fail(errors.New("needs an interactive terminal; use cmd/learn in scripts"))
+	}
+	root, err := topics.FindRoot(".")
+	if err != nil {
+		fail(err)
+	}
+	all, err := topics.Discover(root)
+	if err != nil {
+		fail(err)
+	}
+	binDir, err := os.MkdirTemp("", "tui-bin-*")
+	if err != nil {
+		fail(err)
+	}
+	defer os.RemoveAll(binDir)
+
+	u := &ui{
+		topics: all,
+		binDir: binDir,
+		runs:   map[string]*run{},
+		follow: true,
+		events: make(chan any, 256),
+	}
+	if path, err := progress.DefaultPath(); err == nil {
+		u.tracker, _ = progress.Open(path)
+	}
+	u.catalog, _ = catalog.Build(root)
+
+	restore, err := rawMode()
+	if err != nil {
+		fail(err)
+	}
+	fmt.Print(altScreen, hideCursor)
+	defer func() {
+		fmt.Print(reset, showCursor, mainScreen)
+		restore()
+	}()
+	u.loop()
+}
+
+func (u *ui) loop() {
+	keys := make(chan string, 16)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			for _, k := range parseKeys(string(buf[:n])) {
+				keys <- k
+			}
+		}
+	}()
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+
+	// output can arrive thousands of lines a second; redraw at most ~30
+	// times a second while it streams
+	tick := time.NewTicker(33 * time.Millisecond)
+	defer tick.Stop()
+	dirty, changed := true, false
+	u.w, u.h = termSize()
+
+	for {
+		if dirty {
+			u.render(os.Stdout)
+			dirty = false
+		}
+		select {
+		case k, ok := <-keys:
+			if !ok || !u.key(k) {
+				u.stopAll()
+				return
+			}
+			dirty = true
+		case ev := <-u.events:
+			u.event(ev)
+			changed = true // drawn on the next tick
+		case <-tick.C:
+			dirty = dirty || changed
+			changed = false
+		case <-resize:
+			u.w, u.h = termSize()
+			dirty = true
+		}
+	}
+}
+
+// key handles one key press and reports whether to keep going.
+func (u *ui) key(k string) bool {
+	u.msg = ""
+	if resizeOnKey {
+		u.w, u.h = termSize()
+	}
+	page := max(1, u.bodyHeight()-1)
+	switch k {
+	case "q", keyCtrlC:
+		return false
+	case keyTab, keyLeft, keyRight, "h", "l":
+		if u.focus == sidebarPane {
+			u.focus = outputPane
+		} else {
+			u.focus = sidebarPane
+		}
+	case keyUp, "k":
+		u.move(-1)
+	case keyDown, "j":
+		u.move(1)
+	case keyPgUp, "b":
+		u.scrollBy(-page)
+	case keyPgDn, " ":
+		u.scrollBy(page)
+	case keyHome, "g":
+		u.follow = false
+		u.scroll = 0
+	case keyEnd, "G":
+		u.follow = true
+	case keyEnter, "r":
+		u.start(u.topics[u.sel])
+	case "s":
+		if r := u.runs[u.topics[u.sel].Name]; r != nil && r.cancel != nil {
+			r.cancel()
+		}
+	case "c":
+		if r := u.runs[u.topics[u.sel].Name]; r != nil && r.cancel == nil {
+			delete(u.runs, u.topics[u.sel].Name)
+		}
+	case "d":
+		u.toggleDone()
+	}
+	return true
+}
+
+func (u *ui) move(d int) {
+	if u.focus == outputPane {
+		u.scrollBy(d)
+		return
+	}
+	u.sel = min(max(u.sel+d, 0), len(u.topics)-1)
+	u.follow = true
+}
+
+func (u *ui) scrollBy(d int) {
+	r := u.runs[u.topics[u.sel].Name]
+	if r == nil {
+		return
+	}
+	last := max(0, len(r.lines)-u.bodyHeight())
+	if u.follow {
+		u.scroll = last
+	}
+	u.scroll = min(max(u.scroll+d, 0), last)
+	u.follow = u.scroll == last
+}
+
+func (u *ui) toggleDone() {
+	if u.tracker == nil {
+		u.msg = "progress file unavailable"
+		return
+	}
+	key := progress.TopicKey(u.topics[u.sel].Name)
+	var err error
+	if _, done := u.tracker.IsDone(key); done {
+		err = u.tracker.Reset(key)
+	} else {
+		_, err = u.tracker.MarkDone(key)
+	}
+	if err != nil {
+		u.msg = err.Error()
+	}
+}
+
+func (u *ui) stopAll() {
+	for _, r := range u.runs {
+		if r.cancel != nil {
+			r.cancel()
+		}
+	}
+}
+
+func (u *ui) event(ev any) {
+	switch ev := ev.(type) {
+	case lineEvent:
+		if r := u.runs[ev.topic]; r != nil {
+			r.lines = append(r.lines, ev.line)
+			if len(r.lines) > maxLines {
+				r.lines = r.lines[len(r.lines)-maxLines:]
+			}
+		}
+	case statusEvent:
+		if r := u.runs[ev.topic]; r != nil {
+			r.status = ev.status
+		}
+	case doneEvent:
+		r := u.runs[ev.topic]
+		if r == nil {
+			return
+		}
+		r.cancel = nil
+		elapsed := time.Since(r.started).Round(10 * time.Millisecond)
+		var exit *exec.ExitError
+		switch {
+		case ev.err == nil:
+			r.status = fmt.Sprintf("exit 0 · %v", elapsed)
+		case errors.Is(ev.err, context.Canceled):
+			r.status = "stopped"
+		case errors.As(ev.err, &exit):
+			r.status = fmt.Sprintf("%v · %v", ev.err, elapsed)
+		default:
+			r.status = ev.err.Error()
+		}
+	}
+}
+
+// start builds t and runs it, streaming stdout and stderr into its run.
+// Stdin is not connected: the terminal belongs to the UI.
+func (u *ui) start(t topics.Topic) {
+	if r := u.runs[t.Name]; r != nil && r.cancel != nil {
+		u.msg = t.Name + " is already running (s stops it)"
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	u.runs[t.Name] = &run{status: "building…", started: time.Now(), cancel: cancel}
+	u.follow = true
+
+	go func() {
+		bin := filepath.Join(u.binDir, strings.ReplaceAll(t.Name, "/", "_"))
+		build := t.BuildCommand(ctx, bin)
+		if out, err := build.CombinedOutput(); err != nil {
+			u.send(t.Name, out)
+			u.events <- doneEvent{t.Name, contextErr(ctx, err)}
+			return
+		}
+		u.events <- statusEvent{t.Name, "running…"}
+
+		pr, pw := io.Pipe()
+		cmd := exec.CommandContext(ctx, bin)
+		cmd.Dir = t.Dir
+		cmd.Stdout, cmd.Stderr = pw, pw
+		cmd.WaitDelay = time.Second // don't hang on pipes a grandchild holds open
+		errc := make(chan error, 1)
+		go func() {
+			errc <- cmd.Run()
+			pw.Close()
+		}()
+		sc := bufio.NewScanner(pr)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		for sc.Scan() {
+			u.events <- lineEvent{t.Name, clean(sc.Text())}
+		}
+		pr.CloseWithError(io.ErrClosedPipe) // unblock the writer if a line was too long
+		u.events <- doneEvent{t.Name, contextErr(ctx, <-errc)}
+	}()
+}
+
+func (u *ui) send(topic string, out []byte) {
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		u.events <- lineEvent{topic, clean(line)}
+	}
+}
+
+// contextErr prefers context.Canceled over the "signal: killed" it causes.
+func contextErr(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+var csi = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// clean makes a line of program output safe to draw: escape sequences and
+// control characters are dropped and tabs expanded.
+func clean(s string) string {
+	s = csi.ReplaceAllString(s, "")
+	s = strings.ReplaceAll(s, "\t", "    ")
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "tui:", err)
+	os.Exit(1)
+}
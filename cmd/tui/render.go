@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/armaanepiic/Golang/progress"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+// bodyHeight is the number of rows between the title and status bars.
+func (u *ui) bodyHeight() int { return max(1, u.h-2) }
+
+func (u *ui) sidebarWidth() int { return min(28, max(12, u.w/3)) }
+
+// render redraws the whole screen in one write, which avoids flicker
+// without having to track what changed.
+func (u *ui) render(w io.Writer) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+
+	t := u.topics[u.sel]
+	r := u.runs[t.Name]
+	bodyH := u.bodyHeight()
+	sw := u.sidebarWidth()
+	ow := max(1, u.w-sw-1)
+
+	title := " " + t.Name
+	if t.Synopsis != "" {
+		title += " — " + t.Synopsis
+	}
+	if r != nil {
+		title += "  [" + r.status + "]"
+	}
+	b.WriteString(reverse + bold + fit(title, u.w) + reset)
+
+	// keep the selection on screen
+	if u.sel < u.top {
+		u.top = u.sel
+	}
+	if u.sel >= u.top+bodyH {
+		u.top = u.sel - bodyH + 1
+	}
+	var lines []string
+	if r != nil {
+		lines = r.lines
+	}
+	idle := u.idleLines(t)
+	last := max(0, len(lines)-bodyH)
+	if u.follow || u.scroll > last {
+		u.scroll = last
+	}
+
+	for row := range bodyH {
+		fmt.Fprintf(&b, "\x1b[%d;1H", row+2)
+		b.WriteString(u.sidebarRow(u.top+row, sw))
+		b.WriteString(dim + "│" + reset)
+		switch i := u.scroll + row; {
+		case i < len(lines):
+			b.WriteString(fit(lines[i], ow))
+		case r == nil && row < len(idle):
+			b.WriteString(dim + fit(idle[row], ow) + reset)
+		default:
+			b.WriteString(strings.Repeat(" ", ow))
+		}
+	}
+
+	fmt.Fprintf(&b, "\x1b[%d;1H", u.h)
+	status := " ↑↓ move  Tab pane  Enter run  s stop  d done  c clear  PgUp/PgDn scroll  q quit"
+	if u.msg != "" {
+		status = " " + u.msg
+	} else if len(lines) > bodyH {
+		status = fmt.Sprintf(" %d-%d/%d |%s", u.scroll+1, min(u.scroll+bodyH, len(lines)), len(lines), status)
+	}
+	b.WriteString(reverse + fit(status, u.w) + reset)
+	io.WriteString(w, b.String())
+}
+
+// idleLines is what the output pane shows before t has run: how to run
+// it and, from the catalog, the stubs it leaves to fill in.
+func (u *ui) idleLines(t topics.Topic) []string {
+	lines := []string{" press Enter to run " + t.Name}
+	if u.catalog == nil {
+		return lines
+	}
+	todos := u.catalog.TODOsIn(t.Name)
+	if len(todos) == 0 {
+		return lines
+	}
+	lines = append(lines, "", " to do:")
+	for _, td := range todos {
+		lines = append(lines, fmt.Sprintf("   %s:%d  %s: %s", td.File, td.Line, td.Func, td.Text))
+	}
+	return append(lines, "", " then: go run ./cmd/learn check "+t.Name)
+}
+
+func (u *ui) sidebarRow(i, width int) string {
+	if i >= len(u.topics) {
+		return strings.Repeat(" ", width)
+	}
+	t := u.topics[i]
+	mark := "  "
+	if u.tracker != nil {
+		if _, ok := u.tracker.IsDone(progress.TopicKey(t.Name)); ok {
+			mark = green + "✓ " + reset
+		}
+	}
+	if r := u.runs[t.Name]; r != nil && r.cancel != nil {
+		mark = "▸ "
+	}
+	text := fit(t.Name, width-2)
+	switch {
+	case i == u.sel && u.focus == sidebarPane:
+		text = reverse + text + reset
+	case i == u.sel:
+		text = bold + text + reset
+	}
+	return mark + text
+}
+
+// fit pads or truncates s to exactly width columns, counting one column
+// per rune.
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := utf8.RuneCountInString(s)
+	if n <= width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	rs := []rune(s)
+	return string(rs[:width-1]) + "…"
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// notifyResize is a no-op where there is no SIGWINCH; the size is read
+// again on every key press instead.
+func notifyResize(chan<- os.Signal) {}
+
+const resizeOnKey = true
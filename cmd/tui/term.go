@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI sequences used by the UI.
+const (
+	altScreen  = "\x1b[?1049h"
+	mainScreen = "\x1b[?1049l"
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+	reverse    = "\x1b[7m"
+	bold       = "\x1b[1m"
+	dim        = "\x1b[2m"
+	green      = "\x1b[32m"
+	reset      = "\x1b[0m"
+)
+
+// stty runs stty on the terminal. Using it instead of ioctls keeps the
+// raw-mode code free of per-OS syscall details.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// rawMode switches the terminal to raw, no-echo input and returns a
+// function that restores the previous settings.
+func rawMode() (restore func(), err error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("stty -g: %w (is stdin a terminal?)", err)
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return func() { stty(saved) }, nil
+}
+
+// termSize returns the terminal's columns and rows, or 80x24 if it
+// cannot be read.
+func termSize() (w, h int) {
+	out, err := stty("size")
+	if err == nil {
+		if _, err := fmt.Sscan(out, &h, &w); err == nil && w > 0 && h > 0 {
+			return w, h
+		}
+	}
+	return 80, 24
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// Key names produced by parseKeys for non-printing keys.
+const (
+	keyUp    = "up"
+	keyDown  = "down"
+	keyLeft  = "left"
+	keyRight = "right"
+	keyPgUp  = "pgup"
+	keyPgDn  = "pgdn"
+	keyHome  = "home"
+	keyEnd   = "end"
+	keyEnter = "enter"
+	keyTab   = "tab"
+	keyEsc   = "esc"
+	keyCtrlC = "ctrl+c"
+)
+
+var escapes = map[string]string{
+	"\x1b[A": keyUp, "\x1b[B": keyDown, "\x1b[C": keyRight, "\x1b[D": keyLeft,
+	"\x1bOA": keyUp, "\x1bOB": keyDown, "\x1bOC": keyRight, "\x1bOD": keyLeft,
+	"\x1b[5~": keyPgUp, "\x1b[6~": keyPgDn,
+	"\x1b[H": keyHome, "\x1b[F": keyEnd, "\x1b[1~": keyHome, "\x1b[4~": keyEnd,
+	"\x1bOH": keyHome, "\x1bOF": keyEnd,
+}
+
+// parseKeys splits one read from the terminal into keys. A read usually
+// holds one key, but pasting or fast typing can deliver several.
+func parseKeys(s string) []string {
+	var keys []string
+	for s != "" {
+		if s[0] == 0x1b && len(s) > 1 {
+			// CSI: ESC [ params final, or SS3: ESC O final
+			end := 2
+			if s[1] == '[' {
+				for end < len(s) && !(s[end] >= 0x40 && s[end] <= 0x7e) {
+					end++
+				}
+			}
+			end = min(end+1, len(s))
+			if k, ok := escapes[s[:end]]; ok {
+				keys = append(keys, k)
+			}
+			s = s[end:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		switch r {
+		case 0x1b:
+			keys = append(keys, keyEsc)
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case '\t':
+			keys = append(keys, keyTab)
+		case 3:
+			keys = append(keys, keyCtrlC)
+		default:
+			keys = append(keys, string(r))
+		}
+		s = s[size:]
+	}
+	return keys
+}
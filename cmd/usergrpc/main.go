@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/usergrpc"
+	"github.com/armaanepiic/Golang/userpb"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "server address")
+	client := flag.Bool("client", false, "run as a client against -addr")
+	flag.Parse()
+
+	if *client {
+		runClient(*addr)
+		return
+	}
+
+	store := userstore.New()
+	store.Create(userstore.User{Name: "Arman", Age: 30})
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("gRPC user service running on", *addr)
+	log.Fatal(usergrpc.Register(store, nil).Serve(ln))
+}
+
+func runClient(addr string) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c := userpb.NewUserServiceClient(conn)
+	u, err := c.CreateUser(ctx, &userpb.CreateUserRequest{Name: "Nusrat", Age: 28})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("created:", u)
+
+	list, err := c.ListUsers(ctx, &userpb.ListUsersRequest{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, u := range list.GetUsers() {
+		fmt.Println("user:", u.GetId(), u.GetName(), u.GetAge())
+	}
+}
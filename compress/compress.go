@@ -0,0 +1,100 @@
+// Package compress has gzip helpers for byte slices and streams, and an
+// HTTP middleware that compresses responses for clients that accept it.
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrTooLarge is returned by GunzipBytes when the output exceeds limit.
+var ErrTooLarge = errors.New("compress: decompressed data too large")
+
+// GzipBytes compresses b at the default level.
+func GzipBytes(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := getWriter(&buf)
+	defer putWriter(zw)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GunzipBytes decompresses gzip data. limit caps the decompressed size to
+// guard against zip bombs; 0 means no limit.
+func GunzipBytes(b []byte, limit int64) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var r io.Reader = zr
+	if limit > 0 {
+		r = io.LimitReader(zr, limit+1)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(out)) > limit {
+		return nil, ErrTooLarge
+	}
+	return out, nil
+}
+
+// NewWriter returns a WriteCloser that gzips everything written to it
+// into w. Close flushes the gzip footer but does not close w.
+func NewWriter(w io.Writer) io.WriteCloser {
+	return &writer{zw: getWriter(w)}
+}
+
+type writer struct {
+	zw *gzip.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.zw == nil {
+		return 0, io.ErrClosedPipe
+	}
+	return w.zw.Write(p)
+}
+
+func (w *writer) Close() error {
+	if w.zw == nil {
+		return nil
+	}
+	err := w.zw.Close()
+	putWriter(w.zw)
+	w.zw = nil
+	return err
+}
+
+// NewReader returns a ReadCloser that decompresses the gzip stream r.
+// Closing it does not close r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+var writers = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// getWriter reuses a gzip.Writer; each one holds a few hundred KiB of
+// compression state, so allocating one per response adds up.
+func getWriter(w io.Writer) *gzip.Writer {
+	zw := writers.Get().(*gzip.Writer)
+	zw.Reset(w)
+	return zw
+}
+
+func putWriter(zw *gzip.Writer) {
+	zw.Reset(io.Discard)
+	writers.Put(zw)
+}
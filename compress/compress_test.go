@@ -0,0 +1,94 @@
+package compress_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/compress"
+)
+
+func TestBytesRoundTrip(t *testing.T) {
+	for _, in := range [][]byte{nil, []byte("a"), bytes.Repeat([]byte("gopher "), 10_000)} {
+		z, err := compress.GzipBytes(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := compress.GunzipBytes(z, 0)
+		if err != nil || !bytes.Equal(out, in) {
+			t.Fatalf("round trip of %d bytes: got %d bytes, %v", len(in), len(out), err)
+		}
+	}
+}
+
+func TestGunzipLimit(t *testing.T) {
+	z, err := compress.GzipBytes(bytes.Repeat([]byte{0}, 1<<20)) // a small bomb
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := compress.GunzipBytes(z, 1<<20-1); !errors.Is(err, compress.ErrTooLarge) {
+		t.Fatalf("one byte over the limit: %v", err)
+	}
+	if out, err := compress.GunzipBytes(z, 1<<20); err != nil || len(out) != 1<<20 {
+		t.Fatalf("exactly the limit: %d bytes, %v", len(out), err)
+	}
+	if _, err := compress.GunzipBytes([]byte("not gzip"), 0); err == nil {
+		t.Fatal("garbage decompressed")
+	}
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := compress.NewWriter(&buf)
+	var want strings.Builder
+	for i := range 1000 {
+		line := strings.Repeat("x", i%50) + "\n"
+		want.WriteString(line)
+		if _, err := io.WriteString(w, line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("late")); err == nil {
+		t.Error("Write after Close succeeded")
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close = %v", err)
+	}
+
+	r, err := compress.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != want.String() {
+		t.Fatalf("stream round trip: %d bytes, %v", len(got), err)
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := map[string]bool{
+		"":                    false,
+		"gzip":                true,
+		"deflate, gzip;q=0.5": true,
+		"GZIP":                true,
+		"x-gzip":              true,
+		"gzip;q=0":            false,
+		"gzip;q=0.0":          false,
+		"br":                  false,
+		"*":                   true,
+		"*;q=0":               false,
+		"*, gzip;q=0":         false,
+		"identity, *;q=0.1":   true,
+	}
+	for header, want := range tests {
+		if got := compress.AcceptsGzip(header); got != want {
+			t.Errorf("AcceptsGzip(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package compress_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/compress"
+)
+
+// serve runs one request through Middleware(100) in front of a handler
+// writing body with the given Content-Type.
+func serve(t *testing.T, contentType, body, acceptEncoding string) *http.Response {
+	t.Helper()
+	h := compress.Middleware(100)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		io.WriteString(w, body)
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+func read(t *testing.T, res *http.Response) string {
+	t.Helper()
+	var r io.Reader = res.Body
+	if res.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = zr
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestMiddlewareCompresses(t *testing.T) {
+	body := strings.Repeat(`{"name":"gopher"}`, 20)
+	res := serve(t, "application/json", body, "gzip")
+	if res.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q", res.Header.Get("Content-Encoding"))
+	}
+	if res.Header.Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Vary = %q", res.Header.Get("Vary"))
+	}
+	if got := read(t, res); got != body {
+		t.Fatalf("body = %q", got)
+	}
+}
+
+func TestMiddlewareSkips(t *testing.T) {
+	big := strings.Repeat("a", 500)
+	tests := []struct {
+		name, contentType, body, accept string
+	}{
+		{"below minSize", "text/plain", "short", "gzip"},
+		{"no Accept-Encoding", "text/plain", big, ""},
+		{"gzip refused", "text/plain", big, "gzip;q=0"},
+		{"event stream", "text/event-stream", big, "gzip"},
+		{"already compressed type", "image/png", big, "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serve(t, tt.contentType, tt.body, tt.accept)
+			if ce := res.Header.Get("Content-Encoding"); ce != "" {
+				t.Fatalf("Content-Encoding = %q", ce)
+			}
+			if got := read(t, res); got != tt.body {
+				t.Fatalf("body = %q", got)
+			}
+		})
+	}
+}
+
+func TestMiddlewareFlushBeforeMinSize(t *testing.T) {
+	h := compress.Middleware(100)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "first ")
+		http.NewResponseController(w).Flush()
+		io.WriteString(w, strings.Repeat("b", 500))
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Header().Get("Content-Encoding") != "" || !rec.Flushed {
+		t.Fatalf("flushed early response: encoding %q, flushed %v", rec.Header().Get("Content-Encoding"), rec.Flushed)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "first bbb") {
+		t.Fatalf("body = %.20q", rec.Body.String())
+	}
+}
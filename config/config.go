@@ -0,0 +1,251 @@
+// Package config fills a struct from, in order of increasing priority,
+// its `default` tags, a config file, environment variables and
+// command-line flags.
+//
+// Every exported field gets a dotted key built from its `config` tag (or
+// its lower-cased name), with nested structs adding a level:
+//
+//	type Config struct {
+//		Server struct {
+//			Addr    string        `config:"addr" default:":8080"`
+//			Timeout time.Duration `default:"5s"`
+//		}
+//		Debug bool
+//	}
+//
+// gives the keys server.addr, server.timeout and debug. With EnvPrefix
+// "APP" they are read from APP_SERVER_ADDR, APP_SERVER_TIMEOUT and
+// APP_DEBUG, and as flags they are -server.addr, -server.timeout and
+// -debug. Files are flattened to the same keys.
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Options says where Load looks for values.
+type Options struct {
+	File      string   // config file; its extension picks the decoder. Empty = none
+	EnvPrefix string   // prefix of environment variables; empty = no env lookup
+	Args      []string // command-line arguments without the program name; nil = no flags
+	Name      string   // program name used in flag usage messages
+}
+
+// Load fills dst, which must be a pointer to a struct.
+func Load(dst any, opts Options) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: Load needs a pointer to a struct, got %T", dst)
+	}
+	fields := collect(rv.Elem(), "")
+
+	// defaults
+	for _, f := range fields {
+		if f.def != "" {
+			if err := f.set(f.def); err != nil {
+				return fmt.Errorf("config: default for %s: %w", f.key, err)
+			}
+		}
+	}
+
+	// file
+	if opts.File != "" {
+		values, err := ReadFile(opts.File)
+		if err != nil {
+			return err
+		}
+		known := make(map[string]*field, len(fields))
+		for _, f := range fields {
+			known[f.key] = f
+		}
+		for k, v := range values {
+			f, ok := known[k]
+			if !ok {
+				return fmt.Errorf("config: %s: unknown key %q", opts.File, k)
+			}
+			if err := f.set(v); err != nil {
+				return fmt.Errorf("config: %s: %s: %w", opts.File, k, err)
+			}
+		}
+	}
+
+	// environment
+	if opts.EnvPrefix != "" {
+		for _, f := range fields {
+			name := EnvName(opts.EnvPrefix, f.key)
+			if v, ok := os.LookupEnv(name); ok {
+				if err := f.set(v); err != nil {
+					return fmt.Errorf("config: $%s: %w", name, err)
+				}
+			}
+		}
+	}
+
+	// flags: only the ones actually given override the rest
+	if opts.Args != nil {
+		fs := flag.NewFlagSet(opts.Name, flag.ContinueOnError)
+		for _, f := range fields {
+			fs.Var(flagValue{f}, f.key, f.usage)
+		}
+		if err := fs.Parse(opts.Args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flagValue lets the flag package set a field directly. Bool fields are
+// boolean flags, so a bare -debug means -debug=true.
+type flagValue struct{ f *field }
+
+func (v flagValue) String() string {
+	if v.f == nil { // the flag package probes the zero value
+		return ""
+	}
+	return v.f.current()
+}
+
+func (v flagValue) Set(s string) error { return v.f.set(s) }
+
+func (v flagValue) IsBoolFlag() bool { return v.f.v.Kind() == reflect.Bool }
+
+// EnvName returns the environment variable for key: prefix and key
+// upper-cased, joined by underscores, with dots turned into underscores.
+func EnvName(prefix, key string) string {
+	name := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if prefix == "" {
+		return name
+	}
+	return strings.ToUpper(prefix) + "_" + name
+}
+
+// ReadFile reads a config file and flattens it into dotted keys, using
+// the decoder registered for its extension.
+func ReadFile(path string) (map[string]string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	dec, ok := decoders[ext]
+	if !ok {
+		return nil, fmt.Errorf("config: no decoder for %q files", ext)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := dec.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+	return values, nil
+}
+
+type field struct {
+	key   string
+	def   string
+	usage string
+	v     reflect.Value
+}
+
+func collect(v reflect.Value, prefix string) []*field {
+	var fields []*field
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Tag.Get("config")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		key := prefix + name
+		fv := v.Field(i)
+		if sf.Type.Kind() == reflect.Struct && sf.Type != reflect.TypeFor[time.Time]() {
+			fields = append(fields, collect(fv, key+".")...)
+			continue
+		}
+		fields = append(fields, &field{key: key, def: sf.Tag.Get("default"), usage: sf.Tag.Get("usage"), v: fv})
+	}
+	return fields
+}
+
+func (f *field) current() string {
+	if f.v.Type() == reflect.TypeFor[time.Duration]() {
+		return time.Duration(f.v.Int()).String()
+	}
+	if f.v.Kind() == reflect.Slice {
+		parts := make([]string, f.v.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(f.v.Index(i).Interface())
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(f.v.Interface())
+}
+
+func (f *field) set(s string) error {
+	return setValue(f.v, s)
+}
+
+func setValue(v reflect.Value, s string) error {
+	if v.Type() == reflect.TypeFor[time.Duration]() {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		var parts []string
+		if s != "" {
+			parts = strings.Split(s, ",")
+		}
+		sl := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setValue(sl.Index(i), strings.TrimSpace(p)); err != nil {
+				return err
+			}
+		}
+		v.Set(sl)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
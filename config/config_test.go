@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Server struct {
+		Addr    string        `config:"addr" default:":8080"`
+		Timeout time.Duration `default:"5s"`
+	}
+	Workers int     `default:"4"`
+	Ratio   float64 `default:"0.5"`
+	Debug   bool
+	Tags    []string
+	Skip    string `config:"-"`
+}
+
+// writeFile writes a config file into a temp dir and returns its path.
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPrecedence(t *testing.T) {
+	const file = `{"server": {"addr": ":9000", "timeout": "10s"}, "workers": 8, "tags": ["a", "b"]}`
+	tests := []struct {
+		name string
+		file bool
+		env  map[string]string
+		args []string
+		want func(c *testConfig)
+	}{
+		{
+			name: "defaults",
+			want: func(c *testConfig) {},
+		},
+		{
+			name: "file over default",
+			file: true,
+			want: func(c *testConfig) {
+				c.Server.Addr, c.Server.Timeout, c.Workers, c.Tags = ":9000", 10*time.Second, 8, []string{"a", "b"}
+			},
+		},
+		{
+			name: "env over file",
+			file: true,
+			env:  map[string]string{"APP_SERVER_ADDR": ":7000", "APP_DEBUG": "true", "APP_TAGS": "x, y"},
+			want: func(c *testConfig) {
+				c.Server.Addr, c.Server.Timeout, c.Workers, c.Tags = ":7000", 10*time.Second, 8, []string{"x", "y"}
+				c.Debug = true
+			},
+		},
+		{
+			name: "flag over env",
+			file: true,
+			env:  map[string]string{"APP_SERVER_ADDR": ":7000", "APP_WORKERS": "2"},
+			args: []string{"-server.addr", ":6000", "-ratio=0.25"},
+			want: func(c *testConfig) {
+				c.Server.Addr, c.Server.Timeout, c.Workers, c.Tags = ":6000", 10*time.Second, 2, []string{"a", "b"}
+				c.Ratio = 0.25
+			},
+		},
+		{
+			name: "bare bool flag",
+			env:  map[string]string{"APP_DEBUG": "false"},
+			args: []string{"-debug", "-workers", "3"},
+			want: func(c *testConfig) { c.Debug, c.Workers = true, 3 },
+		},
+		{
+			name: "explicit false bool flag",
+			env:  map[string]string{"APP_DEBUG": "true"},
+			args: []string{"-debug=false"},
+			want: func(c *testConfig) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{EnvPrefix: "app", Args: tt.args}
+			if tt.file {
+				opts.File = writeFile(t, "app.json", file)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var got testConfig
+			if err := Load(&got, opts); err != nil {
+				t.Fatal(err)
+			}
+			var want testConfig
+			want.Server.Addr, want.Server.Timeout, want.Workers, want.Ratio = ":8080", 5*time.Second, 4, 0.5
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got  %+v\nwant %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLargeJSONNumbers(t *testing.T) {
+	var c struct {
+		Limit int64
+		Big   uint64
+		Ratio float64
+	}
+	path := writeFile(t, "n.json", `{"limit": 10000000, "big": 18446744073709551615, "ratio": 1e-3}`)
+	if err := Load(&c, Options{File: path}); err != nil {
+		t.Fatal(err)
+	}
+	if c.Limit != 10_000_000 || c.Big != 1<<64-1 || c.Ratio != 0.001 {
+		t.Fatalf("got %+v", c)
+	}
+}
+
+func TestFileFormats(t *testing.T) {
+	files := map[string]string{
+		"app.json": `{"server": {"addr": ":9000"}, "workers": 8, "debug": true, "tags": ["a", "b"]}`,
+		"app.yaml": "server:\n  addr: \":9000\"\nworkers: 8\ndebug: true\ntags:\n  - a\n  - b\n",
+		"app.toml": "workers = 8\ndebug = true\ntags = [\"a\", \"b\"]\n\n[server]\naddr = \":9000\"\n",
+	}
+	for name, data := range files {
+		var c testConfig
+		if err := Load(&c, Options{File: writeFile(t, name, data)}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if c.Server.Addr != ":9000" || c.Workers != 8 || !c.Debug || !reflect.DeepEqual(c.Tags, []string{"a", "b"}) {
+			t.Errorf("%s: got %+v", name, c)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var c testConfig
+	if err := Load(c, Options{}); err == nil {
+		t.Error("Load of a non-pointer succeeded")
+	}
+	if err := Load(&c, Options{File: writeFile(t, "a.json", `{"nope": 1}`)}); err == nil {
+		t.Error("unknown file key accepted")
+	}
+	if err := Load(&c, Options{File: writeFile(t, "a.ini", "x=1")}); err == nil {
+		t.Error("unknown file extension accepted")
+	}
+	t.Setenv("BAD_WORKERS", "many")
+	if err := Load(&c, Options{EnvPrefix: "bad"}); err == nil {
+		t.Error("non-numeric env value accepted")
+	}
+	if err := Load(&c, Options{Args: []string{"-workers", "many"}}); err == nil {
+		t.Error("non-numeric flag value accepted")
+	}
+	if err := Load(&c, Options{Args: []string{"-skip", "x"}}); err == nil {
+		t.Error(`flag for a config:"-" field accepted`)
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// A Decoder turns the contents of a config file into flat dotted keys.
+// Lists are joined with commas, the same form flags and env use.
+type Decoder interface {
+	Decode(data []byte) (map[string]string, error)
+}
+
+// DecoderFunc adapts a function to Decoder.
+type DecoderFunc func(data []byte) (map[string]string, error)
+
+// Decode calls f(data).
+func (f DecoderFunc) Decode(data []byte) (map[string]string, error) { return f(data) }
+
+var decoders = map[string]Decoder{
+	".json": DecoderFunc(decodeJSON),
+	".yaml": DecoderFunc(decodeYAML),
+	".yml":  DecoderFunc(decodeYAML),
+	".toml": DecoderFunc(decodeTOML),
+}
+
+// RegisterDecoder makes files with extension ext (e.g. ".hcl") readable.
+// It replaces any decoder already registered for ext and is meant to be
+// called from init.
+func RegisterDecoder(ext string, dec Decoder) {
+	decoders[strings.ToLower(ext)] = dec
+}
+
+func decodeJSON(data []byte) (map[string]string, error) {
+	// UseNumber keeps numbers as written: a float64 would print 10000000
+	// as "1e+07", which the integer fields cannot parse.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree map[string]any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string)
+	flatten(out, "", tree)
+	return out, nil
+}
+
+func flatten(out map[string]string, prefix string, v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, sub := range v {
+			flatten(out, prefix+k+".", sub)
+		}
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmt.Sprint(e)
+		}
+		out[strings.TrimSuffix(prefix, ".")] = strings.Join(parts, ",")
+	default:
+		out[strings.TrimSuffix(prefix, ".")] = fmt.Sprint(v)
+	}
+}
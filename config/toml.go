@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeTOML understands the part of TOML config files actually use:
+// [table] and [a.b] headers, key = value pairs with dotted or quoted
+// keys, basic and literal strings, numbers, booleans, single-line arrays
+// of those, and # comments. Multi-line strings, inline tables, arrays of
+// tables and dates are rejected.
+func decodeTOML(data []byte) (map[string]string, error) {
+	out := make(map[string]string)
+	table := ""
+	for i, raw := range strings.Split(string(data), "\n") {
+		n := i + 1
+		line := strings.TrimSpace(stripComment(raw))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") || !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("toml line %d: unsupported table header %q", n, line)
+			}
+			name, err := tomlKey(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("toml line %d: %w", n, err)
+			}
+			table = name + "."
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("toml line %d: expected key = value", n)
+		}
+		key, err := tomlKey(strings.TrimSpace(k))
+		if err != nil {
+			return nil, fmt.Errorf("toml line %d: %w", n, err)
+		}
+		value, err := tomlValue(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("toml line %d: %w", n, err)
+		}
+		full := table + key
+		if _, dup := out[full]; dup {
+			return nil, fmt.Errorf("toml line %d: duplicate key %q", n, full)
+		}
+		out[full] = value
+	}
+	return out, nil
+}
+
+// stripComment cuts a # comment that is not inside a string.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func tomlKey(s string) (string, error) {
+	var parts []string
+	for p := range strings.SplitSeq(s, ".") {
+		p = strings.TrimSpace(p)
+		if len(p) >= 2 && (p[0] == '"' || p[0] == '\'') && p[len(p)-1] == p[0] {
+			p = p[1 : len(p)-1]
+		}
+		if p == "" {
+			return "", fmt.Errorf("bad key %q", s)
+		}
+		parts = append(parts, p)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+func tomlValue(s string) (string, error) {
+	switch {
+	case s == "":
+		return "", fmt.Errorf("missing value")
+	case s[0] == '"':
+		if strings.HasPrefix(s, `"""`) {
+			return "", fmt.Errorf("multi-line strings are not supported")
+		}
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("bad string %s", s)
+		}
+		return v, nil
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return "", fmt.Errorf("bad string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	case s[0] == '[':
+		if s[len(s)-1] != ']' {
+			return "", fmt.Errorf("arrays must be on one line")
+		}
+		var parts []string
+		for _, e := range splitArray(s[1 : len(s)-1]) {
+			v, err := tomlValue(e)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, v)
+		}
+		return strings.Join(parts, ","), nil
+	case s[0] == '{':
+		return "", fmt.Errorf("inline tables are not supported")
+	case s == "true" || s == "false":
+		return s, nil
+	}
+	num := strings.ReplaceAll(s, "_", "")
+	if _, err := strconv.ParseFloat(num, 64); err == nil {
+		return num, nil
+	}
+	if _, err := strconv.ParseInt(num, 0, 64); err == nil {
+		return num, nil
+	}
+	return "", fmt.Errorf("unsupported value %s", s)
+}
+
+// splitArray splits the inside of an array on commas outside strings,
+// dropping a trailing comma.
+func splitArray(s string) []string {
+	var out []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			out = append(out, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		out = append(out, last)
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeYAML understands the block-style subset of YAML config files use:
+// nested mappings by indentation, scalars (plain, 'single' or "double"
+// quoted), "- item" lists and [a, b] flow lists of scalars, and #
+// comments. Anchors, multi-document files, block scalars (| and >) and
+// flow mappings are rejected.
+func decodeYAML(data []byte) (map[string]string, error) {
+	type level struct {
+		indent int
+		prefix string
+	}
+	out := make(map[string]string)
+	stack := []level{{indent: -1}}
+	var listKey string // key whose "- item" lines we are collecting
+	listIndent := -1
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		n := i + 1
+		text := strings.TrimRight(stripComment(raw), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml line %d: tabs are not allowed for indentation", n)
+		}
+		indent := len(text) - len(trimmed)
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if listKey == "" || indent < listIndent {
+				return nil, fmt.Errorf("yaml line %d: list item without a key", n)
+			}
+			v, err := yamlScalar(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			if err != nil {
+				return nil, fmt.Errorf("yaml line %d: %w", n, err)
+			}
+			if prev := out[listKey]; prev != "" {
+				v = prev + "," + v
+			}
+			out[listKey] = v
+			continue
+		}
+		listKey = ""
+
+		k, v, ok := strings.Cut(trimmed, ":")
+		if !ok || (v != "" && v[0] != ' ') {
+			return nil, fmt.Errorf("yaml line %d: expected key: value", n)
+		}
+		for indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		key, err := yamlScalar(strings.TrimSpace(k))
+		if err != nil || key == "" {
+			return nil, fmt.Errorf("yaml line %d: bad key %q", n, k)
+		}
+		full := stack[len(stack)-1].prefix + key
+		v = strings.TrimSpace(v)
+
+		if v == "" {
+			// either a nested mapping or a list follows
+			stack = append(stack, level{indent: indent, prefix: full + "."})
+			listKey, listIndent = full, indent
+			continue
+		}
+		value, err := yamlValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("yaml line %d: %w", n, err)
+		}
+		if _, dup := out[full]; dup {
+			return nil, fmt.Errorf("yaml line %d: duplicate key %q", n, full)
+		}
+		out[full] = value
+	}
+	return out, nil
+}
+
+func yamlValue(s string) (string, error) {
+	switch s[0] {
+	case '[':
+		if s[len(s)-1] != ']' {
+			return "", fmt.Errorf("flow lists must be on one line")
+		}
+		var parts []string
+		for _, e := range splitArray(s[1 : len(s)-1]) {
+			v, err := yamlScalar(e)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, v)
+		}
+		return strings.Join(parts, ","), nil
+	case '{':
+		return "", fmt.Errorf("flow mappings are not supported")
+	case '|', '>':
+		return "", fmt.Errorf("block scalars are not supported")
+	case '&', '*':
+		return "", fmt.Errorf("anchors and aliases are not supported")
+	}
+	return yamlScalar(s)
+}
+
+func yamlScalar(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	switch s[0] {
+	case '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("bad string %s", s)
+		}
+		return v, nil
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return "", fmt.Errorf("bad string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	switch s {
+	case "~", "null":
+		return "", nil
+	case "yes", "on":
+		return "true", nil
+	case "no", "off":
+		return "false", nil
+	}
+	return s, nil
+}
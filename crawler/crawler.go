@@ -0,0 +1,228 @@
+// Package crawler fetches a web site concurrently, following links up to a
+// maximum depth while waiting between requests to the same host.
+package crawler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/armaanepiic/Golang/set"
+)
+
+// Page is the result of fetching one URL.
+type Page struct {
+	URL    string
+	Depth  int
+	Status int
+	Links  []string // absolute, fragment-free links found on the page
+	Err    error
+}
+
+// Config controls a crawl. Zero values get sensible defaults.
+type Config struct {
+	MaxDepth int           // 0 fetches only the start URL
+	Workers  int           // concurrent fetchers, default 4
+	Delay    time.Duration // minimum gap between requests to one host
+	SameHost bool          // only follow links on the start URL's host
+	Client   *http.Client  // default http.DefaultClient
+	MaxBody  int64         // bytes read per page, default 1 MiB
+}
+
+type job struct {
+	url   string
+	depth int
+}
+
+// Crawler runs crawls with a fixed configuration.
+type Crawler struct {
+	cfg Config
+
+	mu      sync.Mutex
+	visited *set.Set[string]
+	nextHit map[string]time.Time // host -> earliest time of the next request
+}
+
+// New returns a Crawler for cfg.
+func New(cfg Config) *Crawler {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 4
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	if cfg.MaxBody <= 0 {
+		cfg.MaxBody = 1 << 20
+	}
+	return &Crawler{cfg: cfg}
+}
+
+// Crawl fetches start and everything reachable from it within MaxDepth.
+// Pages are returned in the order they finished. Cancelling ctx stops the
+// crawl early and returns what was fetched so far.
+func (c *Crawler) Crawl(ctx context.Context, start string) ([]Page, error) {
+	root, err := url.Parse(start)
+	if err != nil {
+		return nil, err
+	}
+	root.Fragment = ""
+
+	c.mu.Lock()
+	c.visited = set.New(root.String())
+	c.nextHit = make(map[string]time.Time)
+	c.mu.Unlock()
+
+	jobs := make(chan job)
+	results := make(chan Page)
+
+	var wg sync.WaitGroup
+	for range c.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results <- c.fetch(ctx, j)
+			}
+		}()
+	}
+
+	// The coordinator owns the queue; pending counts jobs handed to workers
+	// that have not reported back yet.
+	var pages []Page
+	queue := []job{{url: root.String()}}
+	pending := 0
+	done := ctx.Done()
+	for len(queue) > 0 || pending > 0 {
+		if ctx.Err() != nil {
+			queue = nil // drain the workers, start nothing new
+		}
+		var send chan job
+		var next job
+		if len(queue) > 0 {
+			send, next = jobs, queue[0]
+		}
+		select {
+		case send <- next:
+			queue = queue[1:]
+			pending++
+		case p := <-results:
+			pending--
+			pages = append(pages, p)
+			// links found by a fetch that finished after cancellation
+			// would only start new work; drop them
+			if p.Depth < c.cfg.MaxDepth && ctx.Err() == nil {
+				for _, l := range p.Links {
+					if c.follow(root, l) {
+						queue = append(queue, job{url: l, depth: p.Depth + 1})
+					}
+				}
+			}
+		case <-done:
+			done = nil // the queue is dropped at the top of the loop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return pages, ctx.Err()
+}
+
+// follow reports whether link should be queued, marking it visited.
+func (c *Crawler) follow(root *url.URL, link string) bool {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	if c.cfg.SameHost && u.Host != root.Host {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.visited.Add(link)
+}
+
+func (c *Crawler) fetch(ctx context.Context, j job) Page {
+	p := Page{URL: j.url, Depth: j.depth}
+	u, err := url.Parse(j.url)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	if err := c.wait(ctx, u.Host); err != nil {
+		p.Err = err
+		return p
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	res, err := c.cfg.Client.Do(req)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	defer res.Body.Close()
+
+	p.Status = res.StatusCode
+	body, err := io.ReadAll(io.LimitReader(res.Body, c.cfg.MaxBody))
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	p.Links = ExtractLinks(res.Request.URL, body)
+	return p
+}
+
+// wait blocks until host may be requested again, then reserves the next
+// slot, so concurrent workers never hit one host faster than Delay.
+func (c *Crawler) wait(ctx context.Context, host string) error {
+	if c.cfg.Delay <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	now := time.Now()
+	at := c.nextHit[host]
+	if at.Before(now) {
+		at = now
+	}
+	c.nextHit[host] = at.Add(c.cfg.Delay)
+	c.mu.Unlock()
+
+	if d := at.Sub(now); d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+	return nil
+}
+
+var hrefRe = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+// ExtractLinks returns the unique links of all <a href> tags in body,
+// resolved against base and stripped of fragments.
+func ExtractLinks(base *url.URL, body []byte) []string {
+	seen := set.New[string]()
+	var links []string
+	for _, m := range hrefRe.FindAllSubmatch(body, -1) {
+		raw := string(m[1]) + string(m[2]) + string(m[3])
+		ref, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		abs := base.ResolveReference(ref)
+		abs.Fragment = ""
+		if s := abs.String(); seen.Add(s) {
+			links = append(links, s)
+		}
+	}
+	return links
+}
@@ -0,0 +1,179 @@
+package crawler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/crawler"
+	"github.com/armaanepiic/Golang/leaktest"
+)
+
+// site serves a binary tree of pages: every path shorter than 12 bytes
+// links to path/a and path/b, plus an external link and a fragment.
+func site() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if len(r.URL.Path) < 12 {
+			p := strings.TrimSuffix(r.URL.Path, "/")
+			fmt.Fprintf(w, `<a href="%[1]s/a">a</a> <a href='%[1]s/b#top'>b</a> <a href=https://example.com/>x</a>`, p)
+		}
+	}))
+}
+
+// newClient returns a client with its own transport, so closing its idle
+// connections leaves no keep-alive goroutines behind.
+func newClient() *http.Client {
+	return &http.Client{Transport: &http.Transport{}}
+}
+
+func TestCrawl(t *testing.T) {
+	defer leaktest.Check(t)()
+	srv := site()
+	defer srv.Close()
+	client := newClient()
+	defer client.CloseIdleConnections()
+
+	c := crawler.New(crawler.Config{MaxDepth: 3, Workers: 4, SameHost: true, Client: client})
+	pages, err := c.Crawl(context.Background(), srv.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// depths 0..3 of a binary tree
+	if len(pages) != 1+2+4+8 {
+		t.Fatalf("crawled %d pages, want 15", len(pages))
+	}
+	seen := map[string]bool{}
+	for _, p := range pages {
+		if p.Err != nil || p.Status != http.StatusOK {
+			t.Errorf("%s: %d %v", p.URL, p.Status, p.Err)
+		}
+		if seen[p.URL] {
+			t.Errorf("%s fetched twice", p.URL)
+		}
+		seen[p.URL] = true
+		if p.Depth > 3 || strings.Contains(p.URL, "example.com") {
+			t.Errorf("unexpected page %s at depth %d", p.URL, p.Depth)
+		}
+	}
+	if !seen[srv.URL+"/a/b/a"] {
+		t.Error("missing depth-3 page /a/b/a")
+	}
+}
+
+func TestCrawlCancel(t *testing.T) {
+	defer leaktest.Check(t)()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done() // a server that never answers
+	}))
+	defer srv.Close()
+	client := newClient()
+	defer client.CloseIdleConnections()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	pages, err := crawler.New(crawler.Config{Workers: 4, Client: client}).Crawl(ctx, srv.URL+"/")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Crawl = %v, want DeadlineExceeded", err)
+	}
+	if len(pages) != 1 || pages[0].Err == nil {
+		t.Fatalf("pages = %+v, want the start page with its error", pages)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	srv := site()
+	defer srv.Close()
+
+	start := time.Now()
+	c := crawler.New(crawler.Config{MaxDepth: 1, Workers: 3, Delay: 30 * time.Millisecond, SameHost: true, Client: srv.Client()})
+	pages, err := c.Crawl(context.Background(), srv.URL+"/")
+	if err != nil || len(pages) != 3 {
+		t.Fatalf("Crawl = %d pages, %v", len(pages), err)
+	}
+	// three requests to one host need two gaps, whatever the worker count
+	if d := time.Since(start); d < 60*time.Millisecond {
+		t.Fatalf("crawl took %v, want at least 60ms", d)
+	}
+}
+
+func TestExtractLinks(t *testing.T) {
+	base, _ := url.Parse("https://go.dev/doc/")
+	body := `<A HREF="/blog">b</A> <a class=x href='tutorial/#intro'>t</a>
+		<a href=https://pkg.go.dev>p</a> <a href="/blog#dup">again</a> <a name="x">no href</a>`
+	got := crawler.ExtractLinks(base, []byte(body))
+	want := []string{"https://go.dev/blog", "https://go.dev/doc/tutorial/", "https://pkg.go.dev"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ExtractLinks = %q, want %q", got, want)
+	}
+}
+
+// roundTripper lets a test answer requests without a server.
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func htmlResponse(r *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}
+}
+
+func TestCrawlCancelStartsNothingNew(t *testing.T) {
+	defer leaktest.Check(t)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var requested []string
+	client := &http.Client{Transport: roundTripper(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/":
+			return htmlResponse(r, `<a href="/slow">s</a> <a href="/cancel">c</a>`), nil
+		case "/cancel":
+			cancel()
+			return htmlResponse(r, ""), nil
+		case "/slow":
+			// finish well after the coordinator has seen the cancellation,
+			// with links that must not be followed
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			var links strings.Builder
+			for i := range 10 {
+				fmt.Fprintf(&links, `<a href="/slow/%d">%d</a> `, i, i)
+			}
+			return htmlResponse(r, links.String()), nil
+		}
+		return htmlResponse(r, ""), nil
+	})}
+
+	c := crawler.New(crawler.Config{MaxDepth: 3, Workers: 2, Client: client})
+	pages, err := c.Crawl(ctx, "http://crawl.test/")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Crawl = %v, want Canceled", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	slices.Sort(requested)
+	if want := []string{"/", "/cancel", "/slow"}; !slices.Equal(requested, want) {
+		t.Fatalf("requested %q, want only %q", requested, want)
+	}
+	if len(pages) != 3 {
+		t.Fatalf("got %d pages, want 3", len(pages))
+	}
+}
@@ -0,0 +1,106 @@
+// Package cryptox encrypts data with a passphrase: the key is derived
+// with PBKDF2-SHA256 from a random salt, and the data is sealed with
+// AES-256-GCM under a random nonce, so the same input never produces the
+// same output and any tampering is detected.
+//
+// Output layout:
+//
+//	magic "CX1\x00" | iterations (uint32 BE) | salt (16) | nonce (12) | ciphertext+tag
+package cryptox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	magic    = "CX1\x00"
+	saltSize = 16
+	keySize  = 32 // AES-256
+
+	// DefaultIterations follows the OWASP 2023 advice for PBKDF2-SHA256.
+	DefaultIterations = 600_000
+
+	// maxIterations stops crafted input from making Decrypt spin.
+	maxIterations = 100 * DefaultIterations
+)
+
+// ErrDecrypt means the passphrase is wrong or the data was modified. The
+// two cannot be told apart, by design.
+var ErrDecrypt = errors.New("cryptox: wrong passphrase or corrupted data")
+
+// ErrFormat means the input was not produced by Encrypt.
+var ErrFormat = errors.New("cryptox: not encrypted data")
+
+// Iterations is the PBKDF2 work factor used by Encrypt. It is stored in
+// the output, so changing it does not break existing data.
+var Iterations = DefaultIterations
+
+// Encrypt seals plaintext with a key derived from passphrase.
+func Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	header := make([]byte, len(magic)+4+saltSize, len(magic)+4+saltSize+12+len(plaintext)+16)
+	copy(header, magic)
+	binary.BigEndian.PutUint32(header[len(magic):], uint32(Iterations))
+	salt := header[len(magic)+4:]
+	rand.Read(salt)
+
+	gcm, err := newGCM(passphrase, salt, Iterations)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+
+	out := append(header, nonce...)
+	// the header is authenticated too, so nobody can lower the iterations
+	return gcm.Seal(out, nonce, plaintext, header), nil
+}
+
+// Decrypt opens data produced by Encrypt.
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	hlen := len(magic) + 4 + saltSize
+	if len(data) < hlen || string(data[:len(magic)]) != magic {
+		return nil, ErrFormat
+	}
+	header := data[:hlen]
+	iter := int(binary.BigEndian.Uint32(header[len(magic):]))
+	if iter < 1 || iter > maxIterations {
+		return nil, ErrFormat
+	}
+	gcm, err := newGCM(passphrase, header[len(magic)+4:], iter)
+	if err != nil {
+		return nil, err
+	}
+	rest := data[hlen:]
+	if len(rest) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrFormat
+	}
+	nonce, ct := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ct, header)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plain, nil
+}
+
+// IsEncrypted reports whether data looks like Encrypt output.
+func IsEncrypted(data []byte) bool {
+	return len(data) >= len(magic) && string(data[:len(magic)]) == magic
+}
+
+func newGCM(passphrase string, salt []byte, iter int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iter, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
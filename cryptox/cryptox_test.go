@@ -0,0 +1,106 @@
+package cryptox
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// TestMain keeps the key derivation cheap; the iteration count is stored
+// in the output, so decryption still uses whatever Encrypt wrote.
+func TestMain(m *testing.M) {
+	Iterations = 1000
+	m.Run()
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, plain := range [][]byte{nil, []byte("x"), bytes.Repeat([]byte("secret "), 1000)} {
+		data, err := Encrypt(plain, "correct horse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !IsEncrypted(data) {
+			t.Fatal("IsEncrypted(Encrypt(...)) = false")
+		}
+		got, err := Decrypt(data, "correct horse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Fatalf("Decrypt = %q, want %q", got, plain)
+		}
+	}
+}
+
+func TestRandomized(t *testing.T) {
+	a, _ := Encrypt([]byte("same"), "pw")
+	b, _ := Encrypt([]byte("same"), "pw")
+	if bytes.Equal(a, b) {
+		t.Fatal("two encryptions of the same input are identical")
+	}
+}
+
+func TestWrongKey(t *testing.T) {
+	data, err := Encrypt([]byte("top secret"), "right")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pw := range []string{"wrong", "", "right "} {
+		if _, err := Decrypt(data, pw); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("Decrypt with %q: %v, want ErrDecrypt", pw, err)
+		}
+	}
+}
+
+func TestTampered(t *testing.T) {
+	data, err := Encrypt([]byte("top secret"), "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hlen := len(magic) + 4
+	// flip one bit in the salt, the nonce, the ciphertext and the tag
+	for _, i := range []int{hlen, hlen + saltSize, hlen + saltSize + 12, len(data) - 1} {
+		bad := bytes.Clone(data)
+		bad[i] ^= 1
+		if _, err := Decrypt(bad, "pw"); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("bit flipped at %d: %v, want ErrDecrypt", i, err)
+		}
+	}
+
+	// the iteration count is authenticated: lowering it is detected
+	bad := bytes.Clone(data)
+	binary.BigEndian.PutUint32(bad[len(magic):], uint32(Iterations-1))
+	if _, err := Decrypt(bad, "pw"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("changed iterations: %v, want ErrDecrypt", err)
+	}
+
+	if _, err := Decrypt(data[:len(data)-1], "pw"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("truncated tag: %v, want ErrDecrypt", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	data, _ := Encrypt([]byte("x"), "pw")
+	zeroIter := bytes.Clone(data)
+	binary.BigEndian.PutUint32(zeroIter[len(magic):], 0)
+	hugeIter := bytes.Clone(data)
+	binary.BigEndian.PutUint32(hugeIter[len(magic):], maxIterations+1)
+
+	tests := map[string][]byte{
+		"empty":           nil,
+		"plain text":      []byte(`{"users": []}`),
+		"header only":     data[:len(magic)+4+saltSize],
+		"no room for tag": data[:len(magic)+4+saltSize+12+15],
+		"zero iterations": zeroIter,
+		"huge iterations": hugeIter,
+	}
+	for name, in := range tests {
+		if _, err := Decrypt(in, "pw"); !errors.Is(err, ErrFormat) {
+			t.Errorf("%s: %v, want ErrFormat", name, err)
+		}
+	}
+	if IsEncrypted([]byte("CX")) || IsEncrypted([]byte(`{}`)) {
+		t.Error("IsEncrypted accepts non-encrypted data")
+	}
+}
@@ -0,0 +1,262 @@
+// Package csvx decodes CSV rows straight into structs, one row at a
+// time, so files of any size can be processed in constant memory.
+//
+// Columns are matched to fields by header name using the `csv` tag (or
+// the field name, case-insensitively). A tag of "-" skips the field and
+// ",required" makes a missing column an error:
+//
+//	type User struct {
+//		Name string `csv:"name,required"`
+//		Age  int    `csv:"age"`
+//	}
+package csvx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RowError is a problem with one row. Line is the 1-based line in the
+// file where the row starts.
+type RowError struct {
+	Line   int
+	Column string // empty if the whole row is bad
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d: column %q: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error { return e.Err }
+
+// ErrTooManyErrors stops decoding once Options.MaxErrors is reached.
+var ErrTooManyErrors = errors.New("csvx: too many bad rows")
+
+// Options tunes Decode. The zero value reads comma separated files and
+// collects every row error.
+type Options struct {
+	Comma     rune // default ','
+	MaxErrors int  // stop after this many bad rows; 0 = no limit
+
+	// Progress, if set, is called every ProgressEvery rows (default 1000)
+	// and once at the end with the rows and bytes read so far.
+	Progress      func(rows int, bytes int64)
+	ProgressEvery int
+}
+
+// Result summarises a Decode run.
+type Result struct {
+	Rows   int // data rows read, good and bad
+	OK     int // rows passed to fn without error
+	Errors []*RowError
+}
+
+// Decode reads a header line from r and then calls fn with every row
+// decoded into a T. Rows that fail to decode, or for which fn returns an
+// error, are recorded in Result.Errors and skipped. The returned error is
+// only set when reading itself fails (bad header, I/O error, or
+// MaxErrors reached).
+func Decode[T any](r io.Reader, opts Options, fn func(v T) error) (Result, error) {
+	var res Result
+	cr := &countingReader{r: r}
+	rd := csv.NewReader(cr)
+	if opts.Comma != 0 {
+		rd.Comma = opts.Comma
+	}
+	rd.FieldsPerRecord = -1 // we report the mismatch ourselves, per row
+	rd.ReuseRecord = true
+	if opts.ProgressEvery <= 0 {
+		opts.ProgressEvery = 1000
+	}
+
+	header, err := rd.Read()
+	if err != nil {
+		if err == io.EOF {
+			err = errors.New("csvx: empty input, expected a header line")
+		}
+		return res, err
+	}
+	header = slices.Clone(header) // ReuseRecord would overwrite it
+	cols, err := mapColumns[T](header)
+	if err != nil {
+		return res, err
+	}
+
+	fail := func(e *RowError) error {
+		res.Errors = append(res.Errors, e)
+		if opts.MaxErrors > 0 && len(res.Errors) >= opts.MaxErrors {
+			return ErrTooManyErrors
+		}
+		return nil
+	}
+
+	for {
+		rec, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var pe *csv.ParseError
+			if !errors.As(err, &pe) {
+				return res, err
+			}
+			res.Rows++
+			if err := fail(&RowError{Line: pe.StartLine, Err: pe.Err}); err != nil {
+				return res, err
+			}
+			continue
+		}
+		res.Rows++
+		line, _ := rd.FieldPos(0)
+
+		var v T
+		if rerr := decodeRow(reflect.ValueOf(&v).Elem(), cols, header, rec, line); rerr != nil {
+			if err := fail(rerr); err != nil {
+				return res, err
+			}
+		} else if ferr := fn(v); ferr != nil {
+			if err := fail(&RowError{Line: line, Err: ferr}); err != nil {
+				return res, err
+			}
+		} else {
+			res.OK++
+		}
+
+		if opts.Progress != nil && res.Rows%opts.ProgressEvery == 0 {
+			opts.Progress(res.Rows, cr.n)
+		}
+	}
+	if opts.Progress != nil {
+		opts.Progress(res.Rows, cr.n)
+	}
+	return res, nil
+}
+
+// column links a CSV column to a struct field.
+type column struct {
+	index int   // position in the record
+	field []int // reflect field index
+}
+
+func mapColumns[T any](header []string) ([]column, error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csvx: %s is not a struct", t)
+	}
+	pos := make(map[string]int, len(header))
+	for i, h := range header {
+		pos[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	var cols []column
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		name, opt, _ := strings.Cut(sf.Tag.Get("csv"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		i, ok := pos[strings.ToLower(name)]
+		if !ok {
+			if opt == "required" {
+				return nil, fmt.Errorf("csvx: header has no %q column", name)
+			}
+			continue
+		}
+		cols = append(cols, column{index: i, field: sf.Index})
+	}
+	return cols, nil
+}
+
+func decodeRow(v reflect.Value, cols []column, header, rec []string, line int) *RowError {
+	if len(rec) != len(header) {
+		return &RowError{Line: line, Err: fmt.Errorf("has %d fields, header has %d", len(rec), len(header))}
+	}
+	for _, c := range cols {
+		if err := setField(v.FieldByIndex(c.field), strings.TrimSpace(rec[c.index])); err != nil {
+			return &RowError{Line: line, Column: header[c.index], Err: err}
+		}
+	}
+	return nil
+}
+
+func setField(f reflect.Value, s string) error {
+	if f.Type() == reflect.TypeFor[time.Time]() {
+		if s == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if s == "" && f.Kind() != reflect.String {
+		return nil // empty cell keeps the zero value
+	}
+	if f.Type() == reflect.TypeFor[time.Duration]() {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+		return nil
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.Unwrap(err)
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, f.Type().Bits())
+		if err != nil {
+			return errors.Unwrap(err) // drop strconv's "parsing ..." prefix
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, f.Type().Bits())
+		if err != nil {
+			return errors.Unwrap(err)
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, f.Type().Bits())
+		if err != nil {
+			return errors.Unwrap(err)
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
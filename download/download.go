@@ -0,0 +1,226 @@
+// Package download fetches files concurrently with progress reporting,
+// resumption of partial downloads and checksum verification.
+//
+// A file is written to Dest+".part" first. If that file already exists the
+// download resumes from its size with a Range request; once complete and
+// verified it is renamed to Dest.
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armaanepiic/Golang/retry"
+)
+
+// Job describes one file to download.
+type Job struct {
+	URL    string
+	Dest   string
+	SHA256 string // optional hex digest the finished file must match
+}
+
+// Result is the outcome of a Job.
+type Result struct {
+	Job
+	Bytes    int64 // size of the finished file
+	Resumed  bool  // part of the file came from an earlier attempt
+	Duration time.Duration
+	Err      error
+}
+
+// ErrChecksum is returned when a finished file does not match Job.SHA256.
+var ErrChecksum = errors.New("download: checksum mismatch")
+
+// ProgressFunc receives the bytes written so far and the expected total
+// (-1 if the server did not say).
+type ProgressFunc func(job Job, done, total int64)
+
+// ProgressWriter counts the bytes written through it and reports them.
+type ProgressWriter struct {
+	W        io.Writer
+	Total    int64
+	OnUpdate func(done, total int64)
+
+	done atomic.Int64
+}
+
+func (p *ProgressWriter) Write(b []byte) (int, error) {
+	n, err := p.W.Write(b)
+	d := p.done.Add(int64(n))
+	if p.OnUpdate != nil {
+		p.OnUpdate(d, p.Total)
+	}
+	return n, err
+}
+
+// Done returns the number of bytes written so far.
+func (p *ProgressWriter) Done() int64 { return p.done.Load() }
+
+// Start sets the byte count to begin from, e.g. when resuming.
+func (p *ProgressWriter) Start(n int64) { p.done.Store(n) }
+
+// Manager runs downloads.
+type Manager struct {
+	Client      *http.Client   // default http.DefaultClient
+	Concurrency int            // parallel downloads, default 4
+	Progress    ProgressFunc   // optional
+	Retry       []retry.Option // retry policy per file, default 3 attempts
+}
+
+// Fetch downloads every job and returns the results in job order.
+func (m *Manager) Fetch(ctx context.Context, jobs []Job) []Result {
+	n := m.Concurrency
+	if n <= 0 {
+		n = 4
+	}
+	results := make([]Result, len(jobs))
+	sem := make(chan struct{}, n)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = m.fetch(ctx, job)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (m *Manager) fetch(ctx context.Context, job Job) Result {
+	res := Result{Job: job}
+	start := time.Now()
+	err := retry.Do(ctx, func(ctx context.Context) error {
+		return m.attempt(ctx, job, &res)
+	}, m.Retry...)
+	res.Duration = time.Since(start)
+	res.Err = err
+	return res
+}
+
+func (m *Manager) attempt(ctx context.Context, job Job, res *Result) error {
+	client := m.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	part := job.Dest + ".part"
+
+	var offset int64
+	if fi, err := os.Stat(part); err == nil {
+		offset = fi.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, job.URL, nil)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	total := resp.ContentLength
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+		res.Resumed = true
+		if total >= 0 {
+			total += offset
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// The part file is already complete (or bigger than the file).
+		if size := rangeSize(resp.Header.Get("Content-Range")); size == offset {
+			return m.finish(job, part, offset, res)
+		}
+		os.Remove(part)
+		return errors.New("download: stale partial file, restarting")
+	case resp.StatusCode == http.StatusOK:
+		flags |= os.O_TRUNC // server ignored Range: start over
+		offset = 0
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("download: %s: %s", job.URL, resp.Status)
+	default:
+		return retry.Permanent(fmt.Errorf("download: %s: %s", job.URL, resp.Status))
+	}
+
+	f, err := os.OpenFile(part, flags, 0o644)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+	pw := &ProgressWriter{W: f, Total: total}
+	pw.Start(offset)
+	if m.Progress != nil {
+		pw.OnUpdate = func(done, total int64) { m.Progress(job, done, total) }
+	}
+	_, err = io.Copy(pw, resp.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err // keep the part file for the next attempt
+	}
+	return m.finish(job, part, pw.Done(), res)
+}
+
+func (m *Manager) finish(job Job, part string, size int64, res *Result) error {
+	if job.SHA256 != "" {
+		sum, err := fileSHA256(part)
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		if !strings.EqualFold(sum, job.SHA256) {
+			os.Remove(part)
+			return retry.Permanent(fmt.Errorf("%w: %s: got %s", ErrChecksum, job.Dest, sum))
+		}
+	}
+	if err := os.Rename(part, job.Dest); err != nil {
+		return retry.Permanent(err)
+	}
+	res.Bytes = size
+	return nil
+}
+
+// rangeSize parses the complete length from "bytes */1234".
+func rangeSize(cr string) int64 {
+	_, size, ok := strings.Cut(cr, "/")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,247 @@
+package download_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/download"
+	"github.com/armaanepiic/Golang/retry"
+)
+
+var content = bytes.Repeat([]byte("0123456789abcdef"), 1000)
+
+// server serves content through h and records the Range header of every
+// request.
+type server struct {
+	*httptest.Server
+	mu     sync.Mutex
+	ranges []string
+}
+
+func newServer(t *testing.T, h http.HandlerFunc) *server {
+	s := &server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		s.mu.Unlock()
+		h(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *server) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+// serveContent honours Range requests.
+func serveContent(w http.ResponseWriter, r *http.Request) {
+	http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+}
+
+// manager returns a Manager that records its retry sleeps instead of
+// waiting.
+func manager(s *server, sleeps *[]time.Duration) *download.Manager {
+	return &download.Manager{
+		Client: s.Client(),
+		Retry: []retry.Option{retry.WithSleeper(func(ctx context.Context, d time.Duration) error {
+			*sleeps = append(*sleeps, d)
+			return nil
+		})},
+	}
+}
+
+func digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// fetch downloads the server's file into a temp dir, optionally starting
+// from a part file holding part.
+func fetch(t *testing.T, m *download.Manager, s *server, part []byte, sha string) (download.Result, string) {
+	t.Helper()
+	dest := filepath.Join(t.TempDir(), "file.bin")
+	if part != nil {
+		if err := os.WriteFile(dest+".part", part, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res := m.Fetch(context.Background(), []download.Job{{URL: s.URL, Dest: dest, SHA256: sha}})
+	return res[0], dest
+}
+
+func checkFile(t *testing.T, dest string) {
+	t.Helper()
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("%s holds %d bytes, want the %d served", dest, len(got), len(content))
+	}
+	if _, err := os.Stat(dest + ".part"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("part file left behind: %v", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	s := newServer(t, serveContent)
+	var sleeps []time.Duration
+	m := manager(s, &sleeps)
+	var last, total int64
+	m.Progress = func(_ download.Job, d, n int64) { last, total = d, n }
+
+	res, dest := fetch(t, m, s, nil, digest(content))
+	if res.Err != nil || res.Resumed || res.Bytes != int64(len(content)) {
+		t.Fatalf("Result = %+v", res)
+	}
+	checkFile(t, dest)
+	if last != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("last progress = %d/%d, want %d/%d", last, total, len(content), len(content))
+	}
+	if got := s.requests(); len(got) != 1 || got[0] != "" {
+		t.Errorf("Range headers = %q, want one request without Range", got)
+	}
+}
+
+func TestResume(t *testing.T) {
+	s := newServer(t, serveContent)
+	var sleeps []time.Duration
+	m := manager(s, &sleeps)
+	var first int64 = -1
+	m.Progress = func(_ download.Job, d, _ int64) {
+		if first < 0 {
+			first = d
+		}
+	}
+
+	res, dest := fetch(t, m, s, content[:3000], digest(content))
+	if res.Err != nil || !res.Resumed || res.Bytes != int64(len(content)) {
+		t.Fatalf("Result = %+v, want a resumed download", res)
+	}
+	checkFile(t, dest)
+	if got := s.requests(); len(got) != 1 || got[0] != "bytes=3000-" {
+		t.Errorf("Range headers = %q, want [bytes=3000-]", got)
+	}
+	if first <= 3000 {
+		t.Errorf("first progress = %d, want it to count the 3000 bytes already there", first)
+	}
+}
+
+func TestAlreadyComplete(t *testing.T) {
+	s := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			serveContent(w, r) // 416 with Content-Range: bytes */size
+			return
+		}
+		t.Error("complete part file fetched again")
+		serveContent(w, r)
+	})
+	var sleeps []time.Duration
+	res, dest := fetch(t, manager(s, &sleeps), s, content, "")
+	if res.Err != nil || res.Bytes != int64(len(content)) {
+		t.Fatalf("Result = %+v", res)
+	}
+	checkFile(t, dest)
+	if got := s.requests(); len(got) != 1 {
+		t.Errorf("made %d requests, want 1", len(got))
+	}
+}
+
+func TestStalePart(t *testing.T) {
+	s := newServer(t, serveContent)
+	var sleeps []time.Duration
+	// bigger than the file: the 416 names another size, so start again
+	res, dest := fetch(t, manager(s, &sleeps), s, append(content, "extra"...), digest(content))
+	if res.Err != nil || res.Resumed {
+		t.Fatalf("Result = %+v", res)
+	}
+	checkFile(t, dest)
+	if got := s.requests(); len(got) != 2 || got[1] != "" {
+		t.Errorf("Range headers = %q, want a fresh second request", got)
+	}
+}
+
+func TestRangeIgnored(t *testing.T) {
+	s := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content) // 200 with the whole file, whatever the Range
+	})
+	var sleeps []time.Duration
+	res, dest := fetch(t, manager(s, &sleeps), s, []byte("stale bytes from another version"), digest(content))
+	if res.Err != nil || res.Resumed || res.Bytes != int64(len(content)) {
+		t.Fatalf("Result = %+v, want a restarted download", res)
+	}
+	checkFile(t, dest)
+	if got := s.requests(); len(got) != 1 || got[0] == "" {
+		t.Errorf("Range headers = %q, want one ranged request", got)
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	s := newServer(t, serveContent)
+	var sleeps []time.Duration
+	res, dest := fetch(t, manager(s, &sleeps), s, nil, digest([]byte("something else")))
+	if !errors.Is(res.Err, download.ErrChecksum) {
+		t.Fatalf("Err = %v, want ErrChecksum", res.Err)
+	}
+	for _, p := range []string{dest, dest + ".part"} {
+		if _, err := os.Stat(p); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s still exists: %v", filepath.Base(p), err)
+		}
+	}
+	if n := len(s.requests()); n != 1 || len(sleeps) != 0 {
+		t.Errorf("made %d requests and %d sleeps, want a mismatch not to be retried", n, len(sleeps))
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	s := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		if n <= 2 {
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+			return
+		}
+		serveContent(w, r)
+	})
+	var sleeps []time.Duration
+	res, dest := fetch(t, manager(s, &sleeps), s, nil, digest(content))
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	checkFile(t, dest)
+	if len(sleeps) != 2 {
+		t.Errorf("slept %v, want two backoffs", sleeps)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	s := newServer(t, http.NotFound)
+	var sleeps []time.Duration
+	res, dest := fetch(t, manager(s, &sleeps), s, nil, "")
+	if res.Err == nil {
+		t.Fatal("404 succeeded")
+	}
+	if _, err := os.Stat(dest); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dest created: %v", err)
+	}
+	if n := len(s.requests()); n != 1 || len(sleeps) != 0 {
+		t.Errorf("made %d requests and %d sleeps, want a 404 not to be retried", n, len(sleeps))
+	}
+}
@@ -0,0 +1,49 @@
+package download
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteSummary prints a table with one row per result.
+func WriteSummary(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tSIZE\tTIME\tSPEED\tRESUMED\tSTATUS")
+	for _, r := range results {
+		status := "ok"
+		if r.Err != nil {
+			status = r.Err.Error()
+		}
+		resumed := ""
+		if r.Resumed {
+			resumed = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s/s\t%s\t%s\n",
+			r.Dest, HumanBytes(r.Bytes), r.Duration.Round(time.Millisecond),
+			HumanBytes(speed(r.Bytes, r.Duration)), resumed, status)
+	}
+	return tw.Flush()
+}
+
+func speed(n int64, d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(float64(n) / d.Seconds())
+}
+
+// HumanBytes formats n as B, KiB, MiB or GiB.
+func HumanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 2; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMG"[exp])
+}
@@ -1,3 +1,7 @@
-module ecommerce
+module github.com/armaanepiic/Golang/ecommerce
 
 go 1.26.1
+
+require github.com/armaanepiic/Golang v0.0.0
+
+replace github.com/armaanepiic/Golang => ../
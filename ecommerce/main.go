@@ -1,8 +1,25 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"time"
+
+	"github.com/armaanepiic/Golang/compress"
+	"github.com/armaanepiic/Golang/health"
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/logx"
+	"github.com/armaanepiic/Golang/metrics"
+	"github.com/armaanepiic/Golang/middleware"
+	"github.com/armaanepiic/Golang/profile"
+	"github.com/armaanepiic/Golang/pubsub"
+	"github.com/armaanepiic/Golang/ratelimit"
+	"github.com/armaanepiic/Golang/shutdown"
+	"github.com/armaanepiic/Golang/userapi"
 )
 
 func helloHandler(w http.ResponseWriter, r *http.Request) {
@@ -14,17 +31,74 @@ func aboutHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func main() {
+	drain := flag.Duration("drain", 10*time.Second, "how long to wait for in-flight requests on shutdown")
+	pprofAddr := flag.String("pprof", "", "serve /debug/pprof/ on this `addr`, e.g. localhost:6060 (off if empty)")
+	flag.Parse()
+
+	if err := logx.Setup(logx.ConfigFromEnv()); err != nil { // LOG_LEVEL, LOG_FORMAT
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	mux := http.NewServeMux() // router
 
 	mux.HandleFunc("/hello", helloHandler) // route
 
 	mux.HandleFunc("/about", aboutHandler) // route
 
-	fmt.Println("Server running on :3000")
+	events := pubsub.New[userapi.Event](pubsub.Drop)
+	users := userapi.New(userstore.New(), events, 256) // remembers the last 256 changes
+	users.Register(mux)                                // /users CRUD and /users/events (SSE)
+
+	checks := health.New()
+	checks.Add("disk", health.DiskSpace(".", 100<<20)) // at least 100 MiB free
+	checks.Register(mux)                               // /healthz, /readyz, /version
+
+	httpMetrics := metrics.NewHTTPMetrics(metrics.Default)
+	mux.Handle("GET /metrics", metrics.Default.Handler())
+
+	limiter := ratelimit.New(10, 20) // 10 req/s, bursts of 20
+
+	var tracker shutdown.Tracker // in-flight requests, reported while draining
 
-	err := http.ListenAndServe(":3000", mux) // nil
+	// first one is the outermost: logging sees every request, even rejected ones
+	handler := middleware.Chain(
+		tracker.Middleware,
+		middleware.RequestID, // X-Request-ID in the response and in every log line
+		middleware.Logging(nil),
+		middleware.Recover(nil),
+		middleware.CORS(middleware.CORSOptions{AllowedOrigins: []string{"*"}}),
+		ratelimit.Middleware(limiter),
+		compress.Middleware(0), // gzip bodies of 1 KiB and up
+		middleware.Timeout(5*time.Second),
+		httpMetrics.Middleware, // innermost, so it sees the route the mux matched
+	)(mux)
+
+	srv := &http.Server{Addr: ":3000", Handler: handler}
+	srv.RegisterOnShutdown(events.Close) // ends open event streams so the drain can finish
+
+	ctx, stop := shutdown.OnSignal(context.Background()) // Ctrl+C or SIGTERM
+	defer stop()
+
+	// profiles get their own listener: none of the middleware above (the
+	// 5s timeout would cut a 30s CPU profile short) and no public exposure
+	if *pprofAddr != "" {
+		debug := http.NewServeMux()
+		profile.Register(debug)
+		go func() {
+			slog.Info("pprof listening", "addr", *pprofAddr)
+			if err := http.ListenAndServe(*pprofAddr, debug); err != nil {
+				slog.Error("pprof server", "err", err)
+			}
+		}()
+	}
+
+	slog.Info("server running", "addr", srv.Addr)
+
+	err := shutdown.ListenAndServe(ctx, srv, *drain, &tracker)
 
 	if err != nil {
-		fmt.Println("Error starting the server", err)
+		slog.Error("server error", "err", err)
+		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
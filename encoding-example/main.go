@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/armaanepiic/Golang/encx"
+)
+
+func main() {
+	data := []byte("Go? Yes! <3 ~~~") // contains bytes that become + and / in base64
+
+	// 1. same bytes, different text
+	for _, e := range []encx.Encoding{encx.Base64, encx.Base64URL, encx.Hex} {
+		s := e.Encode(data)
+		back, err := e.Decode(s)
+		fmt.Printf("%-9s %-32s round trip ok: %v %v\n", e, s, bytes.Equal(back, data), err)
+	}
+
+	// 2. why URL-safe matters: + and / break URLs, = needs escaping
+	token := make([]byte, 12)
+	rand.Read(token)
+	fmt.Println("\nin a URL:")
+	fmt.Println("  std: https://example.com/reset?t=" + encx.Base64.Encode(token))
+	fmt.Println("  url: https://example.com/reset?t=" + encx.Base64URL.Encode(token))
+
+	// 3. a JWT is three base64url segments joined by dots
+	header := encx.Base64URL.Encode([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := encx.Base64URL.Encode([]byte(`{"sub":"1","name":"Arman"}`))
+	fmt.Println("\njwt header.payload:", header+"."+payload)
+
+	// 4. base62 for short IDs (the URL shortener uses this)
+	for _, n := range []uint64{0, 61, 62, 125000, 1<<64 - 1} {
+		code := encx.EncodeBase62(n)
+		back, _ := encx.DecodeBase62(code)
+		fmt.Printf("base62 %-20d -> %-11s -> %d\n", n, code, back)
+	}
+
+	// 5. streaming: encode while copying, no full copy in memory
+	fmt.Print("\nstreamed base64: ")
+	enc := encx.Base64.NewEncoder(os.Stdout)
+	io.Copy(enc, strings.NewReader(strings.Repeat("stream ", 5)))
+	enc.Close() // writes the last partial block and padding
+	fmt.Println()
+
+	dec := encx.Hex.NewDecoder(strings.NewReader("48656c6c6f2c20476f21"))
+	plain, _ := io.ReadAll(dec)
+	fmt.Println("streamed hex decode:", string(plain))
+
+	// 6. bad input is an error, not garbage
+	_, err := encx.Hex.Decode("zz")
+	fmt.Println("\nbad hex:", err)
+}
+
+/*
+	base64     3 bytes -> 4 chars, +33%, alphabet A-Z a-z 0-9 + /  (padding =)
+	base64url  same, but - and _ instead of + and /, padding dropped
+	hex        1 byte  -> 2 chars, +100%, easy to read
+	base62     numbers only, no symbols at all -> perfect for short codes
+*/
@@ -0,0 +1,121 @@
+// Package encx wraps the standard base64 and hex encoders behind one
+// small interface, with streaming variants, and adds base62 for short
+// URL-safe numeric IDs.
+package encx
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"strings"
+)
+
+// Encoding is a binary-to-text encoding.
+type Encoding int
+
+const (
+	Base64    Encoding = iota // standard alphabet, padded
+	Base64URL                 // URL and filename safe alphabet, no padding (JWT, cookies, URLs)
+	Hex                       // lower-case hexadecimal
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case Base64:
+		return "base64"
+	case Base64URL:
+		return "base64url"
+	case Hex:
+		return "hex"
+	}
+	return "unknown"
+}
+
+// Encode returns the text form of b.
+func (e Encoding) Encode(b []byte) string {
+	switch e {
+	case Base64:
+		return base64.StdEncoding.EncodeToString(b)
+	case Base64URL:
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Decode parses s. Base64URL also accepts padded input, since some
+// producers add it.
+func (e Encoding) Decode(s string) ([]byte, error) {
+	switch e {
+	case Base64:
+		return base64.StdEncoding.DecodeString(s)
+	case Base64URL:
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	}
+	return hex.DecodeString(s)
+}
+
+// NewEncoder returns a writer that encodes everything written to it into
+// w. Close must be called to flush the last partial block.
+func (e Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	switch e {
+	case Base64:
+		return base64.NewEncoder(base64.StdEncoding, w)
+	case Base64URL:
+		return base64.NewEncoder(base64.RawURLEncoding, w)
+	}
+	return nopCloser{hex.NewEncoder(w)}
+}
+
+// NewDecoder returns a reader that decodes the text read from r.
+func (e Encoding) NewDecoder(r io.Reader) io.Reader {
+	switch e {
+	case Base64:
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case Base64URL:
+		return base64.NewDecoder(base64.RawURLEncoding, r)
+	}
+	return hex.NewDecoder(r)
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// EncodeBase62 returns n in base62: short, and safe in URLs without
+// escaping.
+func EncodeBase62(n uint64) string {
+	if n == 0 {
+		return base62[:1]
+	}
+	var buf [11]byte // 62^11 > 2^64
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = base62[n%62]
+		n /= 62
+	}
+	return string(buf[i:])
+}
+
+// DecodeBase62 parses a string produced by EncodeBase62.
+func DecodeBase62(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("encx: empty base62 string")
+	}
+	var n uint64
+	for _, c := range []byte(s) {
+		i := strings.IndexByte(base62, c)
+		if i < 0 {
+			return 0, errors.New("encx: invalid base62 character")
+		}
+		if n > (math.MaxUint64-uint64(i))/62 {
+			return 0, errors.New("encx: base62 value overflows uint64")
+		}
+		n = n*62 + uint64(i)
+	}
+	return n, nil
+}
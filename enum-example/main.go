@@ -0,0 +1,73 @@
+// Enums: a named integer type, iota constants and generated String,
+// MarshalText and ParseRole methods (see role.go and go generate).
+//
+//	go run ./enum-example
+//	go run ./enum-example -role admin
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// permissions switches over the roles. A switch on an enum reads like a
+// table; the default case catches values outside the constant block.
+func permissions(r Role) []string {
+	switch r {
+	case RoleGuest:
+		return []string{"read"}
+	case RoleMember:
+		return []string{"read", "comment"}
+	case RoleModerator:
+		return []string{"read", "comment", "hide"}
+	case RoleAdmin:
+		return []string{"read", "comment", "hide", "ban"}
+	default:
+		return nil
+	}
+}
+
+type account struct {
+	Name string `json:"name"`
+	Role Role   `json:"role"` // written as text because Role has MarshalText
+}
+
+func main() {
+	role := RoleMember
+	flag.TextVar(&role, "role", RoleMember, "role to show (guest, member, moderator or admin)")
+	flag.Parse()
+
+	fmt.Println("all roles:")
+	for _, r := range RoleValues() {
+		fmt.Printf("  %d %-9s %v\n", int(r), r, permissions(r))
+	}
+	fmt.Println("a value outside the block:", Role(7), permissions(Role(7)))
+	fmt.Printf("-role %v may %v\n", role, permissions(role))
+
+	// encoding/json uses MarshalText and UnmarshalText
+	b, err := json.Marshal(account{"Ada", RoleAdmin})
+	if err != nil {
+		fail(err)
+	}
+	fmt.Println("json:", string(b))
+	var a account
+	if err := json.Unmarshal([]byte(`{"name":"Bob","role":"moderator"}`), &a); err != nil {
+		fail(err)
+	}
+	fmt.Printf("decoded: %s is a %v (%d)\n", a.Name, a.Role, a.Role)
+	if _, err := json.Marshal(account{"Eve", Role(7)}); err != nil {
+		fmt.Println("marshal Role(7):", err)
+	}
+
+	for _, s := range []string{"Admin", "GUEST", "owner"} {
+		r, err := ParseRole(s)
+		fmt.Printf("ParseRole(%q) = %v, %v\n", s, r, err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,14 @@
+package main
+
+//go:generate go run github.com/armaanepiic/Golang/cmd/enumgen -type Role -trimprefix Role
+
+// Role is what a user may do. Go has no enum keyword: an enum is a named
+// integer type and a block of constants, with iota numbering them.
+type Role int
+
+const (
+	RoleGuest Role = iota
+	RoleMember
+	RoleModerator
+	RoleAdmin
+)
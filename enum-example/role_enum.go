@@ -0,0 +1,59 @@
+// Code generated by "enumgen -type Role -trimprefix Role"; DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the name of r, or Role(n) if it has none.
+func (r Role) String() string {
+	switch r {
+	case RoleGuest:
+		return "Guest"
+	case RoleMember:
+		return "Member"
+	case RoleModerator:
+		return "Moderator"
+	case RoleAdmin:
+		return "Admin"
+	}
+	return "Role(" + strconv.FormatInt(int64(r), 10) + ")"
+}
+
+// MarshalText returns the name of r. Values without a name are an
+// error, so they never reach JSON or a config file.
+func (r Role) MarshalText() ([]byte, error) {
+	s := r.String()
+	if strings.HasPrefix(s, "Role(") {
+		return nil, fmt.Errorf("invalid Role %d", int64(r))
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText sets r from its name, as ParseRole does.
+func (r *Role) UnmarshalText(text []byte) error {
+	v, err := ParseRole(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// ParseRole returns the Role named s, ignoring case.
+func ParseRole(s string) (Role, error) {
+	for _, v := range RoleValues() {
+		if strings.EqualFold(s, v.String()) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid Role %q (want one of Guest, Member, Moderator, Admin)", s)
+}
+
+// RoleValues returns every named Role in increasing order.
+func RoleValues() []Role {
+	return []Role{RoleGuest, RoleMember, RoleModerator, RoleAdmin}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		r    Role
+		want string
+	}{
+		{RoleGuest, "Guest"},
+		{RoleMember, "Member"},
+		{RoleModerator, "Moderator"},
+		{RoleAdmin, "Admin"},
+		{Role(7), "Role(7)"},
+		{Role(-1), "Role(-1)"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Errorf("Role(%d).String() = %q, want %q", int(tt.r), got, tt.want)
+		}
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	for _, s := range []string{"Admin", "admin", "ADMIN"} {
+		if r, err := ParseRole(s); err != nil || r != RoleAdmin {
+			t.Errorf("ParseRole(%q) = %v, %v", s, r, err)
+		}
+	}
+	for _, s := range []string{"", "root", "Role(3)", "3"} {
+		if r, err := ParseRole(s); err == nil {
+			t.Errorf("ParseRole(%q) = %v, want an error", s, r)
+		}
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, r := range RoleValues() {
+		text, err := r.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: %v", r, err)
+		}
+		var back Role
+		if err := back.UnmarshalText(text); err != nil || back != r {
+			t.Errorf("%v -> %q -> %v, %v", r, text, back, err)
+		}
+	}
+
+	if _, err := Role(7).MarshalText(); err == nil {
+		t.Error("MarshalText of an unnamed value succeeded")
+	}
+	r := RoleMember
+	if err := r.UnmarshalText([]byte("owner")); err == nil || r != RoleMember {
+		t.Errorf("UnmarshalText(owner) = %v, left %v", err, r)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	b, err := json.Marshal(account{"Ada", RoleAdmin})
+	if err != nil || string(b) != `{"name":"Ada","role":"Admin"}` {
+		t.Fatalf("Marshal = %s, %v", b, err)
+	}
+	var a account
+	if err := json.Unmarshal([]byte(`{"name":"Bob","role":"moderator"}`), &a); err != nil || a.Role != RoleModerator {
+		t.Fatalf("Unmarshal = %+v, %v", a, err)
+	}
+	if _, err := json.Marshal(account{"Eve", Role(7)}); err == nil {
+		t.Error("Marshal of an unnamed role succeeded")
+	}
+}
@@ -0,0 +1,187 @@
+package exercise
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Case is the outcome of one test case.
+type Case struct {
+	Name   string // as reported by go test, with spaces turned into _
+	Passed bool
+	Output []string // what the test logged, without file:line prefixes
+}
+
+// Result is the outcome of checking an exercise.
+type Result struct {
+	Passed bool
+	Cases  []Case
+
+	// BuildOutput is set when the solution doesn't compile or the test
+	// binary didn't run at all.
+	BuildOutput string
+}
+
+// Failed returns the cases that failed.
+func (r *Result) Failed() []Case {
+	var failed []Case
+	for _, c := range r.Cases {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Check runs the hidden test of e against the stub in dir, the topic's
+// directory. A failing solution is not an error: err is only set if the
+// test could not be run at all.
+func Check(ctx context.Context, e *Exercise, dir string) (*Result, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(dir, e.File)); err != nil {
+		return nil, fmt.Errorf("exercise: %s: %w", e.ID(), err)
+	}
+
+	tmp, err := os.MkdirTemp("", "exercise-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	test := filepath.Join(tmp, "check_test.go")
+	if err := os.WriteFile(test, e.test, 0o644); err != nil {
+		return nil, err
+	}
+	// the test appears inside dir for the go command only
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {filepath.Join(dir, "zz_exercise_"+e.Func+"_test.go"): test},
+	})
+	if err != nil {
+		return nil, err
+	}
+	overlayFile := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayFile, overlay, 0o644); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "go", "test", "-overlay", overlayFile, "-count=1", "-json",
+		"-run", "^"+e.TestName()+"$", ".")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		return nil, fmt.Errorf("exercise: go test: %w", err)
+	}
+	r, perr := Parse(bytes.NewReader(out), e.TestName())
+	if perr != nil {
+		return nil, perr
+	}
+	if !r.Passed && len(r.Cases) == 0 && r.BuildOutput == "" {
+		r.BuildOutput = strings.TrimSpace(stderr.String())
+	}
+	return r, nil
+}
+
+// event is a line of go test -json output (see go doc test2json).
+type event struct {
+	Action string
+	Test   string
+	Output string
+}
+
+var fileLine = regexp.MustCompile(`^\s*\S+\.go:\d+: `)
+
+// Parse reads go test -json output for the test function named test and
+// collects its subtests as cases. A test without subtests is reported as
+// a single case. Lines that are not JSON, such as compiler errors from
+// older go versions, become BuildOutput.
+func Parse(r io.Reader, test string) (*Result, error) {
+	res := &Result{}
+	index := map[string]int{} // case name -> index in res.Cases
+	caseOf := func(name string) *Case {
+		i, ok := index[name]
+		if !ok {
+			i = len(res.Cases)
+			index[name] = i
+			res.Cases = append(res.Cases, Case{Name: name})
+		}
+		return &res.Cases[i]
+	}
+
+	var build strings.Builder
+	ran, failed := false, false
+	lastFailed := test // a panic is reported after the subtest it ended
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		var ev event
+		if len(line) == 0 || line[0] != '{' || json.Unmarshal(line, &ev) != nil {
+			build.Write(line)
+			build.WriteByte('\n')
+			continue
+		}
+		if ev.Action == "build-output" {
+			build.WriteString(ev.Output)
+			continue
+		}
+		sub, isSub := strings.CutPrefix(ev.Test, test+"/")
+		if ev.Test != test && !isSub {
+			continue
+		}
+		if !isSub {
+			switch ev.Action {
+			case "pass":
+				ran = true
+			case "fail":
+				ran, failed = true, true
+			case "output":
+				if s := strings.TrimSpace(ev.Output); strings.HasPrefix(s, "panic:") {
+					c := caseOf(lastFailed)
+					c.Output = append(c.Output, s)
+				}
+			}
+			continue
+		}
+		switch ev.Action {
+		case "pass":
+			caseOf(sub).Passed = true
+		case "fail":
+			caseOf(sub)
+			lastFailed = sub
+		case "output":
+			s := strings.TrimRight(ev.Output, "\n")
+			if t := strings.TrimSpace(s); t == "" || strings.HasPrefix(t, "=== ") || strings.HasPrefix(t, "--- ") {
+				continue
+			}
+			c := caseOf(sub)
+			c.Output = append(c.Output, strings.TrimSpace(fileLine.ReplaceAllString(s, "")))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("exercise: reading test output: %w", err)
+	}
+
+	if ran && len(res.Cases) == 0 {
+		res.Cases = []Case{{Name: test, Passed: !failed}}
+	}
+	res.Passed = ran && !failed
+	if !ran {
+		res.BuildOutput = strings.TrimSpace(build.String())
+	}
+	return res, nil
+}
@@ -0,0 +1,120 @@
+// Package exercise checks the learner's solutions to small exercises that
+// sit next to the example topics.
+//
+// Each exercise is a stub function in its topic's directory (for example
+// Reverse in slice/exercise.go) plus a table-driven test that ships
+// inside this package, under testdata/<topic>/. The test is hidden: it
+// never lives in the topic directory, but is laid over it with go test
+// -overlay when the exercise is checked, so the learner sees only which
+// cases failed and a hint for each.
+package exercise
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+//go:embed testdata
+var data embed.FS
+
+// Exercise describes one exercise. It is read from
+// testdata/<topic>/exercises.json; the hidden test is
+// testdata/<topic>/<name>_check.go with "-" in name replaced by "_".
+type Exercise struct {
+	Topic  string `json:"-"`
+	Name   string `json:"name"`
+	Func   string `json:"func"`   // the function the learner writes; tested by Test<Func>
+	File   string `json:"file"`   // the stub, relative to the topic directory
+	Prompt string `json:"prompt"` // what to implement
+	Hint   string `json:"hint"`   // shown for failing cases without a hint of their own
+
+	// Hints holds a hint per test case, keyed by the case name as passed
+	// to t.Run.
+	Hints map[string]string `json:"hints"`
+
+	test []byte
+}
+
+// ID is "topic/name", the form Lookup and the learn tool accept.
+func (e *Exercise) ID() string { return e.Topic + "/" + e.Name }
+
+// TestName is the hidden test function.
+func (e *Exercise) TestName() string { return "Test" + e.Func }
+
+// HintFor returns the hint for a failing case.
+func (e *Exercise) HintFor(caseName string) string {
+	for name, h := range e.Hints {
+		if name == caseName || strings.ReplaceAll(name, " ", "_") == caseName {
+			return h
+		}
+	}
+	return e.Hint
+}
+
+// ErrNotFound is returned by Lookup.
+var ErrNotFound = errors.New("exercise: no such exercise")
+
+// All returns every exercise, sorted by topic and then name.
+func All() ([]*Exercise, error) {
+	topics, err := fs.ReadDir(data, "testdata")
+	if err != nil {
+		return nil, err
+	}
+	var all []*Exercise
+	for _, d := range topics {
+		if !d.IsDir() {
+			continue
+		}
+		exs, err := load(d.Name())
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, exs...)
+	}
+	slices.SortFunc(all, func(a, b *Exercise) int { return strings.Compare(a.ID(), b.ID()) })
+	return all, nil
+}
+
+// Lookup returns the exercises of a topic, or just the one named by
+// "topic/name".
+func Lookup(all []*Exercise, name string) ([]*Exercise, error) {
+	var found []*Exercise
+	for _, e := range all {
+		if e.Topic == name || e.ID() == name {
+			found = append(found, e)
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%w %q", ErrNotFound, name)
+	}
+	return found, nil
+}
+
+func load(topic string) ([]*Exercise, error) {
+	dir := path.Join("testdata", topic)
+	raw, err := data.ReadFile(path.Join(dir, "exercises.json"))
+	if err != nil {
+		return nil, err
+	}
+	var exs []*Exercise
+	if err := json.Unmarshal(raw, &exs); err != nil {
+		return nil, fmt.Errorf("exercise: %s: %w", topic, err)
+	}
+	for _, e := range exs {
+		e.Topic = topic
+		if e.Name == "" || e.Func == "" || e.File == "" {
+			return nil, fmt.Errorf("exercise: %s: name, func and file are required", e.ID())
+		}
+		file := strings.ReplaceAll(e.Name, "-", "_") + "_check.go"
+		if e.test, err = data.ReadFile(path.Join(dir, file)); err != nil {
+			return nil, fmt.Errorf("exercise: %s: hidden test: %w", e.ID(), err)
+		}
+	}
+	return exs, nil
+}
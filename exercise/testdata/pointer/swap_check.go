@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestSwap(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b int
+	}{
+		{"swaps values", 1, 2},
+		{"negative", -5, 8},
+		{"equal values", 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.a, tt.b
+			Swap(&a, &b)
+			if a != tt.b || b != tt.a {
+				t.Errorf("after Swap(&%d, &%d): a = %d, b = %d", tt.a, tt.b, a, b)
+			}
+		})
+	}
+
+	t.Run("same pointer", func(t *testing.T) {
+		x := 4
+		Swap(&x, &x)
+		if x != 4 {
+			t.Errorf("Swap(&x, &x) changed x from 4 to %d", x)
+		}
+	})
+	t.Run("nil", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Swap(nil, &x) panicked: %v", r)
+			}
+		}()
+		x := 1
+		Swap(nil, &x)
+		if x != 1 {
+			t.Errorf("Swap(nil, &x) changed x to %d", x)
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestSumDigits(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want int
+	}{
+		{"zero", 0, 0},
+		{"one digit", 7, 7},
+		{"several digits", 472, 13},
+		{"zeros inside", 1005, 6},
+		{"negative", -472, 13},
+		{"large", 999999999, 81},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SumDigits(tt.n); got != tt.want {
+				t.Errorf("SumDigits(%d) = %d, want %d", tt.n, got, tt.want)
+			}
+		})
+	}
+}
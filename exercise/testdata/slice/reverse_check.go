@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"nil", nil, []int{}},
+		{"one", []int{7}, []int{7}},
+		{"odd length", []int{1, 2, 3}, []int{3, 2, 1}},
+		{"even length", []int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Reverse(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("Reverse(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("input unchanged", func(t *testing.T) {
+		in := []int{1, 2, 3}
+		Reverse(in)
+		if !slices.Equal(in, []int{1, 2, 3}) {
+			t.Errorf("after Reverse the input is %v, want [1 2 3]", in)
+		}
+	})
+	t.Run("result independent", func(t *testing.T) {
+		in := []int{1, 2, 3}
+		got := Reverse(in)
+		if len(got) > 0 {
+			got[0] = 99
+		}
+		if in[0] == 99 || in[2] == 99 {
+			t.Errorf("changing the result changed the input: %v", in)
+		}
+	})
+}
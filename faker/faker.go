@@ -0,0 +1,116 @@
+// Package faker generates plausible fake data for fixtures, benchmarks
+// and demos. A Faker is deterministic for a given seed, so generated
+// fixtures are the same on every run.
+package faker
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+)
+
+var (
+	firstNames = []string{
+		"Armaan", "Nadia", "Rahim", "Karim", "Fatima", "Ayesha", "Tanvir", "Sadia",
+		"Imran", "Nusrat", "Rafi", "Mitu", "Sakib", "Lamia", "Jamal", "Priya",
+		"Alex", "Sam", "Jordan", "Maria", "Chen", "Yuki", "Omar", "Lena",
+	}
+	lastNames = []string{
+		"Hossain", "Rahman", "Ahmed", "Chowdhury", "Islam", "Khan", "Sarker", "Das",
+		"Roy", "Akter", "Uddin", "Karim", "Smith", "Garcia", "Kim", "Novak",
+	}
+	cities = []string{
+		"Dhaka", "Chattogram", "Khulna", "Rajshahi", "Sylhet", "Barishal",
+		"Rangpur", "Mymensingh", "Comilla", "Gazipur",
+	}
+	words = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing
+		elit sed do eiusmod tempor incididunt ut labore et dolore magna aliqua`)
+)
+
+// Faker is a seeded source of fake values. It is not safe for concurrent
+// use; give each goroutine its own.
+type Faker struct {
+	r *rand.Rand
+}
+
+// New returns a Faker seeded with seed.
+func New(seed uint64) *Faker {
+	return &Faker{r: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+}
+
+// Rand exposes the underlying generator for values faker has no helper
+// for.
+func (f *Faker) Rand() *rand.Rand { return f.r }
+
+// IntN returns an int in [0, n).
+func (f *Faker) IntN(n int) int { return f.r.IntN(n) }
+
+// Between returns an int in [lo, hi].
+func (f *Faker) Between(lo, hi int) int { return lo + f.r.IntN(hi-lo+1) }
+
+// Pick returns a random element of s, which must not be empty.
+func Pick[T any](f *Faker, s []T) T { return s[f.r.IntN(len(s))] }
+
+func (f *Faker) FirstName() string { return Pick(f, firstNames) }
+func (f *Faker) LastName() string  { return Pick(f, lastNames) }
+func (f *Faker) Name() string      { return f.FirstName() + " " + f.LastName() }
+func (f *Faker) City() string      { return Pick(f, cities) }
+
+// Age returns an adult age, 18 to 80.
+func (f *Faker) Age() int { return f.Between(18, 80) }
+
+// Email returns an address at example.com (reserved, never delivered).
+func (f *Faker) Email() string {
+	return strings.ToLower(f.FirstName()+"."+f.LastName()) + "@example.com"
+}
+
+// Sentence returns n lorem ipsum words with a capital and a full stop.
+func (f *Faker) Sentence(n int) string {
+	ws := make([]string, n)
+	for i := range ws {
+		ws[i] = Pick(f, words)
+	}
+	s := strings.Join(ws, " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+// User returns a user with the given ID and a fake name and age.
+func (f *Faker) User(id int) userstore.User {
+	return userstore.User{ID: id, Name: f.Name(), Age: f.Age()}
+}
+
+// Users returns n users with IDs 1 to n.
+func (f *Faker) Users(n int) []userstore.User {
+	users := make([]userstore.User, n)
+	for i := range users {
+		users[i] = f.User(i + 1)
+	}
+	return users
+}
+
+// WriteUsersJSON writes n users to w as a JSON array, one user per line,
+// without holding them all in memory.
+func (f *Faker) WriteUsersJSON(w io.Writer, n int) error {
+	enc := json.NewEncoder(w) // Encode appends the newline
+	if _, err := io.WriteString(w, "[\n"); err != nil {
+		return err
+	}
+	for i := range n {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(f.User(i + 1)); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
@@ -0,0 +1,206 @@
+// Package fileserver serves a directory tree over HTTP.
+//
+// Conditional requests (ETag / If-None-Match, If-Modified-Since) and byte
+// ranges are delegated to http.ServeContent. Text files are gzipped for
+// clients that accept it, except for range requests, whose offsets refer
+// to the uncompressed bytes.
+package fileserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/compress"
+)
+
+// Options tune a Handler.
+type Options struct {
+	// Listing renders an HTML index for directories without index.html.
+	Listing bool
+	// GzipMinSize is the smallest text file that gets compressed.
+	// Zero means 1 KiB.
+	GzipMinSize int64
+}
+
+// Handler serves the files of fsys.
+type Handler struct {
+	fsys fs.FS
+	opts Options
+}
+
+// New returns a Handler serving fsys, typically os.DirFS(dir).
+func New(fsys fs.FS, opts Options) *Handler {
+	if opts.GzipMinSize <= 0 {
+		opts.GzipMinSize = 1 << 10
+	}
+	return &Handler{fsys: fsys, opts: opts}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+	info, err := fs.Stat(h.fsys, name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			http.Redirect(w, r, r.URL.Path+"/", http.StatusMovedPermanently)
+			return
+		}
+		index := path.Join(name, "index.html")
+		if ii, err := fs.Stat(h.fsys, index); err == nil && !ii.IsDir() {
+			h.serveFile(w, r, index, ii)
+			return
+		}
+		if !h.opts.Listing {
+			http.NotFound(w, r)
+			return
+		}
+		h.serveListing(w, r, name)
+		return
+	}
+	h.serveFile(w, r, name, info)
+}
+
+func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, name string, info fs.FileInfo) {
+	f, err := h.fsys.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", ctype)
+	w.Header().Add("Vary", "Accept-Encoding")
+	etag := fmt.Sprintf(`"%x-%x`, info.ModTime().UnixNano(), info.Size())
+
+	if h.shouldGzip(r, ctype, info.Size()) {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+
+		w.Header().Set("ETag", etag+`-gz"`)
+		w.Header().Set("Content-Encoding", "gzip")
+		http.ServeContent(w, r, name, info.ModTime(), bytes.NewReader(buf.Bytes()))
+		return
+	}
+
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rs = bytes.NewReader(data)
+	}
+	w.Header().Set("ETag", etag+`"`)
+	http.ServeContent(w, r, name, info.ModTime(), rs)
+}
+
+func (h *Handler) shouldGzip(r *http.Request, ctype string, size int64) bool {
+	if size < h.opts.GzipMinSize || r.Header.Get("Range") != "" {
+		return false
+	}
+	if !compress.AcceptsGzip(r.Header.Get("Accept-Encoding")) {
+		return false
+	}
+	return isText(ctype)
+}
+
+func isText(ctype string) bool {
+	mt, _, _ := mime.ParseMediaType(ctype)
+	if strings.HasPrefix(mt, "text/") {
+		return true
+	}
+	switch mt {
+	case "application/json", "application/javascript", "application/xml", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+var listingTmpl = template.Must(template.New("listing").Parse(`<!doctype html>
+<title>Index of {{.Path}}</title>
+<h1>Index of {{.Path}}</h1>
+<table>
+<tr><th>Name</th><th>Size</th><th>Modified</th></tr>
+{{if ne .Path "/"}}<tr><td><a href="../">../</a></td><td></td><td></td></tr>
+{{end}}{{range .Entries}}<tr><td><a href="{{.Href}}">{{.Name}}</a></td><td>{{.Size}}</td><td>{{.Mod}}</td></tr>
+{{end}}</table>
+`))
+
+type listEntry struct {
+	Name, Href, Size, Mod string
+}
+
+func (h *Handler) serveListing(w http.ResponseWriter, r *http.Request, dir string) {
+	entries, err := fs.ReadDir(h.fsys, dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		if a.IsDir() != b.IsDir() {
+			if a.IsDir() {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	var list []listEntry
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		le := listEntry{Name: e.Name(), Href: url.PathEscape(e.Name()), Mod: info.ModTime().UTC().Format(time.DateTime)}
+		if e.IsDir() {
+			le.Name += "/"
+			le.Href += "/"
+		} else {
+			le.Size = fmt.Sprint(info.Size())
+		}
+		list = append(list, le)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	listingTmpl.Execute(w, struct {
+		Path    string
+		Entries []listEntry
+	}{Path: r.URL.Path, Entries: list})
+}
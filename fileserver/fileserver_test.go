@@ -0,0 +1,192 @@
+package fileserver_test
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/armaanepiic/Golang/fileserver"
+)
+
+var modTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+var big = strings.Repeat("all work and no play\n", 200)
+
+func newHandler(listing bool) http.Handler {
+	file := func(data string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(data), ModTime: modTime}
+	}
+	return fileserver.New(fstest.MapFS{
+		"hello.txt":       file("hello, world"),
+		"big.txt":         file(big),
+		"logo.png":        file(big), // large but not text
+		"site/index.html": file("<h1>home</h1>"),
+		"docs/a#b.txt":    file("hash"),
+		"docs/50%.txt":    file("percent"),
+		"docs/why?.txt":   file("question"),
+		"docs/sub/x.txt":  file("x"),
+	}, fileserver.Options{Listing: listing})
+}
+
+func do(h http.Handler, method, target string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServeFile(t *testing.T) {
+	rec := do(newHandler(false), "GET", "/hello.txt")
+	if rec.Code != 200 || rec.Body.String() != "hello, world" {
+		t.Fatalf("GET = %d %q", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if rec.Header().Get("ETag") == "" || rec.Header().Get("Last-Modified") == "" {
+		t.Errorf("validators missing: %v", rec.Header())
+	}
+
+	if rec := do(newHandler(false), "POST", "/hello.txt"); rec.Code != 405 || rec.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST = %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+	if rec := do(newHandler(false), "GET", "/missing.txt"); rec.Code != 404 {
+		t.Errorf("missing file = %d", rec.Code)
+	}
+}
+
+func TestRanges(t *testing.T) {
+	h := newHandler(false)
+
+	rec := do(h, "GET", "/hello.txt", "Range", "bytes=7-11")
+	if rec.Code != 206 || rec.Body.String() != "world" || rec.Header().Get("Content-Range") != "bytes 7-11/12" {
+		t.Fatalf("single range = %d %q %q", rec.Code, rec.Body.String(), rec.Header().Get("Content-Range"))
+	}
+
+	rec = do(h, "GET", "/hello.txt", "Range", "bytes=0-4,7-11")
+	mt, params, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if rec.Code != 206 || mt != "multipart/byteranges" {
+		t.Fatalf("multi range = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	mr := multipart.NewReader(rec.Body, params["boundary"])
+	for _, want := range []string{"hello", "world"} {
+		p, err := mr.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, _ := io.ReadAll(p); string(b) != want {
+			t.Errorf("part = %q, want %q", b, want)
+		}
+	}
+
+	if rec := do(h, "GET", "/hello.txt", "Range", "bytes=100-"); rec.Code != 416 {
+		t.Errorf("unsatisfiable range = %d", rec.Code)
+	}
+
+	// offsets refer to the plain bytes, so a range is never gzipped
+	rec = do(h, "GET", "/big.txt", "Range", "bytes=0-2", "Accept-Encoding", "gzip")
+	if rec.Code != 206 || rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != "all" {
+		t.Errorf("range with gzip = %d %q %q", rec.Code, rec.Header().Get("Content-Encoding"), rec.Body.String())
+	}
+}
+
+func TestConditional(t *testing.T) {
+	h := newHandler(false)
+	etag := do(h, "GET", "/hello.txt").Header().Get("ETag")
+
+	if rec := do(h, "GET", "/hello.txt", "If-None-Match", etag); rec.Code != 304 || rec.Body.Len() != 0 {
+		t.Errorf("If-None-Match = %d", rec.Code)
+	}
+	if rec := do(h, "GET", "/hello.txt", "If-None-Match", `"other"`); rec.Code != 200 {
+		t.Errorf("stale If-None-Match = %d", rec.Code)
+	}
+	if rec := do(h, "GET", "/hello.txt", "If-Modified-Since", modTime.Format(http.TimeFormat)); rec.Code != 304 {
+		t.Errorf("If-Modified-Since = %d", rec.Code)
+	}
+	if rec := do(h, "GET", "/hello.txt", "If-Modified-Since", modTime.Add(-time.Hour).Format(http.TimeFormat)); rec.Code != 200 {
+		t.Errorf("older If-Modified-Since = %d", rec.Code)
+	}
+}
+
+func TestGzip(t *testing.T) {
+	h := newHandler(false)
+
+	rec := do(h, "GET", "/big.txt", "Accept-Encoding", "gzip")
+	if rec.Header().Get("Content-Encoding") != "gzip" || !strings.Contains(rec.Header().Get("Vary"), "Accept-Encoding") {
+		t.Fatalf("headers %v", rec.Header())
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := io.ReadAll(zr); err != nil || string(b) != big {
+		t.Fatalf("gunzipped %d bytes, %v", len(b), err)
+	}
+	gzTag := rec.Header().Get("ETag")
+	if plain := do(h, "GET", "/big.txt").Header().Get("ETag"); plain == gzTag {
+		t.Errorf("gzip and identity share ETag %s", plain)
+	}
+
+	for _, tt := range []struct{ path, accept string }{
+		{"/big.txt", "gzip;q=0"},
+		{"/big.txt", "gzip;q=0.0"},
+		{"/big.txt", "br"},
+		{"/hello.txt", "gzip"}, // below GzipMinSize
+		{"/logo.png", "gzip"},  // not text
+	} {
+		if rec := do(h, "GET", tt.path, "Accept-Encoding", tt.accept); rec.Header().Get("Content-Encoding") != "" {
+			t.Errorf("%s with %q was compressed", tt.path, tt.accept)
+		}
+	}
+}
+
+func TestDirectories(t *testing.T) {
+	h := newHandler(true)
+
+	if rec := do(h, "GET", "/docs"); rec.Code != 301 || rec.Header().Get("Location") != "/docs/" {
+		t.Errorf("no slash = %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if rec := do(h, "GET", "/site/"); rec.Code != 200 || rec.Body.String() != "<h1>home</h1>" {
+		t.Errorf("index.html = %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := do(newHandler(false), "GET", "/docs/"); rec.Code != 404 {
+		t.Errorf("listing disabled = %d", rec.Code)
+	}
+
+	rec := do(h, "GET", "/docs/")
+	body := rec.Body.String()
+	if rec.Code != 200 || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("listing = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	for _, want := range []string{
+		`<a href="../">../</a>`,
+		`<a href="sub/">sub/</a>`,
+		`<a href="a%23b.txt">a#b.txt</a>`,
+		`<a href="50%25.txt">50%.txt</a>`,
+		`<a href="why%3F.txt">why?.txt</a>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("listing lacks %s:\n%s", want, body)
+		}
+	}
+	if strings.Index(body, "sub/") > strings.Index(body, "50%") {
+		t.Error("directories are not listed first")
+	}
+
+	// each link leads back to its file
+	for name, want := range map[string]string{"a%23b.txt": "hash", "50%25.txt": "percent", "why%3F.txt": "question"} {
+		if rec := do(h, "GET", "/docs/"+name); rec.Code != 200 || rec.Body.String() != want {
+			t.Errorf("GET /docs/%s = %d %q", name, rec.Code, rec.Body.String())
+		}
+	}
+}
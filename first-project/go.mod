@@ -1,3 +1,3 @@
-module example.com
+module github.com/armaanepiic/Golang/first-project
 
 go 1.25.6
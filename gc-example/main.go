@@ -0,0 +1,116 @@
+// Garbage collector: allocate in a few patterns and watch what the
+// collector does with them. GOGC (debug.SetGCPercent) sets how much the
+// heap may grow over the live data before the next collection: a lower
+// value collects more often and keeps the heap small, a higher one
+// collects less often and lets it grow, and -1 turns collection off.
+//
+//	go run ./gc-example
+//	GODEBUG=gctrace=1 go run ./gc-example   # the runtime's own log line per GC
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"text/tabwriter"
+	"time"
+
+	"github.com/armaanepiic/Golang/gcstats"
+)
+
+// sink keeps allocations from being optimised away or kept on the stack.
+var sink []byte
+
+// garbage makes many small objects that die immediately: the cheap case,
+// since a collection only has to trace what is still live.
+func garbage(n int) {
+	for range n {
+		sink = make([]byte, 256)
+	}
+}
+
+// retained keeps everything it allocates, so the live heap grows and
+// every collection has more to trace.
+func retained(n int) [][]byte {
+	var keep [][]byte
+	for range n {
+		keep = append(keep, make([]byte, 4096))
+	}
+	return keep
+}
+
+// large allocates objects too big for the size classes; each goes
+// straight to the heap as its own span.
+func large(n int) {
+	for range n {
+		sink = make([]byte, 1<<20)
+	}
+}
+
+type run struct {
+	name    string
+	percent int
+	work    func()
+}
+
+func main() {
+	n := flag.Int("n", 200_000, "allocations per run")
+	flag.Parse()
+	// restore the default when done, whatever the runs changed it to
+	defer debug.SetGCPercent(debug.SetGCPercent(100))
+
+	var kept [][]byte
+	runs := []run{
+		{"garbage", 100, func() { garbage(*n) }},
+		{"garbage", 25, func() { garbage(*n) }},
+		{"garbage", 400, func() { garbage(*n) }},
+		{"garbage", -1, func() { garbage(*n) }},
+		{"large", 100, func() { large(*n / 1000) }},
+		{"retained", 100, func() { kept = retained(*n / 10) }},
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "pattern\tGOGC\tallocated\tGCs\tpause total\tmax pause\theap after\ttime")
+	for _, r := range runs {
+		runtime.GC() // start every run from a collected heap
+		debug.SetGCPercent(r.percent)
+		before := gcstats.Sample()
+		r.work()
+		d := gcstats.Sample().Sub(before)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%v\t%v\t%s\t%v\n", r.name, gogc(r.percent),
+			gcstats.Bytes(d.Allocated), d.GCs, d.PauseTotal, d.MaxPause(),
+			gcstats.Bytes(d.HeapAfter), d.Elapsed.Round(time.Millisecond))
+	}
+	tw.Flush()
+	kept = nil
+
+	// the same retained pattern sampled over time: the heap climbs and
+	// the next-GC target moves up with it
+	fmt.Println()
+	fmt.Println("retained, sampled every 20ms:")
+	debug.SetGCPercent(100)
+	runtime.GC()
+	stop := gcstats.Record(20 * time.Millisecond)
+	for range 5 {
+		kept = append(kept, retained(*n/50)...)
+		garbage(*n)
+	}
+	snaps := stop()
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "t\theap\tnext GC\tGCs\tpause total")
+	for _, s := range snaps {
+		fmt.Fprintf(tw, "%v\t%s\t%s\t%d\t%v\n", s.Time.Sub(snaps[0].Time).Round(time.Millisecond),
+			gcstats.Bytes(s.HeapAlloc), gcstats.Bytes(s.NextGC), s.NumGC, s.PauseTotal)
+	}
+	tw.Flush()
+	fmt.Printf("kept %s in %d buffers\n", gcstats.Bytes(uint64(len(kept))*4096), len(kept))
+}
+
+func gogc(percent int) string {
+	if percent < 0 {
+		return "off"
+	}
+	return fmt.Sprint(percent)
+}
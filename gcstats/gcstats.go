@@ -0,0 +1,167 @@
+// Package gcstats takes snapshots of the garbage collector's counters
+// and reports what changed between two of them: how much was allocated,
+// how many collections ran and how long they paused the program.
+//
+//	before := gcstats.Sample()
+//	work()
+//	fmt.Println(gcstats.Sample().Sub(before))
+//
+// Sample calls runtime.ReadMemStats, which briefly stops the world, so
+// take snapshots around a piece of work rather than inside a hot loop.
+package gcstats
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Snapshot is the state of the heap and the collector at one moment.
+type Snapshot struct {
+	Time        time.Time
+	HeapAlloc   uint64 // bytes of live and not yet collected objects
+	HeapObjects uint64
+	NextGC      uint64 // heap size that triggers the next collection
+	TotalAlloc  uint64 // bytes allocated since the program started
+	Mallocs     uint64 // objects allocated since the program started
+	NumGC       uint32
+	PauseTotal  time.Duration
+	CPUFraction float64 // share of CPU time spent in the GC since start
+
+	pauses [256]uint64 // runtime.MemStats.PauseNs: a ring of recent pauses
+}
+
+// Sample reads the current statistics.
+func Sample() Snapshot {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return Snapshot{
+		Time:        time.Now(),
+		HeapAlloc:   m.HeapAlloc,
+		HeapObjects: m.HeapObjects,
+		NextGC:      m.NextGC,
+		TotalAlloc:  m.TotalAlloc,
+		Mallocs:     m.Mallocs,
+		NumGC:       m.NumGC,
+		PauseTotal:  time.Duration(m.PauseTotalNs),
+		CPUFraction: m.GCCPUFraction,
+		pauses:      m.PauseNs,
+	}
+}
+
+func (s Snapshot) String() string {
+	return fmt.Sprintf("heap %s (next GC at %s), %d objects, %d GCs", Bytes(s.HeapAlloc), Bytes(s.NextGC), s.HeapObjects, s.NumGC)
+}
+
+// Delta is what happened between two snapshots.
+type Delta struct {
+	Elapsed    time.Duration
+	Allocated  uint64 // bytes
+	Objects    uint64 // allocations
+	GCs        uint32
+	PauseTotal time.Duration
+	Pauses     []time.Duration // of each GC in between, oldest first; at most the last 256
+	HeapBefore uint64
+	HeapAfter  uint64
+}
+
+// Sub returns what happened between before and s.
+func (s Snapshot) Sub(before Snapshot) Delta {
+	d := Delta{
+		Elapsed:    s.Time.Sub(before.Time),
+		Allocated:  s.TotalAlloc - before.TotalAlloc,
+		Objects:    s.Mallocs - before.Mallocs,
+		GCs:        s.NumGC - before.NumGC,
+		PauseTotal: s.PauseTotal - before.PauseTotal,
+		HeapBefore: before.HeapAlloc,
+		HeapAfter:  s.HeapAlloc,
+	}
+	// PauseNs[(NumGC+255)%256] is the most recent pause
+	for i := min(d.GCs, uint32(len(s.pauses))); i > 0; i-- {
+		gc := s.NumGC - i + 1 // 1-based number of the collection
+		d.Pauses = append(d.Pauses, time.Duration(s.pauses[(gc+255)%256]))
+	}
+	return d
+}
+
+// MaxPause is the longest pause in d.Pauses.
+func (d Delta) MaxPause() time.Duration {
+	if len(d.Pauses) == 0 {
+		return 0
+	}
+	return slices.Max(d.Pauses)
+}
+
+func (d Delta) String() string {
+	return fmt.Sprintf("%s in %d objects, %d GCs, paused %v (max %v), heap %s -> %s in %v",
+		Bytes(d.Allocated), d.Objects, d.GCs, d.PauseTotal, d.MaxPause(),
+		Bytes(d.HeapBefore), Bytes(d.HeapAfter), d.Elapsed.Round(time.Millisecond))
+}
+
+// MetricReporter is the part of *testing.B that Report needs.
+type MetricReporter interface {
+	ReportMetric(n float64, unit string)
+}
+
+// Report adds d to a benchmark's results as per-operation metrics:
+// GCs/op and pause-ns/op, next to the ns/op and allocs/op the testing
+// package reports itself.
+//
+//	before := gcstats.Sample()
+//	for b.Loop() { ... }
+//	gcstats.Report(b, b.N, gcstats.Sample().Sub(before))
+func Report(r MetricReporter, ops int, d Delta) {
+	if ops <= 0 {
+		return
+	}
+	r.ReportMetric(float64(d.GCs)/float64(ops), "GCs/op")
+	r.ReportMetric(float64(d.PauseTotal.Nanoseconds())/float64(ops), "pause-ns/op")
+}
+
+// Record samples every interval in the background until the returned
+// function is called, which returns the snapshots in order, including a
+// first one taken immediately and a last one taken at stop.
+func Record(interval time.Duration) (stop func() []Snapshot) {
+	var mu sync.Mutex
+	snaps := []Snapshot{Sample()}
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				s := Sample()
+				mu.Lock()
+				snaps = append(snaps, s)
+				mu.Unlock()
+			}
+		}
+	}()
+	return func() []Snapshot {
+		close(done)
+		<-finished
+		mu.Lock()
+		defer mu.Unlock()
+		return append(snaps, Sample())
+	}
+}
+
+// Bytes formats n as B, KiB, MiB or GiB.
+func Bytes(n uint64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
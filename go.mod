@@ -0,0 +1,18 @@
+module github.com/armaanepiic/Golang
+
+go 1.26.1
+
+require (
+	golang.org/x/net v0.59.0
+	golang.org/x/tools v0.50.0
+	google.golang.org/grpc v1.79.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
+)
@@ -0,0 +1,159 @@
+// Package golden pins down what the example programs print. A topic's
+// stdout is captured and compared with a checked-in golden file, so a
+// refactor of a teaching example can't silently change the output the
+// lesson talks about.
+//
+// Golden files live in testdata/golden at the repository root, one per
+// topic, named after the topic with "/" replaced by "__". Only topics
+// that have one are checked: examples that print times, random numbers
+// or run servers are left out by simply not recording them.
+package golden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/topics"
+)
+
+// Path returns the golden file of topic t under the repository root.
+func Path(root string, t topics.Topic) string {
+	return filepath.Join(root, "testdata", "golden", strings.ReplaceAll(t.Name, "/", "__")+".golden")
+}
+
+// Has reports whether t has a golden file.
+func Has(root string, t topics.Topic) bool {
+	_, err := os.Stat(Path(root, t))
+	return err == nil
+}
+
+// Capture builds t and runs it with no input, returning what it wrote to
+// stdout. The program must exit on its own within timeout; a non-zero
+// exit is an error that includes its stderr.
+func Capture(ctx context.Context, t topics.Topic, timeout time.Duration) ([]byte, error) {
+	tmp, err := os.MkdirTemp("", "golden-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	bin := filepath.Join(tmp, "example")
+	if out, err := t.BuildCommand(ctx, bin).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("golden: build %s: %w\n%s", t.Name, err, out)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin)
+	cmd.Dir = t.Dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	cmd.WaitDelay = time.Second
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("golden: %s still running after %v; only programs that exit can have golden output", t.Name, timeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("golden: %s: %w\n%s", t.Name, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return normalize(stdout.Bytes()), nil
+}
+
+// normalize makes output comparable across platforms: \r\n becomes \n.
+func normalize(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+}
+
+// Compare checks got against t's golden file and returns a diff, empty
+// if they match.
+func Compare(root string, t topics.Topic, got []byte) (string, error) {
+	want, err := os.ReadFile(Path(root, t))
+	if err != nil {
+		return "", err
+	}
+	return Diff(normalize(want), got), nil
+}
+
+// Update writes got as t's golden file.
+func Update(root string, t topics.Topic, got []byte) error {
+	path := Path(root, t)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, got, 0o644)
+}
+
+// Diff returns a line diff from want to got, with "-" for lines only in
+// want and "+" for lines only in got, or "" if they are equal. Unchanged
+// lines are shown only next to changes.
+func Diff(want, got []byte) string {
+	if bytes.Equal(want, got) {
+		return ""
+	}
+	a := strings.Split(string(want), "\n")
+	b := strings.Split(string(got), "\n")
+
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, line{'+', b[j]})
+			j++
+		}
+	}
+
+	// show changes with two lines of context around them
+	const around = 2
+	show := make([]bool, len(lines))
+	for k, l := range lines {
+		if l.op != ' ' {
+			for c := max(0, k-around); c <= min(len(lines)-1, k+around); c++ {
+				show[c] = true
+			}
+		}
+	}
+	var out strings.Builder
+	for k, l := range lines {
+		if !show[k] {
+			continue
+		}
+		if k > 0 && !show[k-1] {
+			out.WriteString("  ...\n")
+		}
+		fmt.Fprintf(&out, "%c %s\n", l.op, l.text)
+	}
+	return out.String()
+}
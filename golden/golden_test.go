@@ -0,0 +1,83 @@
+package golden_test
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/golden"
+	"github.com/armaanepiic/Golang/topics"
+)
+
+var update = flag.Bool("update", false, "write the current output as the golden files")
+
+// TestExamples runs every topic that has a golden file and compares its
+// output. go test ./golden -update re-records them; review the diff first.
+func TestExamples(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs every example")
+	}
+	root, err := topics.FindRoot(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := topics.Discover(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for _, tp := range all {
+		if !golden.Has(root, tp) {
+			continue
+		}
+		n++
+		t.Run(tp.Name, func(t *testing.T) {
+			t.Parallel()
+			got, err := golden.Capture(context.Background(), tp, 30*time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *update {
+				if err := golden.Update(root, tp, got); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			diff, err := golden.Compare(root, tp, got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff != "" {
+				t.Errorf("output differs from %s (- golden, + now)\n%s", golden.Path(root, tp), diff)
+			}
+		})
+	}
+	if n == 0 {
+		t.Fatal("no golden files found under testdata/golden")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got string
+		diff      string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{"changed line", "a\nb\nc\n", "a\nx\nc\n", "  a\n- b\n+ x\n  c\n  \n"},
+		{"added line", "a\n", "a\nb\n", "  a\n+ b\n  \n"},
+		{
+			"context is cut",
+			"1\n2\n3\n4\n5\n6\n7\n8\n", "1\n2\n3\n4\n5\n6\n7\nX\n",
+			"  ...\n  6\n  7\n- 8\n+ X\n  \n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := golden.Diff([]byte(tt.want), []byte(tt.got)); got != tt.diff {
+				t.Errorf("Diff =\n%q\nwant\n%q", got, tt.diff)
+			}
+		})
+	}
+}
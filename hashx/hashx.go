@@ -0,0 +1,109 @@
+// Package hashx computes MD5, SHA-1, SHA-256 and SHA-512 digests of
+// streams and files, and reads and writes checksum manifests in the
+// format of sha256sum and friends.
+package hashx
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+// Algorithm names a hash function.
+type Algorithm string
+
+// MD5 and SHA1 are only good for spotting accidental corruption; use
+// SHA256 or SHA512 when someone might tamper with the data.
+const (
+	MD5    Algorithm = "md5"
+	SHA1   Algorithm = "sha1"
+	SHA256 Algorithm = "sha256"
+	SHA512 Algorithm = "sha512"
+)
+
+// ParseAlgorithm accepts names like "sha256", "SHA-256" or "sha-256".
+func ParseAlgorithm(s string) (Algorithm, error) {
+	a := Algorithm(strings.ReplaceAll(strings.ToLower(s), "-", ""))
+	switch a {
+	case MD5, SHA1, SHA256, SHA512:
+		return a, nil
+	}
+	return "", fmt.Errorf("hashx: unknown algorithm %q", s)
+}
+
+// New returns a fresh hash.Hash for a.
+func (a Algorithm) New() hash.Hash {
+	switch a {
+	case MD5:
+		return md5.New()
+	case SHA1:
+		return sha1.New()
+	case SHA512:
+		return sha512.New()
+	}
+	return sha256.New()
+}
+
+// Size returns the digest length in hex characters.
+func (a Algorithm) Size() int {
+	return a.New().Size() * 2
+}
+
+// Progress is called as data is hashed with the number of bytes done.
+type Progress func(done int64)
+
+// Sum hashes everything read from r and returns the hex digest. progress
+// may be nil; if set, its last call reports the full length.
+func Sum(r io.Reader, a Algorithm, progress Progress) (string, error) {
+	h := a.New()
+	var w io.Writer = h
+	var pw *progressWriter
+	if progress != nil {
+		pw = &progressWriter{w: h, fn: progress}
+		w = pw
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return "", err
+	}
+	if pw != nil && pw.last != pw.n {
+		progress(pw.n)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SumFile hashes the file at path.
+func SumFile(path string, a Algorithm, progress Progress) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return Sum(f, a, progress)
+}
+
+type progressWriter struct {
+	w    io.Writer
+	n    int64
+	fn   Progress
+	last int64
+}
+
+// reportEvery throttles progress callbacks.
+const reportEvery = 1 << 20
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+	if p.n-p.last >= reportEvery || err != nil {
+		p.fn(p.n)
+		p.last = p.n
+	}
+	return n, err
+}
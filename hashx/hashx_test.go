@@ -0,0 +1,184 @@
+package hashx_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/hashx"
+)
+
+// Digests of the fixtures, as printed by md5sum, sha1sum, sha256sum and
+// sha512sum.
+var known = []struct {
+	file string
+	algo hashx.Algorithm
+	sum  string
+}{
+	{"abc.txt", hashx.MD5, "900150983cd24fb0d6963f7d28e17f72"},
+	{"abc.txt", hashx.SHA1, "a9993e364706816aba3e25717850c26c9cd0d89d"},
+	{"abc.txt", hashx.SHA256, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	{"abc.txt", hashx.SHA512, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+	{"empty.txt", hashx.MD5, "d41d8cd98f00b204e9800998ecf8427e"},
+	{"empty.txt", hashx.SHA1, "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+	{"empty.txt", hashx.SHA256, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	{"empty.txt", hashx.SHA512, "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e"},
+}
+
+func TestSumFile(t *testing.T) {
+	for _, k := range known {
+		sum, err := hashx.SumFile(filepath.Join("testdata", k.file), k.algo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sum != k.sum {
+			t.Errorf("%s %s = %s, want %s", k.algo, k.file, sum, k.sum)
+		}
+		if len(sum) != k.algo.Size() {
+			t.Errorf("%s Size = %d, digest has %d", k.algo, k.algo.Size(), len(sum))
+		}
+	}
+	if _, err := hashx.SumFile("testdata/nope", hashx.SHA256, nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: %v", err)
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	for in, want := range map[string]hashx.Algorithm{"sha256": hashx.SHA256, "SHA-256": hashx.SHA256, "Md5": hashx.MD5, "sha-512": hashx.SHA512} {
+		if got, err := hashx.ParseAlgorithm(in); err != nil || got != want {
+			t.Errorf("ParseAlgorithm(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := hashx.ParseAlgorithm("crc32"); err == nil {
+		t.Error("crc32 accepted")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 3<<20+12345)
+	var calls []int64
+	// hide WriterTo so the data arrives in io.Copy's small chunks
+	r := struct{ io.Reader }{bytes.NewReader(data)}
+	sum, err := hashx.Sum(r, hashx.SHA256, func(done int64) { calls = append(calls, done) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := hashx.Sum(bytes.NewReader(data), hashx.SHA256, nil)
+	if sum != want {
+		t.Fatal("progress changed the digest")
+	}
+	// roughly once per MiB, increasing, and the last call is the total
+	if len(calls) != 4 || !slices.IsSorted(calls) || calls[len(calls)-1] != int64(len(data)) {
+		t.Fatalf("progress calls %v for %d bytes", calls, len(data))
+	}
+
+	calls = nil
+	hashx.Sum(strings.NewReader("small"), hashx.MD5, func(done int64) { calls = append(calls, done) })
+	if !slices.Equal(calls, []int64{5}) {
+		t.Errorf("small input: progress calls %v", calls)
+	}
+}
+
+func TestHashTreeMatchesFixtureManifest(t *testing.T) {
+	var seen []string
+	entries, err := hashx.HashTree("testdata/tree", hashx.SHA256, nil, func(rel string) { seen = append(seen, rel) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	if err := hashx.WriteManifest(&got, entries); err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("testdata/tree.sha256") // written by sha256sum
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != string(want) {
+		t.Errorf("manifest:\n%s\nwant:\n%s", got.String(), want)
+	}
+	if !slices.Equal(seen, []string{"hello.txt", "sub/lorem.txt", "users.csv"}) {
+		t.Errorf("progress saw %v", seen)
+	}
+
+	skipped, err := hashx.HashTree("testdata/tree", hashx.SHA256, func(rel string) bool { return strings.HasPrefix(rel, "sub/") }, nil)
+	if err != nil || len(skipped) != 2 {
+		t.Errorf("skip: %v, %v", skipped, err)
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	in := "# made by hand\n\nABCDEF00  plain.txt\nabcdef01 *binary.bin\r\n"
+	entries, err := hashx.ReadManifest(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []hashx.Entry{{Sum: "abcdef00", Path: "plain.txt"}, {Sum: "abcdef01", Path: "binary.bin"}}
+	if !slices.Equal(entries, want) {
+		t.Fatalf("entries %v", entries)
+	}
+	for _, bad := range []string{"nothex  a.txt\n", "abcdef\n", "abcdef  \n"} {
+		if _, err := hashx.ReadManifest(strings.NewReader(bad)); err == nil {
+			t.Errorf("ReadManifest(%q) accepted", bad)
+		}
+	}
+}
+
+// copyTree copies testdata/tree into a temporary directory.
+func copyTree(t *testing.T) string {
+	t.Helper()
+	dst := t.TempDir()
+	if err := os.CopyFS(dst, os.DirFS("testdata/tree")); err != nil {
+		t.Fatal(err)
+	}
+	return dst
+}
+
+func TestVerify(t *testing.T) {
+	f, err := os.Open("testdata/tree.sha256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	entries, err := hashx.ReadManifest(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range hashx.Verify("testdata/tree", entries) {
+		if r.Status != hashx.OK {
+			t.Errorf("pristine %s: %v %v", r.Path, r.Status, r.Err)
+		}
+	}
+
+	dir := copyTree(t)
+	if err := os.WriteFile(filepath.Join(dir, "users.csv"), []byte("id,name\n1,Mallory\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "sub", "lorem.txt")); err != nil {
+		t.Fatal(err)
+	}
+	entries = append(entries,
+		hashx.Entry{Sum: strings.Repeat("0", 64), Path: "../outside.txt"},
+		hashx.Entry{Sum: "abc", Path: "hello.txt"},
+	)
+	var got []string
+	for _, r := range hashx.Verify(dir, entries) {
+		got = append(got, r.Path+" "+r.Status.String())
+	}
+	want := []string{
+		"hello.txt OK",
+		"sub/lorem.txt MISSING",
+		"users.csv FAILED",
+		"../outside.txt UNREADABLE",
+		"hello.txt UNREADABLE", // no algorithm makes 3-digit digests
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Verify:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,158 @@
+package hashx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Entry is one line of a manifest: a digest and a slash-separated path.
+type Entry struct {
+	Sum  string
+	Path string
+}
+
+// Status is the outcome of verifying one entry.
+type Status int
+
+const (
+	OK Status = iota
+	Mismatch
+	Missing
+	Unreadable
+)
+
+func (s Status) String() string {
+	return [...]string{"OK", "FAILED", "MISSING", "UNREADABLE"}[s]
+}
+
+// Result is the verification result of one entry.
+type Result struct {
+	Entry
+	Status Status
+	Err    error // set for Missing and Unreadable
+}
+
+// WriteManifest writes entries as "digest  path" lines, the format
+// sha256sum -c understands.
+func WriteManifest(w io.Writer, entries []Entry) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range entries {
+		fmt.Fprintf(bw, "%s  %s\n", e.Sum, e.Path)
+	}
+	return bw.Flush()
+}
+
+// ReadManifest parses a manifest. Blank lines and # comments are skipped.
+// A "*" before the path (binary mode in coreutils) is accepted.
+func ReadManifest(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimRight(s.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sum, path, ok := strings.Cut(line, " ")
+		path = strings.TrimPrefix(strings.TrimPrefix(path, " "), "*")
+		if !ok || path == "" || !isHex(sum) {
+			return nil, fmt.Errorf("hashx: manifest line %d: expected \"digest  path\"", n)
+		}
+		entries = append(entries, Entry{Sum: strings.ToLower(sum), Path: path})
+	}
+	return entries, s.Err()
+}
+
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashTree hashes every regular file under root, in path order. progress
+// is called per file with its path when it starts; it may be nil.
+func HashTree(root string, a Algorithm, skip func(rel string) bool, progress func(rel string)) ([]Entry, error) {
+	var entries []Entry
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if skip != nil && skip(rel) {
+			return nil
+		}
+		if progress != nil {
+			progress(rel)
+		}
+		sum, err := SumFile(path, a, nil)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, Entry{Sum: sum, Path: rel})
+		return nil
+	})
+	slices.SortFunc(entries, func(x, y Entry) int { return strings.Compare(x.Path, y.Path) })
+	return entries, err
+}
+
+// Verify rehashes every entry relative to root. The algorithm is
+// inferred from the digest length (MD5, SHA-1, SHA-256 or SHA-512).
+func Verify(root string, entries []Entry) []Result {
+	results := make([]Result, 0, len(entries))
+	for _, e := range entries {
+		r := Result{Entry: e}
+		a, ok := algorithmForLength(len(e.Sum))
+		path, err := localPath(root, e.Path)
+		switch {
+		case !ok:
+			r.Status, r.Err = Unreadable, fmt.Errorf("unknown digest length %d", len(e.Sum))
+		case err != nil:
+			r.Status, r.Err = Unreadable, err
+		default:
+			sum, err := SumFile(path, a, nil)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				r.Status, r.Err = Missing, err
+			case err != nil:
+				r.Status, r.Err = Unreadable, err
+			case sum != e.Sum:
+				r.Status = Mismatch
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func algorithmForLength(n int) (Algorithm, bool) {
+	for _, a := range []Algorithm{MD5, SHA1, SHA256, SHA512} {
+		if a.Size() == n {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// localPath refuses manifest paths that escape root.
+func localPath(root, p string) (string, error) {
+	p = filepath.FromSlash(p)
+	if !filepath.IsLocal(p) {
+		return "", fmt.Errorf("path %q leaves the directory", p)
+	}
+	return filepath.Join(root, p), nil
+}
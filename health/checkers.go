@@ -0,0 +1,32 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// Pinger is implemented by *sql.DB and most database clients.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Ping checks a database connection.
+func Ping(db Pinger) Checker {
+	return db.PingContext
+}
+
+// DiskSpace fails when the file system holding path has less than minFree
+// bytes available to unprivileged users.
+// It is implemented on Linux and macOS; elsewhere it returns ErrSkipped.
+func DiskSpace(path string, minFree uint64) Checker {
+	return func(ctx context.Context) error {
+		free, err := freeBytes(path)
+		if err != nil {
+			return err
+		}
+		if free < minFree {
+			return fmt.Errorf("%s: %d bytes free, want at least %d", path, free, minFree)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package health
+
+import "fmt"
+
+func freeBytes(path string) (uint64, error) {
+	return 0, fmt.Errorf("%w: disk space not supported on this platform", ErrSkipped)
+}
@@ -0,0 +1,29 @@
+//go:build linux || darwin
+
+package health
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	free, err := freeBytes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free == 0 {
+		t.Skip("temp dir file system is full")
+	}
+	if err := DiskSpace(dir, 1)(context.Background()); err != nil {
+		t.Errorf("DiskSpace(1 byte) = %v", err)
+	}
+	if err := DiskSpace(dir, math.MaxUint64)(context.Background()); err == nil {
+		t.Error("DiskSpace(MaxUint64) succeeded")
+	}
+	if _, err := freeBytes(dir + "/missing"); err == nil {
+		t.Error("freeBytes of a missing path succeeded")
+	}
+}
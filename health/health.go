@@ -0,0 +1,184 @@
+// Package health serves liveness, readiness and build information
+// endpoints:
+//
+//	/healthz  the process is up and serving requests
+//	/readyz   every registered checker passes (e.g. database, disk space)
+//	/version  module path, version and VCS details from debug.ReadBuildInfo
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Checker reports whether a dependency is usable.
+type Checker func(ctx context.Context) error
+
+// ErrSkipped is returned, possibly wrapped, by a checker that cannot run
+// here. The check is reported as skipped and does not fail readiness.
+var ErrSkipped = errors.New("health: check skipped")
+
+// CheckResult is the outcome of one checker.
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the JSON body of /healthz and /readyz.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+const (
+	StatusOK      = "ok"
+	StatusFail    = "fail"
+	StatusSkipped = "skipped"
+)
+
+// Health holds the readiness checkers.
+type Health struct {
+	// Timeout bounds each readiness probe as a whole. Zero means 5 seconds.
+	Timeout time.Duration
+
+	mu       sync.RWMutex
+	checkers map[string]Checker
+}
+
+// New returns a Health without checkers.
+func New() *Health {
+	return &Health{checkers: make(map[string]Checker)}
+}
+
+// Add registers a readiness checker under name, replacing any previous one.
+func (h *Health) Add(name string, c Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkers[name] = c
+}
+
+// Check runs every checker concurrently and collects the results.
+func (h *Health) Check(ctx context.Context) Report {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	h.mu.RLock()
+	names := make([]string, 0, len(h.checkers))
+	for n := range h.checkers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	checkers := make([]Checker, len(names))
+	for i, n := range names {
+		checkers[i] = h.checkers[n]
+	}
+	h.mu.RUnlock()
+
+	results := make([]CheckResult, len(names))
+	var wg sync.WaitGroup
+	for i, c := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := c(ctx)
+			res := CheckResult{Status: StatusOK, Duration: time.Since(start).String()}
+			switch {
+			case errors.Is(err, ErrSkipped):
+				res.Status = StatusSkipped
+				res.Error = err.Error()
+			case err != nil:
+				res.Status = StatusFail
+				res.Error = err.Error()
+			}
+			results[i] = res
+		}()
+	}
+	wg.Wait()
+
+	rep := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(names))}
+	for i, n := range names {
+		rep.Checks[n] = results[i]
+		if results[i].Status == StatusFail {
+			rep.Status = StatusFail
+		}
+	}
+	return rep
+}
+
+// Register mounts /healthz, /readyz and /version on mux.
+func (h *Health) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.handleLive)
+	mux.HandleFunc("GET /readyz", h.handleReady)
+	mux.HandleFunc("GET /version", handleVersion)
+}
+
+func (h *Health) handleLive(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+func (h *Health) handleReady(w http.ResponseWriter, r *http.Request) {
+	rep := h.Check(r.Context())
+	code := http.StatusOK
+	if rep.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, rep)
+}
+
+// BuildInfo is the JSON body of /version.
+type BuildInfo struct {
+	Path      string `json:"path"`
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	Revision  string `json:"vcs_revision,omitempty"`
+	Time      string `json:"vcs_time,omitempty"`
+	Modified  bool   `json:"vcs_modified,omitempty"`
+}
+
+// ReadBuildInfo extracts BuildInfo from the running binary.
+func ReadBuildInfo() BuildInfo {
+	bi := BuildInfo{GoVersion: runtime.Version(), Version: "(devel)"}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return bi
+	}
+	bi.Path = info.Main.Path
+	if info.Main.Version != "" {
+		bi.Version = info.Main.Version
+	}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			bi.Revision = s.Value
+		case "vcs.time":
+			bi.Time = s.Value
+		case "vcs.modified":
+			bi.Modified = s.Value == "true"
+		}
+	}
+	return bi
+}
+
+func handleVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, ReadBuildInfo())
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,29 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/armaanepiic/Golang/health"
+)
+
+func TestCheck(t *testing.T) {
+	h := health.New()
+	h.Add("up", func(context.Context) error { return nil })
+	h.Add("unsupported", func(context.Context) error {
+		return fmt.Errorf("%w: not on this platform", health.ErrSkipped)
+	})
+	if rep := h.Check(context.Background()); rep.Status != health.StatusOK ||
+		rep.Checks["up"].Status != health.StatusOK ||
+		rep.Checks["unsupported"].Status != health.StatusSkipped {
+		t.Fatalf("Check = %+v, want ok with one check skipped", rep)
+	}
+
+	h.Add("down", func(context.Context) error { return errors.New("refused") })
+	rep := h.Check(context.Background())
+	if rep.Status != health.StatusFail || rep.Checks["down"].Error != "refused" {
+		t.Fatalf("Check = %+v, want fail from down", rep)
+	}
+}
@@ -0,0 +1,202 @@
+// Package httpclient wraps net/http with per-request timeouts, retries on
+// server errors and typed JSON helpers.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/armaanepiic/Golang/breaker"
+	"github.com/armaanepiic/Golang/retry"
+	"github.com/armaanepiic/Golang/trace"
+)
+
+// StatusError is returned for responses with a 4xx or 5xx status code.
+type StatusError struct {
+	Method string
+	URL    string
+	Code   int
+	Body   []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("httpclient: %s %s: %d %s", e.Method, e.URL, e.Code, http.StatusText(e.Code))
+}
+
+// Response is a fully read HTTP response.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Client is an HTTP client. The zero value is not usable; call New.
+type Client struct {
+	hc        *http.Client
+	timeout   time.Duration
+	retryOpts []retry.Option
+	breaker   *breaker.Breaker
+	header    http.Header
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTimeout bounds every attempt of a request, including reading the
+// body. The default is 10 seconds.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = d }
+}
+
+// WithRoundTripper replaces the transport, e.g. with one that talks to an
+// httptest server or records requests in tests.
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(c *Client) { c.hc.Transport = rt }
+}
+
+// WithRetry sets the retry policy for 5xx responses and network errors.
+// By default a request is tried 3 times.
+func WithRetry(opts ...retry.Option) Option {
+	return func(c *Client) { c.retryOpts = opts }
+}
+
+// WithBreaker makes every attempt go through b, so a failing server trips
+// the circuit and later calls fail fast with breaker.ErrOpen.
+func WithBreaker(b *breaker.Breaker) Option {
+	return func(c *Client) { c.breaker = b }
+}
+
+// WithHeader adds a header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) { c.header.Add(key, value) }
+}
+
+// New returns a Client configured by opts.
+func New(opts ...Option) *Client {
+	c := &Client{
+		hc:      &http.Client{},
+		timeout: 10 * time.Second,
+		header:  make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Do sends a request and reads the whole response. Server errors (5xx) and
+// network errors are retried; client errors (4xx) are returned at once as
+// a *StatusError. Only server and network errors count as failures for
+// the breaker: a 4xx means the server is up and answering.
+func (c *Client) Do(ctx context.Context, method, url string, body []byte, header http.Header) (*Response, error) {
+	var resp *Response
+	err := retry.Do(ctx, func(ctx context.Context) error {
+		var clientErr error // a 4xx, kept away from the breaker
+		attempt := func() error {
+			var err error
+			resp, err = c.once(ctx, method, url, body, header)
+			var se *StatusError
+			if errors.As(err, &se) && se.Code < 500 {
+				clientErr = err
+				return nil
+			}
+			return err
+		}
+		var err error
+		if c.breaker != nil {
+			err = c.breaker.Execute(attempt)
+		} else {
+			err = attempt()
+		}
+		if clientErr != nil {
+			return retry.Permanent(clientErr)
+		}
+		if errors.Is(err, breaker.ErrOpen) {
+			return retry.Permanent(err)
+		}
+		return err
+	}, c.retryOpts...)
+	return resp, err
+}
+
+func (c *Client) once(ctx context.Context, method, url string, body []byte, header http.Header) (*Response, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var rd io.Reader
+	if body != nil {
+		rd = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, rd)
+	if err != nil {
+		return nil, retry.Permanent(err)
+	}
+	for k, vs := range c.header {
+		req.Header[k] = append(req.Header[k], vs...)
+	}
+	for k, vs := range header {
+		req.Header[k] = append(req.Header[k], vs...)
+	}
+	if id := trace.FromContext(ctx); id != "" && req.Header.Get(trace.Header) == "" {
+		req.Header.Set(trace.Header, id) // propagate the caller's request ID
+	}
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Response{StatusCode: res.StatusCode, Header: res.Header, Body: data}
+	if res.StatusCode >= 400 {
+		return resp, &StatusError{Method: method, URL: url, Code: res.StatusCode, Body: data}
+	}
+	return resp, nil
+}
+
+// GetJSON fetches url and decodes the JSON response into a T.
+func GetJSON[T any](ctx context.Context, c *Client, url string) (T, error) {
+	return doJSON[T](ctx, c, http.MethodGet, url, nil)
+}
+
+// PostJSON sends in as a JSON body to url and decodes the response into a T.
+func PostJSON[T any](ctx context.Context, c *Client, url string, in any) (T, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return doJSON[T](ctx, c, http.MethodPost, url, body)
+}
+
+func doJSON[T any](ctx context.Context, c *Client, method, url string, body []byte) (T, error) {
+	var out T
+	header := http.Header{"Accept": {"application/json"}}
+	if body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.Do(ctx, method, url, body, header)
+	if err != nil {
+		return out, err
+	}
+	if len(resp.Body) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(resp.Body, &out); err != nil {
+		return out, fmt.Errorf("httpclient: decode %s %s: %w", method, url, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/breaker"
+	"github.com/armaanepiic/Golang/retry"
+	"github.com/armaanepiic/Golang/trace"
+)
+
+// noSleep makes retries immediate.
+func noSleep(ctx context.Context, d time.Duration) error { return ctx.Err() }
+
+// statusServer answers every request with code and counts the requests.
+func statusServer(t *testing.T, code int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestRetriesServerErrors(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	c := New(WithRetry(retry.WithSleeper(noSleep)))
+	resp, err := c.Do(context.Background(), http.MethodGet, srv.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body) != "ok" || hits.Load() != 3 {
+		t.Fatalf("body %q after %d requests, want \"ok\" after 3", resp.Body, hits.Load())
+	}
+}
+
+func TestClientErrorsAreNotRetried(t *testing.T) {
+	srv, hits := statusServer(t, http.StatusNotFound)
+	c := New(WithRetry(retry.WithSleeper(noSleep)))
+
+	resp, err := c.Do(context.Background(), http.MethodGet, srv.URL, nil, nil)
+	var se *StatusError
+	if !errors.As(err, &se) || se.Code != http.StatusNotFound {
+		t.Fatalf("error = %v, want a 404 *StatusError", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("response = %+v, want the 404 response", resp)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Fatalf("%d requests, want 1", n)
+	}
+}
+
+func TestBreakerIgnoresClientErrors(t *testing.T) {
+	srv, hits := statusServer(t, http.StatusBadRequest)
+	b := breaker.New(breaker.Config{Threshold: 2})
+	c := New(WithBreaker(b), WithRetry(retry.WithSleeper(noSleep)))
+
+	for range 5 {
+		_, err := c.Do(context.Background(), http.MethodGet, srv.URL, nil, nil)
+		var se *StatusError
+		if !errors.As(err, &se) {
+			t.Fatalf("error = %v, want a *StatusError", err)
+		}
+	}
+	if s := b.State(); s != breaker.Closed {
+		t.Fatalf("breaker is %v after five 400s, want closed", s)
+	}
+	if n := hits.Load(); n != 5 {
+		t.Fatalf("%d requests, want 5", n)
+	}
+}
+
+func TestBreakerTripsOnServerErrors(t *testing.T) {
+	srv, hits := statusServer(t, http.StatusInternalServerError)
+	b := breaker.New(breaker.Config{Threshold: 2, CoolDown: time.Hour})
+	c := New(WithBreaker(b), WithRetry(retry.WithMaxAttempts(1)))
+
+	for range 2 {
+		c.Do(context.Background(), http.MethodGet, srv.URL, nil, nil)
+	}
+	if s := b.State(); s != breaker.Open {
+		t.Fatalf("breaker is %v after two 500s, want open", s)
+	}
+	if _, err := c.Do(context.Background(), http.MethodGet, srv.URL, nil, nil); !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("error = %v, want breaker.ErrOpen", err)
+	}
+	if n := hits.Load(); n != 2 {
+		t.Fatalf("%d requests, want 2: the open circuit must not reach the server", n)
+	}
+}
+
+func TestBreakerTripsOnNetworkErrors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close() // nothing listens any more
+
+	b := breaker.New(breaker.Config{Threshold: 2, CoolDown: time.Hour})
+	c := New(WithBreaker(b), WithRetry(retry.WithMaxAttempts(1)))
+	for range 2 {
+		if _, err := c.Do(context.Background(), http.MethodGet, url, nil, nil); err == nil {
+			t.Fatal("request to a closed server succeeded")
+		}
+	}
+	if s := b.State(); s != breaker.Open {
+		t.Fatalf("breaker is %v after two refused connections, want open", s)
+	}
+}
+
+func TestJSONHelpers(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Test"); got != "yes" {
+			t.Errorf("X-Test header = %q", got)
+		}
+		if got := r.Header.Get(trace.Header); got != "req-1" {
+			t.Errorf("%s header = %q, want the context's ID", trace.Header, got)
+		}
+		if r.Method == http.MethodPost {
+			if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"name":"ada"}`))
+	}))
+	defer srv.Close()
+
+	ctx := trace.NewContext(context.Background(), "req-1")
+	c := New(WithHeader("X-Test", "yes"))
+	u, err := GetJSON[user](ctx, c, srv.URL)
+	if err != nil || u.Name != "ada" {
+		t.Fatalf("GetJSON = %+v, %v", u, err)
+	}
+	u, err = PostJSON[user](ctx, c, srv.URL, user{Name: "ada"})
+	if err != nil || u.Name != "ada" {
+		t.Fatalf("PostJSON = %+v, %v", u, err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := New(WithTimeout(20*time.Millisecond), WithRetry(retry.WithMaxAttempts(1)))
+	if _, err := c.Do(context.Background(), http.MethodGet, srv.URL, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want DeadlineExceeded", err)
+	}
+}
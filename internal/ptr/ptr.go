@@ -0,0 +1,31 @@
+// Package ptr has small helpers for pointers to values, mostly for
+// optional fields and literals that need an address.
+package ptr
+
+// To returns a pointer to a copy of v, which also works for constants
+// and other values that cannot have their address taken: ptr.To(30).
+func To[T any](v T) *T {
+	return &v
+}
+
+// Deref returns *p, or the zero value if p is nil.
+func Deref[T any](p *T) T {
+	var zero T
+	return Or(p, zero)
+}
+
+// Or returns *p, or def if p is nil.
+func Or[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// Equal reports whether a and b are both nil or point to equal values.
+func Equal[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,77 @@
+// Package slicesx has the generic slice helpers the standard slices
+// package leaves out. Like slices, functions that build a new slice never
+// modify their input.
+package slicesx
+
+// Map returns f applied to every element of s.
+func Map[S ~[]E, E, R any](s S, f func(E) R) []R {
+	out := make([]R, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// Filter returns the elements of s for which keep returns true, in order.
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	var out S
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into a single value, starting from init.
+func Reduce[S ~[]E, E, A any](s S, init A, f func(A, E) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// GroupBy buckets the elements of s by key, keeping their order within
+// each bucket.
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]S {
+	groups := make(map[K]S)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Uniq returns s without repeated elements, keeping the first of each.
+func Uniq[S ~[]E, E comparable](s S) S {
+	seen := make(map[E]struct{}, len(s))
+	var out S
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Cap returns the capacity append grows a slice of capacity oldCap to
+// when it needs room for newLen elements, before the allocator rounds it
+// up to a size class. It mirrors the runtime's rule, so the growth notes
+// in the slice topic can be checked in numbers: double below 256
+// elements, then grow by about 25% plus 192 each step.
+func Cap(oldCap, newLen int) int {
+	if newLen > 2*oldCap {
+		return newLen
+	}
+	const threshold = 256
+	if oldCap < threshold {
+		return 2 * oldCap
+	}
+	c := oldCap
+	for c < newLen {
+		c += (c + 3*threshold) >> 2
+	}
+	return c
+}
@@ -0,0 +1,87 @@
+package slicesx_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/armaanepiic/Golang/internal/slicesx"
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/prop"
+)
+
+var (
+	ints      = prop.SliceOf(prop.Int())
+	smallInts = prop.SliceOf(prop.IntRange(0, 9)) // plenty of duplicates
+)
+
+func TestMapKeepsLengthAndOrder(t *testing.T) {
+	prop.ForAll(t, ints, func(s []int) bool {
+		doubled := slicesx.Map(s, func(v int) int { return 2 * v })
+		for i := range s {
+			if doubled[i] != 2*s[i] {
+				return false
+			}
+		}
+		return len(doubled) == len(s)
+	})
+}
+
+func TestFilterSplitsInTwo(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	odd := func(v int) bool { return !even(v) }
+	prop.ForAll(t, ints, func(s []int) bool {
+		evens, odds := slicesx.Filter(slices.Clone(s), even), slicesx.Filter(slices.Clone(s), odd)
+		return !slices.ContainsFunc(evens, odd) && !slices.ContainsFunc(odds, even) &&
+			len(evens)+len(odds) == len(s)
+	})
+}
+
+func TestReduceSumsLikeALoop(t *testing.T) {
+	prop.ForAll(t, ints, func(s []int) bool {
+		sum := 0
+		for _, v := range s {
+			sum += v
+		}
+		return slicesx.Reduce(s, 0, func(a, v int) int { return a + v }) == sum
+	})
+}
+
+func TestUniq(t *testing.T) {
+	// each value once, in first-seen order, none lost
+	prop.ForAll(t, smallInts, func(s []int) bool {
+		u := slicesx.Uniq(slices.Clone(s))
+		for i, v := range u {
+			if slices.Index(u, v) != i {
+				return false
+			}
+			if i > 0 && slices.Index(s, u[i-1]) > slices.Index(s, v) {
+				return false
+			}
+		}
+		return !slices.ContainsFunc(s, func(v int) bool { return !slices.Contains(u, v) })
+	})
+}
+
+func TestGroupByPartitions(t *testing.T) {
+	prop.ForAll(t, prop.SliceOf(prop.User()), func(us []userstore.User) bool {
+		groups := slicesx.GroupBy(us, func(u userstore.User) int { return u.Age })
+		n := 0
+		for age, g := range groups {
+			if slices.ContainsFunc(g, func(u userstore.User) bool { return u.Age != age }) {
+				return false
+			}
+			n += len(g)
+		}
+		return n == len(us)
+	})
+}
+
+func TestCapBoundsAppendGrowth(t *testing.T) {
+	gen := prop.PairOf(prop.IntRange(0, 5000), prop.IntRange(1, 2000))
+	prop.ForAll(t, gen, func(p prop.Pair[int, int]) bool {
+		old, extra := p.First, p.Second
+		s := append(make([]int, old), make([]int, extra)...)
+		want := slicesx.Cap(old, old+extra)
+		return want >= old+extra && cap(s) >= want
+	})
+}
@@ -0,0 +1,196 @@
+// Package userstore is a small in-memory user repository with JSON file
+// persistence, shared by the examples that need some data to work on.
+package userstore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/armaanepiic/Golang/cryptox"
+)
+
+// ErrNotFound is returned when no user has the requested ID.
+var ErrNotFound = errors.New("userstore: user not found")
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+// Repository is the set of user operations the APIs in this repo depend
+// on. *Store implements it.
+type Repository interface {
+	Create(u User) User
+	Get(id int) (User, error)
+	List() []User
+	Update(u User) error
+	Delete(id int) error
+}
+
+var _ Repository = (*Store)(nil)
+
+// Store keeps users in memory. It is safe for concurrent use.
+type Store struct {
+	mu     sync.RWMutex
+	users  map[int]User
+	nextID int
+}
+
+// New returns an empty store.
+func New() *Store {
+	return &Store{users: make(map[int]User), nextID: 1}
+}
+
+// Create assigns u a fresh ID, stores it and returns the stored copy.
+func (s *Store) Create(u User) User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u.ID = s.nextID
+	s.nextID++
+	s.users[u.ID] = u
+	return u
+}
+
+// Get returns the user with the given ID.
+func (s *Store) Get(id int) (User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return User{}, ErrNotFound
+	}
+	return u, nil
+}
+
+// List returns all users ordered by ID.
+func (s *Store) List() []User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make([]User, 0, len(s.users))
+	for _, u := range s.users {
+		users = append(users, u)
+	}
+	slices.SortFunc(users, func(a, b User) int { return a.ID - b.ID })
+	return users
+}
+
+// Update replaces the stored user that has u.ID.
+func (s *Store) Update(u User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[u.ID]; !ok {
+		return ErrNotFound
+	}
+	s.users[u.ID] = u
+	return nil
+}
+
+// Delete removes the user with the given ID.
+func (s *Store) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.users, id)
+	return nil
+}
+
+// Len returns the number of stored users.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.users)
+}
+
+// Save writes all users to path as JSON. The file is written to a
+// temporary name first and renamed, so a crash never leaves half a file.
+func (s *Store) Save(path string) error {
+	data, err := json.MarshalIndent(s.List(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// SaveEncrypted is like Save but encrypts the file with passphrase
+// (AES-GCM, see cryptox), so it is unreadable at rest.
+func (s *Store) SaveEncrypted(path, passphrase string) error {
+	data, err := json.Marshal(s.List())
+	if err != nil {
+		return err
+	}
+	data, err = cryptox.Encrypt(data, passphrase)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load replaces the contents of the store with the users saved at path.
+func (s *Store) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return s.load(data)
+}
+
+// LoadEncrypted loads a file written by SaveEncrypted. A wrong passphrase
+// or a modified file gives cryptox.ErrDecrypt.
+func (s *Store) LoadEncrypted(path, passphrase string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, err = cryptox.Decrypt(data, passphrase)
+	if err != nil {
+		return err
+	}
+	return s.load(data)
+}
+
+func (s *Store) load(data []byte) error {
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users = make(map[int]User, len(users))
+	s.nextID = 1
+	for _, u := range users {
+		s.users[u.ID] = u
+		s.nextID = max(s.nextID, u.ID+1)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package jsonstream decodes the elements of a JSON array one at a time,
+// so arrays far larger than memory can be processed:
+//
+//	err := jsonstream.Decode(f, func(u userstore.User) error {
+//		total += u.Age
+//		return nil
+//	})
+//
+// Only one element is held at a time, against json.Unmarshal which needs
+// the whole document and the whole resulting slice.
+package jsonstream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotArray is returned when the input does not start with '['.
+var ErrNotArray = errors.New("jsonstream: input is not a JSON array")
+
+// ElementError reports a malformed element or an error returned by the
+// callback, with the element's index and byte offset in the input.
+type ElementError struct {
+	Index  int
+	Offset int64
+	Err    error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("jsonstream: element %d (offset %d): %v", e.Index, e.Offset, e.Err)
+}
+
+func (e *ElementError) Unwrap() error { return e.Err }
+
+// Decode reads a JSON array from r and calls fn with each element
+// decoded into a T, in order. It stops at the first decoding error or
+// the first error returned by fn, wrapped in an *ElementError. Anything
+// after the closing ']' other than whitespace is an error.
+//
+// T is inferred from fn, so decoding users needs no type argument:
+// Decode(r, func(u userstore.User) error { ... }) is the user-specific
+// form, and any other element type works the same way.
+func Decode[T any](r io.Reader, fn func(T) error) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			return ErrNotArray
+		}
+		return fmt.Errorf("jsonstream: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return ErrNotArray
+	}
+
+	for i := 0; dec.More(); i++ {
+		off := dec.InputOffset()
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return &ElementError{Index: i, Offset: off, Err: err}
+		}
+		if err := fn(v); err != nil {
+			return &ElementError{Index: i, Offset: off, Err: err}
+		}
+	}
+
+	if _, err := dec.Token(); err != nil { // the closing ]
+		return fmt.Errorf("jsonstream: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("jsonstream: data after the closing ]")
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package jsonstream_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/faker"
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/jsonstream"
+)
+
+// fixtureUsers is the size of the faker fixture.
+const fixtureUsers = 100_000
+
+// fixture writes n fake users to a JSON file in a temp dir.
+func fixture(tb testing.TB, n int) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "users.json")
+	f, err := os.Create(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	w := bufio.NewWriter(f)
+	if err := faker.New(1).WriteUsersJSON(w, n); err != nil {
+		tb.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		tb.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+// summary is what both decoders compute, so their results can be
+// compared.
+type summary struct {
+	count, ageSum, lastID int
+}
+
+func (s *summary) add(u userstore.User) {
+	s.count++
+	s.ageSum += u.Age
+	s.lastID = u.ID
+}
+
+func stream(path string) (summary, error) {
+	var s summary
+	f, err := os.Open(path)
+	if err != nil {
+		return s, err
+	}
+	defer f.Close()
+	err = jsonstream.Decode(bufio.NewReader(f), func(u userstore.User) error {
+		s.add(u)
+		return nil
+	})
+	return s, err
+}
+
+func unmarshal(path string) (summary, error) {
+	var s summary
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	var users []userstore.User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return s, err
+	}
+	for _, u := range users {
+		s.add(u)
+	}
+	return s, nil
+}
+
+func TestFakerFixture(t *testing.T) {
+	if testing.Short() {
+		t.Skip("writes a 100k-user fixture")
+	}
+	path := fixture(t, fixtureUsers)
+	got, err := stream(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := unmarshal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.count != fixtureUsers || got.lastID != fixtureUsers || got != want {
+		t.Fatalf("jsonstream saw %+v, json.Unmarshal %+v; want %d users", got, want, fixtureUsers)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var got []int
+	err := jsonstream.Decode(strings.NewReader(" [1, 2,\n3] \n"), func(n int) error {
+		got = append(got, n)
+		return nil
+	})
+	if err != nil || fmt.Sprint(got) != "[1 2 3]" {
+		t.Fatalf("Decode = %v, %v", got, err)
+	}
+
+	calls := 0
+	if err := jsonstream.Decode(strings.NewReader("[]"), func(int) error { calls++; return nil }); err != nil || calls != 0 {
+		t.Fatalf("empty array: %d calls, %v", calls, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	noop := func(userstore.User) error { return nil }
+	for _, in := range []string{"", "{}", `"users"`, "42"} {
+		if err := jsonstream.Decode(strings.NewReader(in), noop); !errors.Is(err, jsonstream.ErrNotArray) {
+			t.Errorf("Decode(%q) = %v, want ErrNotArray", in, err)
+		}
+	}
+
+	// the second element has a string age
+	in := `[{"id":1,"age":30}, {"id":2,"age":"old"}]`
+	err := jsonstream.Decode(strings.NewReader(in), noop)
+	var ee *jsonstream.ElementError
+	// the offset points between the end of the first element and the
+	// start of the second
+	second := int64(strings.Index(in, `{"id":2`))
+	if !errors.As(err, &ee) || ee.Index != 1 || ee.Offset < second-2 || ee.Offset > second {
+		t.Fatalf("malformed element: %#v", err)
+	}
+
+	stop := errors.New("stop")
+	err = jsonstream.Decode(strings.NewReader(`[{"id":1},{"id":2},{"id":3}]`), func(u userstore.User) error {
+		if u.ID == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || !errors.As(err, &ee) || ee.Index != 1 {
+		t.Fatalf("callback error: %v", err)
+	}
+
+	for _, in := range []string{`[1, 2`, `[1 2]`, `[1] [2]`, `[1] x`} {
+		if err := jsonstream.Decode(strings.NewReader(in), func(int) error { return nil }); err == nil {
+			t.Errorf("Decode(%q) succeeded", in)
+		}
+	}
+}
+
+// BenchmarkDecode compares streaming with reading the whole file and
+// calling json.Unmarshal. B/op shows the difference in memory.
+func BenchmarkDecode(b *testing.B) {
+	path := fixture(b, fixtureUsers)
+	for _, bc := range []struct {
+		name string
+		run  func(string) (summary, error)
+	}{
+		{"jsonstream", stream},
+		{"Unmarshal", unmarshal},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := bc.run(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func ExampleDecode() {
+	in := `[{"id":1,"name":"Ada","age":36}, {"id":2,"name":"Linus","age":54}]`
+	total := 0
+	err := jsonstream.Decode(strings.NewReader(in), func(u userstore.User) error {
+		total += u.Age
+		return nil
+	})
+	fmt.Println(total, err)
+	// Output: 90 <nil>
+}
@@ -0,0 +1,136 @@
+package kv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client is a connection to a kv server. It is safe for concurrent use;
+// requests are serialised over the single connection.
+type Client struct {
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// Dial connects to a kv server.
+func Dial(addr string) (*Client, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, r: bufio.NewReader(conn)}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Do sends a raw request line and returns the raw reply line.
+func (c *Client) Do(request string) (string, error) {
+	if strings.ContainsAny(request, "\r\n") {
+		return "", errors.New("kv: request must be a single line")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.conn.Write([]byte(request + "\n")); err != nil {
+		return "", err
+	}
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\n")
+	if msg, ok := strings.CutPrefix(line, "-"); ok {
+		return "", errors.New("kv: " + msg)
+	}
+	return line, nil
+}
+
+func checkKey(key string) error {
+	if key == "" || strings.ContainsAny(key, " \t\r\n") {
+		return fmt.Errorf("kv: invalid key %q", key)
+	}
+	return nil
+}
+
+func (c *Client) integer(request string) (int, error) {
+	reply, err := c.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	s, ok := strings.CutPrefix(reply, ":")
+	if !ok {
+		return 0, fmt.Errorf("kv: unexpected reply %q", reply)
+	}
+	return strconv.Atoi(s)
+}
+
+// Ping checks the connection.
+func (c *Client) Ping() error {
+	_, err := c.Do("PING")
+	return err
+}
+
+// Get returns the value of key, or ErrNil if it does not exist.
+func (c *Client) Get(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	reply, err := c.Do("GET " + key)
+	if err != nil {
+		return "", err
+	}
+	if reply == "_" {
+		return "", ErrNil
+	}
+	v, ok := strings.CutPrefix(reply, "$")
+	if !ok {
+		return "", fmt.Errorf("kv: unexpected reply %q", reply)
+	}
+	return v, nil
+}
+
+// Set stores value under key.
+func (c *Client) Set(key, value string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	_, err := c.Do("SET " + key + " " + value)
+	return err
+}
+
+// Del deletes key and reports whether it existed.
+func (c *Client) Del(key string) (bool, error) {
+	if err := checkKey(key); err != nil {
+		return false, err
+	}
+	n, err := c.integer("DEL " + key)
+	return n == 1, err
+}
+
+// Expire sets a time to live on key, rounded down to whole seconds, and
+// reports whether key exists.
+func (c *Client) Expire(key string, ttl time.Duration) (bool, error) {
+	if err := checkKey(key); err != nil {
+		return false, err
+	}
+	n, err := c.integer(fmt.Sprintf("EXPIRE %s %d", key, int(ttl/time.Second)))
+	return n == 1, err
+}
+
+// TTL returns the seconds left on key: -1 if it has no expiry and -2 if
+// it does not exist.
+func (c *Client) TTL(key string) (int, error) {
+	if err := checkKey(key); err != nil {
+		return 0, err
+	}
+	return c.integer("TTL " + key)
+}
@@ -0,0 +1,106 @@
+package kv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The protocol is line based. A request is one line:
+//
+//	PING
+//	GET key
+//	SET key value...      (the value is the rest of the line)
+//	DEL key
+//	EXPIRE key seconds
+//	TTL key
+//
+// A reply is one line whose first byte gives its type:
+//
+//	+OK          status
+//	$value       string
+//	:42          integer
+//	_            nil (missing key)
+//	-ERR msg     error
+//
+// Keys cannot contain whitespace and values cannot contain newlines.
+
+// ErrNil is returned by the client when a key does not exist.
+var ErrNil = errors.New("kv: nil")
+
+// handle executes one request line against store and returns the reply.
+func handle(store *Store, line string) string {
+	cmd, rest, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+	switch strings.ToUpper(cmd) {
+	case "PING":
+		return "+PONG"
+	case "GET":
+		key, ok := oneArg(rest)
+		if !ok {
+			return errReply("usage: GET key")
+		}
+		v, found := store.Get(key)
+		if !found {
+			return "_"
+		}
+		return "$" + v
+	case "SET":
+		key, value, ok := strings.Cut(rest, " ")
+		if !ok || key == "" {
+			return errReply("usage: SET key value")
+		}
+		store.Set(key, value)
+		return "+OK"
+	case "DEL":
+		key, ok := oneArg(rest)
+		if !ok {
+			return errReply("usage: DEL key")
+		}
+		return intReply(store.Del(key))
+	case "EXPIRE":
+		f := strings.Fields(rest)
+		if len(f) != 2 {
+			return errReply("usage: EXPIRE key seconds")
+		}
+		secs, err := strconv.Atoi(f[1])
+		if err != nil || secs < 0 {
+			return errReply("seconds must be a non-negative integer")
+		}
+		return intReply(store.Expire(f[0], time.Duration(secs)*time.Second))
+	case "TTL":
+		key, ok := oneArg(rest)
+		if !ok {
+			return errReply("usage: TTL key")
+		}
+		ttl, found := store.TTL(key)
+		switch {
+		case !found:
+			return ":-2"
+		case ttl == 0:
+			return ":-1"
+		}
+		return ":" + strconv.Itoa(int((ttl+time.Second-1)/time.Second))
+	}
+	return errReply(fmt.Sprintf("unknown command %q", cmd))
+}
+
+func oneArg(s string) (string, bool) {
+	f := strings.Fields(s)
+	if len(f) != 1 {
+		return "", false
+	}
+	return f[0], true
+}
+
+func intReply(b bool) string {
+	if b {
+		return ":1"
+	}
+	return ":0"
+}
+
+func errReply(msg string) string {
+	return "-ERR " + msg
+}
@@ -0,0 +1,194 @@
+package kv
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// serve starts a server for store on a random localhost port and returns
+// its address and a connected client. Both are closed when the test ends.
+func serve(t *testing.T, store *Store) (string, *Client) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewServer(store)
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ln) }()
+
+	c, err := Dial(ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		c.Close()
+		srv.Close()
+		if err := <-done; err != nil {
+			t.Errorf("Serve = %v", err)
+		}
+	})
+	return ln.Addr().String(), c
+}
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(4)
+	store.now = func() time.Time { return now }
+	_, c := serve(t, store)
+
+	if err := c.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get("missing"); !errors.Is(err, ErrNil) {
+		t.Fatalf("Get(missing) error = %v, want ErrNil", err)
+	}
+	if err := c.Set("greeting", "hello, world"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Get("greeting"); err != nil || v != "hello, world" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+	if n, err := c.TTL("greeting"); err != nil || n != -1 {
+		t.Fatalf("TTL without expiry = %d, %v; want -1", n, err)
+	}
+
+	if ok, err := c.Expire("greeting", 10*time.Second); err != nil || !ok {
+		t.Fatalf("Expire = %v, %v", ok, err)
+	}
+	now = now.Add(2500 * time.Millisecond)
+	if n, err := c.TTL("greeting"); err != nil || n != 8 {
+		t.Fatalf("TTL = %d, %v; want 8 (rounded up)", n, err)
+	}
+	now = now.Add(7500 * time.Millisecond)
+	if _, err := c.Get("greeting"); !errors.Is(err, ErrNil) {
+		t.Fatalf("Get after expiry error = %v, want ErrNil", err)
+	}
+	if n, err := c.TTL("greeting"); err != nil || n != -2 {
+		t.Fatalf("TTL of expired key = %d, %v; want -2", n, err)
+	}
+	if ok, err := c.Expire("greeting", time.Second); err != nil || ok {
+		t.Fatalf("Expire of expired key = %v, %v", ok, err)
+	}
+
+	c.Set("k", "v")
+	if ok, err := c.Del("k"); err != nil || !ok {
+		t.Fatalf("Del = %v, %v", ok, err)
+	}
+	if ok, err := c.Del("k"); err != nil || ok {
+		t.Fatalf("second Del = %v, %v", ok, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	_, c := serve(t, NewStore(0))
+
+	for _, req := range []string{"FLUSHALL", "GET", "GET a b", "SET a", "EXPIRE a soon", "EXPIRE a -1", "TTL"} {
+		if _, err := c.Do(req); err == nil {
+			t.Errorf("Do(%q) succeeded, want an error reply", req)
+		}
+	}
+	if _, err := c.Do("PING\nPING"); err == nil {
+		t.Error("Do with a newline succeeded")
+	}
+	if err := c.Set("bad key", "v"); err == nil {
+		t.Error("Set with a space in the key succeeded")
+	}
+	// the connection is still usable after error replies
+	if err := c.Ping(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentClients(t *testing.T) {
+	store := NewStore(0)
+	addr, c := serve(t, store)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cc, err := Dial(addr)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer cc.Close()
+			for j := range 50 {
+				key := fmt.Sprintf("c%d-%d", i, j)
+				if err := cc.Set(key, key); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	// the shared client is safe for concurrent use too
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				if err := c.Ping(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := store.Len(); n != 8*50 {
+		t.Fatalf("store has %d keys, want %d", n, 8*50)
+	}
+}
+
+// TestCloseWhileDialing closes the server while clients keep connecting;
+// a connection accepted just as Close runs must not outlive it.
+func TestCloseWhileDialing(t *testing.T) {
+	for range 20 {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv := NewServer(NewStore(0))
+		done := make(chan error, 1)
+		go func() { done <- srv.Serve(ln) }()
+
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					if c, err := Dial(ln.Addr().String()); err == nil {
+						c.Close()
+					}
+				}
+			}()
+		}
+		time.Sleep(5 * time.Millisecond)
+		srv.Close()
+		close(stop)
+		wg.Wait()
+		if err := <-done; err != nil {
+			t.Fatalf("Serve = %v", err)
+		}
+		srv.mu.Lock()
+		n := len(srv.conns)
+		srv.mu.Unlock()
+		if n != 0 {
+			t.Fatalf("%d connections still tracked after Close", n)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package kv is a small Redis-like key-value store: a sharded in-memory map
+// with expiring keys, a line based TCP protocol, a server and a client.
+package kv
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+type item struct {
+	value   string
+	expires time.Time // zero = never
+}
+
+func (it item) expired(now time.Time) bool {
+	return !it.expires.IsZero() && !now.Before(it.expires)
+}
+
+type shard struct {
+	mu    sync.RWMutex
+	items map[string]item
+}
+
+// Store is a concurrent map split into shards, each with its own lock, so
+// writers to different keys rarely contend.
+type Store struct {
+	shards []*shard
+	now    func() time.Time
+}
+
+// NewStore returns a store with n shards (n <= 0 means 16).
+func NewStore(n int) *Store {
+	if n <= 0 {
+		n = 16
+	}
+	s := &Store{shards: make([]*shard, n), now: time.Now}
+	for i := range s.shards {
+		s.shards[i] = &shard{items: make(map[string]item)}
+	}
+	return s
+}
+
+func (s *Store) shard(key string) *shard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// Get returns the value of key. Expired keys are reported as missing.
+func (s *Store) Get(key string) (string, bool) {
+	sh := s.shard(key)
+	sh.mu.RLock()
+	it, ok := sh.items[key]
+	sh.mu.RUnlock()
+	if !ok || it.expired(s.now()) {
+		return "", false
+	}
+	return it.value, true
+}
+
+// Set stores value under key and clears any expiry.
+func (s *Store) Set(key, value string) {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	sh.items[key] = item{value: value}
+	sh.mu.Unlock()
+}
+
+// Del removes key and reports whether it existed.
+func (s *Store) Del(key string) bool {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	it, ok := sh.items[key]
+	delete(sh.items, key)
+	return ok && !it.expired(s.now())
+}
+
+// Expire makes key disappear after ttl and reports whether key exists.
+func (s *Store) Expire(key string, ttl time.Duration) bool {
+	sh := s.shard(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	now := s.now()
+	it, ok := sh.items[key]
+	if !ok || it.expired(now) {
+		return false
+	}
+	it.expires = now.Add(ttl)
+	sh.items[key] = it
+	return true
+}
+
+// TTL returns the time left before key expires. ok is false if the key
+// does not exist; a zero duration with ok true means no expiry.
+func (s *Store) TTL(key string) (ttl time.Duration, ok bool) {
+	sh := s.shard(key)
+	sh.mu.RLock()
+	it, found := sh.items[key]
+	sh.mu.RUnlock()
+	now := s.now()
+	if !found || it.expired(now) {
+		return 0, false
+	}
+	if it.expires.IsZero() {
+		return 0, true
+	}
+	return it.expires.Sub(now), true
+}
+
+// Len returns the number of stored keys, including expired keys that
+// have not been swept yet.
+func (s *Store) Len() int {
+	n := 0
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		n += len(sh.items)
+		sh.mu.RUnlock()
+	}
+	return n
+}
+
+// Sweep deletes every expired key and returns how many it removed.
+func (s *Store) Sweep() int {
+	now := s.now()
+	removed := 0
+	for _, sh := range s.shards {
+		sh.mu.Lock()
+		for k, it := range sh.items {
+			if it.expired(now) {
+				delete(sh.items, k)
+				removed++
+			}
+		}
+		sh.mu.Unlock()
+	}
+	return removed
+}
+
+// RunSweeper calls Sweep every interval until ctx is done. Reads already
+// hide expired keys; sweeping just frees their memory.
+func (s *Store) RunSweeper(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.Sweep()
+		}
+	}
+}
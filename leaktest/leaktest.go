@@ -0,0 +1,158 @@
+// Package leaktest finds goroutines that outlive the code that started
+// them. Snapshot the goroutines at the start of a test and check at the
+// end that no new ones are left:
+//
+//	func TestBroker(t *testing.T) {
+//		defer leaktest.Check(t)()
+//		...
+//	}
+//
+// Goroutines often need a moment to notice they should stop, so the check
+// retries until a timeout before it reports anything.
+package leaktest
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TB is the part of testing.TB that Check needs, so it works with
+// *testing.T and *testing.B, and with any reporter outside tests.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// DefaultTimeout is how long Check waits for goroutines to finish.
+const DefaultTimeout = 5 * time.Second
+
+// defaultIgnore are goroutines the runtime and the testing package start
+// on their own.
+var defaultIgnore = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^created by testing\.`), // parallel tests and subtests
+	regexp.MustCompile(`(?m)^os/signal\.(loop|signal_recv)`),
+	regexp.MustCompile(`(?m)^runtime\.ensureSigM`),
+}
+
+type config struct {
+	timeout time.Duration
+	ignore  []*regexp.Regexp
+}
+
+// Option configures Check.
+type Option func(*config)
+
+// WithTimeout sets how long to wait for goroutines to finish before they
+// count as leaked.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) { c.timeout = d }
+}
+
+// Ignore allows goroutines whose stack matches any of the regular
+// expressions, such as `net/http\.\(\*persistConn\)` for idle HTTP
+// connections that belong to a shared client. It panics if a pattern
+// does not compile.
+func Ignore(patterns ...string) Option {
+	return func(c *config) {
+		for _, p := range patterns {
+			c.ignore = append(c.ignore, regexp.MustCompile(p))
+		}
+	}
+}
+
+// Check records the running goroutines and returns a function that
+// reports, through t.Errorf, every goroutine started since that is still
+// running once the timeout has passed.
+func Check(t TB, opts ...Option) func() {
+	t.Helper()
+	cfg := config{timeout: DefaultTimeout, ignore: slices.Clone(defaultIgnore)}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	before := map[int]bool{}
+	for _, g := range Goroutines() {
+		before[g.ID] = true
+	}
+	return func() {
+		t.Helper()
+		var leaked []Goroutine
+		deadline := time.Now().Add(cfg.timeout)
+		for wait := time.Millisecond; ; wait = min(2*wait, 100*time.Millisecond) {
+			leaked = leaked[:0]
+			for _, g := range Goroutines() {
+				if !before[g.ID] && !cfg.ignored(g) {
+					leaked = append(leaked, g)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(wait)
+		}
+		if len(leaked) == 0 {
+			return
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "leaktest: %d goroutine(s) still running after %v:", len(leaked), cfg.timeout)
+		for _, g := range leaked {
+			b.WriteString("\n\n" + g.Stack)
+		}
+		t.Errorf("%s", b.String())
+	}
+}
+
+func (c *config) ignored(g Goroutine) bool {
+	for _, re := range c.ignore {
+		if re.MatchString(g.Stack) {
+			return true
+		}
+	}
+	return false
+}
+
+// Goroutine is one goroutine in a stack dump.
+type Goroutine struct {
+	ID    int
+	State string // "running", "chan receive", "select", "IO wait", ...
+	Stack string // the goroutine's full block, header included
+}
+
+// Goroutines returns every goroutine except the calling one.
+func Goroutines() []Goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	// the first block is the caller
+	blocks := strings.Split(strings.TrimSpace(string(buf)), "\n\n")
+	var gs []Goroutine
+	for _, block := range blocks[1:] {
+		if g, ok := parse(block); ok {
+			gs = append(gs, g)
+		}
+	}
+	return gs
+}
+
+// parse reads the header "goroutine 12 [chan receive, 3 minutes]:".
+func parse(block string) (Goroutine, bool) {
+	header, _, _ := strings.Cut(block, "\n")
+	var id int
+	if _, err := fmt.Sscanf(header, "goroutine %d ", &id); err != nil {
+		return Goroutine{}, false
+	}
+	state := ""
+	if i, j := strings.IndexByte(header, '['), strings.IndexByte(header, ']'); i >= 0 && j > i {
+		state, _, _ = strings.Cut(header[i+1:j], ",")
+	}
+	return Goroutine{ID: id, State: state, Stack: block}, true
+}
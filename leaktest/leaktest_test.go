@@ -0,0 +1,78 @@
+package leaktest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recorder is a TB that keeps the reports instead of failing the test.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// leakyWorker blocks until stop is closed.
+func leakyWorker(stop chan struct{}) { <-stop }
+
+func TestReportsLeak(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	r := &recorder{}
+	check := Check(r, WithTimeout(50*time.Millisecond))
+	go leakyWorker(stop)
+	check()
+
+	if len(r.errors) != 1 {
+		t.Fatalf("got %d reports, want 1", len(r.errors))
+	}
+	if msg := r.errors[0]; !strings.Contains(msg, "1 goroutine(s) still running") || !strings.Contains(msg, "leaktest.leakyWorker") {
+		t.Fatalf("report does not name the leaked goroutine:\n%s", msg)
+	}
+}
+
+func TestWaitsForSlowExit(t *testing.T) {
+	defer Check(t)()
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(30 * time.Millisecond) // finishes after the check starts
+		close(done)
+	}()
+}
+
+func TestIgnore(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	r := &recorder{}
+	check := Check(r, WithTimeout(20*time.Millisecond), Ignore(`leaktest\.leakyWorker`))
+	go leakyWorker(stop)
+	check()
+	if len(r.errors) != 0 {
+		t.Fatalf("ignored goroutine reported:\n%s", r.errors[0])
+	}
+}
+
+func TestGoroutines(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	go leakyWorker(stop)
+	time.Sleep(time.Millisecond)
+
+	for _, g := range Goroutines() {
+		if strings.Contains(g.Stack, "leaktest.leakyWorker") {
+			if g.ID == 0 || g.State != "chan receive" {
+				t.Fatalf("parsed %+v", g)
+			}
+			return
+		}
+	}
+	t.Fatal("the worker is missing from Goroutines")
+}
@@ -0,0 +1,303 @@
+// Package lex is a configurable lexer for small languages: it turns
+// source text into identifiers, keywords, numbers, strings and
+// operators, each tagged with its position.
+//
+// A bad character or an unterminated string does not stop the lexer: it
+// records an error, emits an Illegal token and carries on, so a parser
+// can report every problem in one pass.
+package lex
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind is the category of a token.
+type Kind int
+
+const (
+	EOF Kind = iota
+	Illegal
+	Ident
+	Keyword
+	Int
+	Float
+	String
+	Op
+)
+
+func (k Kind) String() string {
+	switch k {
+	case EOF:
+		return "EOF"
+	case Illegal:
+		return "Illegal"
+	case Ident:
+		return "Ident"
+	case Keyword:
+		return "Keyword"
+	case Int:
+		return "Int"
+	case Float:
+		return "Float"
+	case String:
+		return "String"
+	case Op:
+		return "Op"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Pos is a position in the source. Line and Col start at 1; Col counts
+// runes.
+type Pos struct {
+	Offset int
+	Line   int
+	Col    int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Col)
+}
+
+// Token is one lexeme. For strings Text is the unquoted value; Raw is
+// always the exact source text.
+type Token struct {
+	Kind Kind
+	Text string
+	Raw  string
+	Pos  Pos
+}
+
+func (t Token) String() string {
+	if t.Kind == EOF {
+		return "EOF"
+	}
+	return fmt.Sprintf("%s %q", t.Kind, t.Raw)
+}
+
+// Is reports whether t is the operator or keyword s.
+func (t Token) Is(s string) bool {
+	return (t.Kind == Op || t.Kind == Keyword) && t.Text == s
+}
+
+// Error is a lexical error.
+type Error struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// Config describes the language.
+type Config struct {
+	Keywords    []string // identifiers that lex as Keyword
+	Operators   []string // matched longest first, e.g. "==" before "="
+	LineComment string   // e.g. "//" or "#"; empty = no comments
+}
+
+// Lexer produces tokens from a source string.
+type Lexer struct {
+	src    string
+	cfg    Config
+	ops    []string
+	off    int
+	line   int
+	col    int
+	errors []*Error
+}
+
+// New returns a lexer for src.
+func New(src string, cfg Config) *Lexer {
+	ops := slices.Clone(cfg.Operators)
+	slices.SortFunc(ops, func(a, b string) int { return len(b) - len(a) })
+	return &Lexer{src: src, cfg: cfg, ops: ops, line: 1, col: 1}
+}
+
+// Errors returns the errors found so far.
+func (l *Lexer) Errors() []*Error {
+	return l.errors
+}
+
+// All lexes the whole input. The last token is EOF.
+func (l *Lexer) All() []Token {
+	var toks []Token
+	for {
+		t := l.Next()
+		toks = append(toks, t)
+		if t.Kind == EOF {
+			return toks
+		}
+	}
+}
+
+// Tokenize is a shortcut for New(src, cfg).All() that also returns the
+// first error, if any.
+func Tokenize(src string, cfg Config) ([]Token, error) {
+	l := New(src, cfg)
+	toks := l.All()
+	if len(l.errors) > 0 {
+		return toks, l.errors[0]
+	}
+	return toks, nil
+}
+
+func (l *Lexer) pos() Pos { return Pos{Offset: l.off, Line: l.line, Col: l.col} }
+
+func (l *Lexer) peek() rune {
+	if l.off >= len(l.src) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.off:])
+	return r
+}
+
+func (l *Lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.src[l.off:])
+	l.off += size
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *Lexer) errorf(p Pos, format string, args ...any) {
+	l.errors = append(l.errors, &Error{Pos: p, Msg: fmt.Sprintf(format, args...)})
+}
+
+// Next returns the next token. After the end of input it keeps
+// returning EOF.
+func (l *Lexer) Next() Token {
+	l.skipSpaceAndComments()
+	start := l.pos()
+	tok := func(k Kind, text string) Token {
+		return Token{Kind: k, Text: text, Raw: l.src[start.Offset:l.off], Pos: start}
+	}
+
+	r := l.peek()
+	switch {
+	case r == -1:
+		return Token{Kind: EOF, Pos: start}
+	case isLetter(r):
+		for isLetter(l.peek()) || unicode.IsDigit(l.peek()) {
+			l.advance()
+		}
+		word := l.src[start.Offset:l.off]
+		if slices.Contains(l.cfg.Keywords, word) {
+			return tok(Keyword, word)
+		}
+		return tok(Ident, word)
+	case isDigit(r) || r == '.' && l.off+1 < len(l.src) && isDigit(rune(l.src[l.off+1])):
+		k := l.number()
+		return tok(k, l.src[start.Offset:l.off])
+	case r == '"':
+		return l.str(start)
+	}
+	for _, op := range l.ops {
+		if strings.HasPrefix(l.src[l.off:], op) {
+			for range utf8.RuneCountInString(op) {
+				l.advance()
+			}
+			return tok(Op, op)
+		}
+	}
+	l.advance()
+	l.errorf(start, "unexpected character %q", r)
+	return tok(Illegal, l.src[start.Offset:l.off])
+}
+
+func (l *Lexer) skipSpaceAndComments() {
+	for {
+		r := l.peek()
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			l.advance()
+		case l.cfg.LineComment != "" && strings.HasPrefix(l.src[l.off:], l.cfg.LineComment):
+			for l.peek() != '\n' && l.peek() != -1 {
+				l.advance()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// number lexes 12, 1.5, .5, 1e3 and 2.5E-4.
+func (l *Lexer) number() Kind {
+	k := Int
+	for isDigit(l.peek()) {
+		l.advance()
+	}
+	if l.peek() == '.' {
+		k = Float
+		l.advance()
+		for isDigit(l.peek()) {
+			l.advance()
+		}
+	}
+	if r := l.peek(); r == 'e' || r == 'E' {
+		rest := l.src[l.off+1:]
+		if len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+			rest = rest[1:]
+		}
+		if len(rest) > 0 && isDigit(rune(rest[0])) {
+			k = Float
+			l.advance()
+			if r := l.peek(); r == '+' || r == '-' {
+				l.advance()
+			}
+			for isDigit(l.peek()) {
+				l.advance()
+			}
+		}
+	}
+	return k
+}
+
+// str lexes a double-quoted string with \n \t \" \\ escapes. An
+// unterminated string ends at the end of the line and is Illegal.
+func (l *Lexer) str(start Pos) Token {
+	l.advance() // opening quote
+	var b strings.Builder
+	for {
+		r := l.peek()
+		switch r {
+		case '"':
+			l.advance()
+			return Token{Kind: String, Text: b.String(), Raw: l.src[start.Offset:l.off], Pos: start}
+		case '\n', -1:
+			l.errorf(start, "unterminated string")
+			return Token{Kind: Illegal, Text: b.String(), Raw: l.src[start.Offset:l.off], Pos: start}
+		case '\\':
+			esc := l.pos()
+			l.advance()
+			switch e := l.peek(); e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\':
+				b.WriteRune(e)
+			case '\n', -1:
+				continue // reported as unterminated above
+			default:
+				l.errorf(esc, "unknown escape \\%c", e)
+				b.WriteRune(e)
+			}
+			l.advance()
+		default:
+			b.WriteRune(l.advance())
+		}
+	}
+}
+
+func isDigit(r rune) bool  { return r >= '0' && r <= '9' }
+func isLetter(r rune) bool { return r == '_' || unicode.IsLetter(r) }
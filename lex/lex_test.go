@@ -0,0 +1,126 @@
+package lex
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var testConfig = Config{
+	Keywords:    []string{"if", "else", "let"},
+	Operators:   []string{"=", "==", "!=", "<", "<=", "(", ")", "{", "}", "+", "-"},
+	LineComment: "//",
+}
+
+// kinds renders tokens as "Kind Raw" lines, with Text added when it
+// differs from Raw.
+func kinds(toks []Token) string {
+	var b strings.Builder
+	for _, t := range toks {
+		fmt.Fprintf(&b, "%s %s", t.Kind, t.Raw)
+		if t.Text != t.Raw && t.Kind != EOF {
+			fmt.Fprintf(&b, " (%q)", t.Text)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func TestTokenKinds(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"", "EOF \n"},
+		{"  \t\n ", "EOF \n"},
+		{"x _tmp héllo x2", "Ident x\nIdent _tmp\nIdent héllo\nIdent x2\nEOF \n"},
+		{"if else let iffy", "Keyword if\nKeyword else\nKeyword let\nIdent iffy\nEOF \n"},
+		{"0 42 007", "Int 0\nInt 42\nInt 007\nEOF \n"},
+		{"1.5 .5 1. 1e3 2.5E-4 3e+2", "Float 1.5\nFloat .5\nFloat 1.\nFloat 1e3\nFloat 2.5E-4\nFloat 3e+2\nEOF \n"},
+		// an exponent needs digits, otherwise e starts an identifier
+		{"1e 2ex", "Int 1\nIdent e\nInt 2\nIdent ex\nEOF \n"},
+		{`"" "hi" "a\"b\\c\n\t"`, "String \"\" (\"\")\nString \"hi\" (\"hi\")\nString \"a\\\"b\\\\c\\n\\t\" (\"a\\\"b\\\\c\\n\\t\")\nEOF \n"},
+		// longest operator first
+		{"a<=b==c!=d<e=f", "Ident a\nOp <=\nIdent b\nOp ==\nIdent c\nOp !=\nIdent d\nOp <\nIdent e\nOp =\nIdent f\nEOF \n"},
+		{"{(-1)+2}", "Op {\nOp (\nOp -\nInt 1\nOp )\nOp +\nInt 2\nOp }\nEOF \n"},
+		{"a // comment = 1\nb//x", "Ident a\nIdent b\nEOF \n"},
+	}
+	for _, tt := range tests {
+		toks, err := Tokenize(tt.src, testConfig)
+		if err != nil {
+			t.Errorf("Tokenize(%q): %v", tt.src, err)
+			continue
+		}
+		if got := kinds(toks); got != tt.want {
+			t.Errorf("Tokenize(%q):\n%swant:\n%s", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestPositions(t *testing.T) {
+	toks := New("let x =\n  \"é\" + y", testConfig).All()
+	want := []string{"1:1", "1:5", "1:7", "2:3", "2:7", "2:9", "2:10"}
+	if len(toks) != len(want) {
+		t.Fatalf("got %d tokens, want %d", len(toks), len(want))
+	}
+	for i, tok := range toks {
+		if got := tok.Pos.String(); got != want[i] {
+			t.Errorf("token %d (%v) at %s, want %s", i, tok, got, want[i])
+		}
+	}
+	// columns count runes, offsets count bytes
+	if y := toks[5]; y.Pos.Offset != 17 {
+		t.Errorf("offset of y = %d, want 17", y.Pos.Offset)
+	}
+}
+
+func TestErrorRecovery(t *testing.T) {
+	l := New("a @ b \"open\nc \"bad\\q\" # d", testConfig)
+	toks := l.All()
+	want := "Ident a\nIllegal @\nIdent b\nIllegal \"open (\"open\")\nIdent c\nString \"bad\\q\" (\"badq\")\nIllegal #\nIdent d\nEOF \n"
+	if got := kinds(toks); got != want {
+		t.Errorf("tokens:\n%swant:\n%s", got, want)
+	}
+
+	var errs []string
+	for _, e := range l.Errors() {
+		errs = append(errs, e.Error())
+	}
+	wantErrs := []string{
+		"1:3: unexpected character '@'",
+		"1:7: unterminated string",
+		"2:7: unknown escape \\q",
+		"2:11: unexpected character '#'",
+	}
+	if strings.Join(errs, "\n") != strings.Join(wantErrs, "\n") {
+		t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(errs, "\n"), strings.Join(wantErrs, "\n"))
+	}
+
+	// Tokenize returns every token and the first error
+	toks2, err := Tokenize("a @ b", testConfig)
+	if err == nil || err.Error() != "1:3: unexpected character '@'" || len(toks2) != 4 {
+		t.Errorf("Tokenize = %d tokens, %v", len(toks2), err)
+	}
+}
+
+func TestEOFRepeats(t *testing.T) {
+	l := New("x", Config{})
+	l.Next()
+	for range 3 {
+		if tok := l.Next(); tok.Kind != EOF {
+			t.Fatalf("Next after the end = %v, want EOF", tok)
+		}
+	}
+}
+
+func TestTokenHelpers(t *testing.T) {
+	toks, _ := Tokenize("if x", testConfig)
+	if !toks[0].Is("if") || toks[1].Is("x") {
+		t.Error("Is matches only operators and keywords")
+	}
+	if s := toks[1].String(); s != `Ident "x"` {
+		t.Errorf("String() = %s", s)
+	}
+	if s := Kind(99).String(); s != "Kind(99)" {
+		t.Errorf("Kind(99).String() = %s", s)
+	}
+}
@@ -0,0 +1,125 @@
+// Package logx configures log/slog for the programs in this repo and
+// carries request scoped attributes (such as a request ID) in a context.
+package logx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Config selects the output of the default logger.
+type Config struct {
+	Level     string    // debug, info, warn or error; default info
+	Format    string    // text or json; default text
+	Output    io.Writer // default os.Stderr
+	AddSource bool      // include file:line of the call site
+}
+
+// ConfigFromEnv reads LOG_LEVEL and LOG_FORMAT.
+func ConfigFromEnv() Config {
+	return Config{
+		Level:  os.Getenv("LOG_LEVEL"),
+		Format: os.Getenv("LOG_FORMAT"),
+	}
+}
+
+// ParseLevel parses a level name, case-insensitively. "" means info.
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("logx: unknown level %q", s)
+	}
+	return l, nil
+}
+
+// New builds a logger from cfg. Records logged with a context (InfoContext
+// and friends) include the attributes stored by ContextWith.
+func New(cfg Config) (*slog.Logger, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	out := cfg.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	opts := &slog.HandlerOptions{Level: level, AddSource: cfg.AddSource}
+
+	var h slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		h = slog.NewTextHandler(out, opts)
+	case "json":
+		h = slog.NewJSONHandler(out, opts)
+	default:
+		return nil, fmt.Errorf("logx: unknown format %q", cfg.Format)
+	}
+	return slog.New(contextHandler{h}), nil
+}
+
+// Setup builds a logger from cfg and installs it as slog.Default, which
+// also redirects the standard log package.
+func Setup(cfg Config) error {
+	l, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(l)
+	return nil
+}
+
+type ctxKey struct{}
+
+// ContextWith returns a copy of ctx carrying extra log attributes, given
+// as alternating keys and values or slog.Attr like slog.Logger.With.
+func ContextWith(ctx context.Context, args ...any) context.Context {
+	prev, _ := ctx.Value(ctxKey{}).([]any)
+	all := make([]any, 0, len(prev)+len(args))
+	all = append(all, prev...)
+	all = append(all, args...)
+	return context.WithValue(ctx, ctxKey{}, all)
+}
+
+// Attrs returns the attributes stored in ctx by ContextWith.
+func Attrs(ctx context.Context) []any {
+	args, _ := ctx.Value(ctxKey{}).([]any)
+	return args
+}
+
+// With returns the default logger enriched with the attributes in ctx.
+func With(ctx context.Context) *slog.Logger {
+	l := slog.Default()
+	if args := Attrs(ctx); len(args) > 0 {
+		return l.With(args...)
+	}
+	return l
+}
+
+// contextHandler adds the attributes stored in a record's context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if args := Attrs(ctx); len(args) > 0 {
+			r.Add(args...)
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,160 @@
+// Package markdown converts a small subset of Markdown to HTML:
+// ATX headings (# .. ######), paragraphs, bullet and numbered lists,
+// fenced code blocks, horizontal rules, and inline `code`, **bold**,
+// *italic* and [links](url).
+//
+// Conversion happens in two steps: Parse splits the source into blocks,
+// and Render turns the blocks into HTML, handling inline markup as it
+// goes.
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of a block.
+type Kind int
+
+const (
+	Paragraph Kind = iota
+	Heading
+	List
+	Code
+	Rule
+)
+
+// Block is one block-level element.
+type Block struct {
+	Kind    Kind
+	Level   int      // Heading: 1..6
+	Ordered bool     // List: numbered
+	Start   int      // List: first number of an ordered list
+	Lang    string   // Code: info string after the opening fence
+	Text    string   // Paragraph, Heading, Code
+	Items   []string // List
+}
+
+// Parse splits src into blocks.
+func Parse(src string) []Block {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var blocks []Block
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, Block{Kind: Paragraph, Text: strings.Join(para, "\n")})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			fence := trimmed[:3]
+			b := Block{Kind: Code, Lang: strings.TrimSpace(trimmed[3:])}
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			b.Text = strings.Join(code, "\n") // an unclosed fence runs to the end
+			blocks = append(blocks, b)
+
+		case headingLevel(trimmed) > 0:
+			flush()
+			level := headingLevel(trimmed)
+			text := strings.TrimSpace(trimmed[level:])
+			if t := strings.TrimRight(text, "#"); t == "" || strings.HasSuffix(t, " ") {
+				text = strings.TrimSpace(t) // optional closing #s, as in "## Title ##"
+			}
+			blocks = append(blocks, Block{Kind: Heading, Level: level, Text: text})
+
+		case isRule(trimmed):
+			flush()
+			blocks = append(blocks, Block{Kind: Rule})
+
+		case listMarker(trimmed) != nil:
+			flush()
+			first := listMarker(trimmed)
+			b := Block{Kind: List, Ordered: first.ordered, Start: first.number}
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if m := listMarker(t); m != nil && m.ordered == first.ordered {
+					b.Items = append(b.Items, t[m.width:])
+				} else if t != "" && strings.HasPrefix(lines[i], " ") {
+					// indented continuation of the previous item
+					b.Items[len(b.Items)-1] += "\n" + t
+				} else {
+					break
+				}
+			}
+			i-- // the loop above stopped on a line that is not ours
+			blocks = append(blocks, b)
+
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+	return blocks
+}
+
+func headingLevel(s string) int {
+	n := 0
+	for n < len(s) && s[n] == '#' {
+		n++
+	}
+	if n == 0 || n > 6 || (n < len(s) && s[n] != ' ') {
+		return 0
+	}
+	return n
+}
+
+func isRule(s string) bool {
+	if len(s) < 3 {
+		return false
+	}
+	c := s[0]
+	if c != '-' && c != '*' && c != '_' {
+		return false
+	}
+	n := 0
+	for _, r := range s {
+		switch {
+		case byte(r) == c:
+			n++
+		case r != ' ':
+			return false
+		}
+	}
+	return n >= 3
+}
+
+type marker struct {
+	ordered bool
+	number  int
+	width   int // bytes up to the item text
+}
+
+// listMarker recognises "- ", "* ", "+ " and "1. " / "1) ".
+func listMarker(s string) *marker {
+	if len(s) >= 2 && strings.ContainsRune("-*+", rune(s[0])) && s[1] == ' ' {
+		return &marker{width: 2}
+	}
+	i := 0
+	for i < len(s) && i < 9 && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || i+1 >= len(s) || (s[i] != '.' && s[i] != ')') || s[i+1] != ' ' {
+		return nil
+	}
+	n, _ := strconv.Atoi(s[:i])
+	return &marker{ordered: true, number: n, width: i + 2}
+}
@@ -0,0 +1,162 @@
+package markdown
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ToHTML converts Markdown source to an HTML fragment.
+func ToHTML(src string) string {
+	return Render(Parse(src))
+}
+
+// Render turns blocks into HTML. All text is escaped; raw HTML in the
+// source is shown, not interpreted.
+func Render(blocks []Block) string {
+	var b strings.Builder
+	for _, bl := range blocks {
+		switch bl.Kind {
+		case Heading:
+			tag := "h" + strconv.Itoa(bl.Level)
+			b.WriteString("<" + tag + ` id="` + Slug(bl.Text) + `">`)
+			b.WriteString(inline(bl.Text))
+			b.WriteString("</" + tag + ">\n")
+		case Paragraph:
+			b.WriteString("<p>" + inline(bl.Text) + "</p>\n")
+		case Rule:
+			b.WriteString("<hr>\n")
+		case Code:
+			if bl.Lang != "" {
+				b.WriteString(`<pre><code class="language-` + html.EscapeString(strings.Fields(bl.Lang)[0]) + `">`)
+			} else {
+				b.WriteString("<pre><code>")
+			}
+			if bl.Text != "" {
+				b.WriteString(html.EscapeString(bl.Text) + "\n")
+			}
+			b.WriteString("</code></pre>\n")
+		case List:
+			open, end := "<ul>", "</ul>"
+			if bl.Ordered {
+				open, end = "<ol>", "</ol>"
+				if bl.Start != 1 {
+					open = `<ol start="` + strconv.Itoa(bl.Start) + `">`
+				}
+			}
+			b.WriteString(open + "\n")
+			for _, it := range bl.Items {
+				b.WriteString("<li>" + inline(it) + "</li>\n")
+			}
+			b.WriteString(end + "\n")
+		}
+	}
+	return b.String()
+}
+
+// inline renders code spans, emphasis and links inside a block, escaping
+// everything else.
+func inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_[]()#", s[i+1]) >= 0:
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(s[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case (c == '*' || c == '_') && i+1 < len(s) && s[i+1] == c:
+			delim := s[i : i+2]
+			if end := strings.Index(s[i+2:], delim); end > 0 {
+				b.WriteString("<strong>" + inline(s[i+2:i+2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case c == '*' || c == '_':
+			// _ inside a word (snake_case) is not emphasis
+			if c == '_' && i > 0 && isWord(s[i-1]) {
+				break
+			}
+			if end := strings.IndexByte(s[i+1:], c); end > 0 && s[i+1] != ' ' {
+				b.WriteString("<em>" + inline(s[i+1:i+1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+
+		case c == '[':
+			if text, url, n, ok := link(s[i:]); ok {
+				b.WriteString(`<a href="` + html.EscapeString(safeURL(url)) + `">` + inline(text) + "</a>")
+				i += n
+				continue
+			}
+
+		case c == '\n':
+			b.WriteString("\n")
+			i++
+			continue
+		}
+		b.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+// link parses "[text](url)" at the start of s and returns its length.
+func link(s string) (text, url string, n int, ok bool) {
+	mid := strings.Index(s, "](")
+	if mid < 1 {
+		return "", "", 0, false
+	}
+	end := strings.IndexByte(s[mid+2:], ')')
+	if end < 0 {
+		return "", "", 0, false
+	}
+	url = strings.TrimSpace(s[mid+2 : mid+2+end])
+	if strings.ContainsAny(url, " \n") {
+		return "", "", 0, false
+	}
+	return s[1:mid], url, mid + 3 + end, true
+}
+
+// safeURL drops schemes like javascript: that would run code on click.
+func safeURL(u string) string {
+	scheme, _, found := strings.Cut(u, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return u // relative
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return u
+	}
+	return "#"
+}
+
+func isWord(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Slug turns heading text into an id for #fragment links, the way
+// GitHub does: lower-case letters, digits, - and _ are kept, spaces
+// become -, everything else is dropped.
+func Slug(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,135 @@
+// Package memo caches the results of pure functions.
+package memo
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Stats describes how a memoized function has been used so far.
+type Stats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+	Size      int
+}
+
+// Option configures a memoized function.
+type Option func(*config)
+
+type config struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// WithSize limits the cache to n entries, evicting the least recently used
+// entry when full. n <= 0 means unbounded, which is the default.
+func WithSize(n int) Option {
+	return func(c *config) { c.size = n }
+}
+
+// WithTTL expires entries d after they were computed. d <= 0 means entries
+// never expire, which is the default.
+func WithTTL(d time.Duration) Option {
+	return func(c *config) { c.ttl = d }
+}
+
+// WithClock replaces time.Now, which is handy for testing TTLs.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) { c.now = now }
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	val     V
+	expires time.Time
+}
+
+// Memo is a cached version of a function. It is safe for concurrent use.
+type Memo[K comparable, V any] struct {
+	fn  func(K) V
+	cfg config
+
+	mu    sync.Mutex
+	items map[K]*list.Element
+	order *list.List // front = most recently used
+	stats Stats
+}
+
+// Func returns a memoized version of fn.
+//
+// fn is called without holding the cache lock, so it may call back into the
+// Memo recursively (see the Fibonacci example in the recursion module).
+// Two concurrent misses for the same key may both run fn; fn must be pure.
+func Func[K comparable, V any](fn func(K) V, opts ...Option) *Memo[K, V] {
+	cfg := config{now: time.Now}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Memo[K, V]{
+		fn:    fn,
+		cfg:   cfg,
+		items: make(map[K]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Call returns fn(key), computing it only if it is not already cached.
+func (m *Memo[K, V]) Call(key K) V {
+	m.mu.Lock()
+	if el, ok := m.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		if m.cfg.ttl <= 0 || m.cfg.now().Before(e.expires) {
+			m.order.MoveToFront(el)
+			m.stats.Hits++
+			m.mu.Unlock()
+			return e.val
+		}
+		m.remove(el)
+	}
+	m.stats.Misses++
+	m.mu.Unlock()
+
+	val := m.fn(key)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		m.remove(el) // filled by a concurrent or recursive call meanwhile
+	}
+	e := &entry[K, V]{key: key, val: val}
+	if m.cfg.ttl > 0 {
+		e.expires = m.cfg.now().Add(m.cfg.ttl)
+	}
+	m.items[key] = m.order.PushFront(e)
+	if m.cfg.size > 0 && m.order.Len() > m.cfg.size {
+		m.remove(m.order.Back())
+		m.stats.Evictions++
+	}
+	return val
+}
+
+// Forget drops key from the cache.
+func (m *Memo[K, V]) Forget(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+}
+
+// Stats returns a snapshot of the cache counters.
+func (m *Memo[K, V]) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.stats
+	s.Size = m.order.Len()
+	return s
+}
+
+func (m *Memo[K, V]) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.items, el.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,72 @@
+// Memory layout: draws what the slice and pointer topics describe in
+// comments, with real addresses, offsets and shared backing arrays.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/armaanepiic/Golang/memviz"
+)
+
+// User is the struct from the pointer topic.
+type User struct {
+	Name   string
+	Age    int
+	Salary float32
+}
+
+// Padded shows what field order costs: each bool is followed by padding
+// so the int64 after it is 8-byte aligned.
+type Padded struct {
+	Active  bool
+	ID      int64
+	Admin   bool
+	Balance int64
+}
+
+func changeSlice(p []int) []int {
+	p[0] = 10
+	p = append(p, 11)
+	return p
+}
+
+func main() {
+	out := os.Stdout
+
+	fmt.Println("== an array is its elements, back to back ==")
+	arr := [3]int{1, 2, 3}
+	memviz.Elements(out, &arr)
+	p := &arr
+	fmt.Printf("p := &arr holds %p, the address of arr[0]; passing p copies 8 bytes, not %d\n\n", p, len(arr)*8)
+
+	fmt.Println("== struct fields sit at fixed offsets ==")
+	memviz.Struct(out, User{})
+	fmt.Println()
+	memviz.Struct(out, Padded{})
+	fmt.Println()
+
+	fmt.Println("== slices are headers pointing into a backing array ==")
+	x := []int{1, 2, 3, 4, 5}
+	memviz.Slice(out, "x", x)
+	x = append(x, 6) // no room: a new array with double the capacity
+	memviz.Slice(out, "x", x)
+	x = append(x, 7) // fits: same array
+	memviz.Slice(out, "x", x)
+	fmt.Println()
+
+	fmt.Println("== a := x[4:] shares x's array ==")
+	a := x[4:]
+	memviz.Shared(out, memviz.S("x", x), memviz.S("a", a))
+	fmt.Println()
+
+	fmt.Println("== y := changeSlice(a): p[0] = 10 writes x[4]; append fills the spare slot ==")
+	y := changeSlice(a)
+	memviz.Shared(out, memviz.S("x", x), memviz.S("a", a), memviz.S("y", y))
+	fmt.Println("x[0:8] =", x[0:8], "- reslicing past len(x) sees the 11 y appended")
+	fmt.Println()
+
+	fmt.Println("== appending past cap(y) copies y to a new array; x stays ==")
+	y = append(y, 12, 13, 14)
+	memviz.Shared(out, memviz.S("x", x), memviz.S("y", y))
+}
@@ -0,0 +1,265 @@
+// Package memviz prints how Go values sit in memory: the fields of a
+// struct with their offsets and padding, the elements of an array with
+// their addresses, and which slices share a backing array.
+//
+// It reads layouts through reflect, which reports the same numbers as
+// unsafe.Offsetof, unsafe.Sizeof and unsafe.Alignof. Addresses change
+// from run to run; offsets and strides do not.
+package memviz
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrKind is returned when a value has the wrong kind for the function.
+var ErrKind = errors.New("memviz: unsupported kind")
+
+// Field is one struct field.
+type Field struct {
+	Name    string
+	Type    string
+	Offset  uintptr
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr // unused bytes after the field
+}
+
+// Layout is the memory layout of a struct type.
+type Layout struct {
+	Type   string
+	Size   uintptr
+	Align  uintptr
+	Fields []Field
+}
+
+// Padding is the number of bytes in the struct that hold no field.
+func (l Layout) Padding() uintptr {
+	var p uintptr
+	for _, f := range l.Fields {
+		p += f.Padding
+	}
+	return p
+}
+
+// PackedSize is the size the struct would have with its fields sorted by
+// alignment, largest first: usually the smallest possible.
+func (l Layout) PackedSize() uintptr {
+	fs := slices.Clone(l.Fields)
+	slices.SortStableFunc(fs, func(a, b Field) int { return cmp.Compare(b.Align, a.Align) })
+	var off uintptr
+	for _, f := range fs {
+		off = alignUp(off, f.Align) + f.Size
+	}
+	return alignUp(off, l.Align)
+}
+
+func alignUp(n, a uintptr) uintptr {
+	if a == 0 {
+		return n
+	}
+	return (n + a - 1) / a * a
+}
+
+// StructLayout returns the layout of v's type, which must be a struct or
+// a pointer to one.
+func StructLayout(v any) (Layout, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return Layout{}, fmt.Errorf("%w %v: want a struct", ErrKind, t)
+	}
+	l := Layout{Type: t.String(), Size: t.Size(), Align: uintptr(t.Align())}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		f := Field{
+			Name:   sf.Name,
+			Type:   sf.Type.String(),
+			Offset: sf.Offset,
+			Size:   sf.Type.Size(),
+			Align:  uintptr(sf.Type.Align()),
+		}
+		end := t.Size()
+		if i+1 < t.NumField() {
+			end = t.Field(i + 1).Offset
+		}
+		f.Padding = end - (f.Offset + f.Size)
+		l.Fields = append(l.Fields, f)
+	}
+	return l, nil
+}
+
+// Struct prints the layout of v's struct type: every field's offset and
+// size, and where the compiler inserted padding.
+func Struct(w io.Writer, v any) error {
+	l, err := StructLayout(v)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s: size %d, align %d\n", l.Type, l.Size, l.Align)
+	fmt.Fprintln(w, "  offset  size")
+	for _, f := range l.Fields {
+		fmt.Fprintf(w, "  %6d  %4d  %s %s\n", f.Offset, f.Size, f.Name, f.Type)
+		if f.Padding > 0 {
+			fmt.Fprintf(w, "  %6d  %4d  (padding)\n", f.Offset+f.Size, f.Padding)
+		}
+	}
+	if p := l.Padding(); p > 0 {
+		fmt.Fprintf(w, "%d of %d bytes are padding", p, l.Size)
+		if packed := l.PackedSize(); packed < l.Size {
+			fmt.Fprintf(w, "; ordering fields by alignment would make it %d", packed)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// Elements prints the address of every element of v, a pointer to an
+// array or a slice, showing that they sit one stride (the element size)
+// apart.
+func Elements(w io.Writer, v any) error {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Array:
+		rv = rv.Elem()
+	case rv.Kind() == reflect.Slice:
+	default:
+		return fmt.Errorf("%w %T: want a pointer to an array, or a slice", ErrKind, v)
+	}
+	stride := rv.Type().Elem().Size()
+	fmt.Fprintf(w, "%s: %d elements, stride %d bytes, %d bytes of elements\n", rv.Type(), rv.Len(), stride, uintptr(rv.Len())*stride)
+	if rv.Len() == 0 {
+		return nil
+	}
+	base := rv.Index(0).Addr().Pointer()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i := range rv.Len() {
+		addr := rv.Index(i).Addr().Pointer()
+		fmt.Fprintf(tw, "  [%d]\t%#x\t+%d\t%v\n", i, addr, addr-base, rv.Index(i))
+	}
+	return tw.Flush()
+}
+
+// Slice prints the three words of a slice header: the pointer to the
+// backing array, the length and the capacity.
+func Slice(w io.Writer, name string, s any) error {
+	rv := reflect.ValueOf(s)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Errorf("%w %T: want a slice", ErrKind, s)
+	}
+	_, err := fmt.Fprintf(w, "%s %s: ptr=%#x len=%d cap=%d (header %d bytes)\n",
+		name, rv.Type(), rv.Pointer(), rv.Len(), rv.Cap(), reflect.TypeOf(s).Size())
+	return err
+}
+
+// Named is a slice with a label for Shared.
+type Named struct {
+	Name  string
+	Slice any
+}
+
+// S labels a slice for Shared.
+func S(name string, slice any) Named { return Named{name, slice} }
+
+type span struct {
+	Named
+	v          reflect.Value
+	order      int     // position in the arguments
+	start, end uintptr // backing array bytes the slice can reach, up to cap
+}
+
+// Shared draws the slices over their backing arrays, one diagram per
+// array: a column per element, a row per slice. Elements within a
+// slice's length show their value; those between length and capacity,
+// which only reslicing or append can reach, show it in parentheses.
+func Shared(w io.Writer, named ...Named) error {
+	var spans []span
+	for i, n := range named {
+		rv := reflect.ValueOf(n.Slice)
+		if rv.Kind() != reflect.Slice {
+			return fmt.Errorf("%w %T (%s): want a slice", ErrKind, n.Slice, n.Name)
+		}
+		if rv.Cap() == 0 {
+			fmt.Fprintf(w, "%s: no backing array (len 0, cap 0)\n", n.Name)
+			continue
+		}
+		size := rv.Type().Elem().Size()
+		if size == 0 {
+			fmt.Fprintf(w, "%s: zero-size elements take no memory\n", n.Name)
+			continue
+		}
+		spans = append(spans, span{n, rv, i, rv.Pointer(), rv.Pointer() + uintptr(rv.Cap())*size})
+	}
+
+	// group slices whose reachable bytes overlap
+	slices.SortStableFunc(spans, func(a, b span) int { return cmp.Compare(a.start, b.start) })
+	for len(spans) > 0 {
+		end := spans[0].end
+		n := 1
+		for n < len(spans) && spans[n].start < end && spans[n].v.Type() == spans[0].v.Type() {
+			end = max(end, spans[n].end)
+			n++
+		}
+		if err := drawArray(w, spans[:n]); err != nil {
+			return err
+		}
+		spans = spans[n:]
+	}
+	return nil
+}
+
+func drawArray(w io.Writer, group []span) error {
+	// restore the callers' order
+	slices.SortFunc(group, func(a, b span) int { return cmp.Compare(a.order, b.order) })
+	base, end := group[0].start, group[0].end
+	for _, s := range group {
+		base, end = min(base, s.start), max(end, s.end)
+	}
+	size := group[0].v.Type().Elem().Size()
+	slots := int((end - base) / size)
+
+	cells := make([][]string, len(group))
+	width := len(fmt.Sprint(slots - 1))
+	for r, s := range group {
+		cells[r] = make([]string, slots)
+		full := s.v.Slice(0, s.v.Cap())
+		first := int((s.start - base) / size)
+		for i := range full.Len() {
+			c := fmt.Sprint(full.Index(i))
+			if i >= s.v.Len() {
+				c = "(" + c + ")"
+			}
+			cells[r][first+i] = c
+			width = max(width, len(c))
+		}
+	}
+	nameWidth := 0
+	for _, s := range group {
+		nameWidth = max(nameWidth, len(s.Name))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "backing array at %#x: %d × %d bytes\n", base, slots, size)
+	fmt.Fprintf(&b, "%-*s", nameWidth, "")
+	for i := range slots {
+		fmt.Fprintf(&b, " %*d", width, i)
+	}
+	b.WriteString("\n")
+	for r, s := range group {
+		fmt.Fprintf(&b, "%-*s", nameWidth, s.Name)
+		for _, c := range cells[r] {
+			fmt.Fprintf(&b, " %*s", width, c)
+		}
+		fmt.Fprintf(&b, "   len %d cap %d\n", s.v.Len(), s.v.Cap())
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,166 @@
+// Package merkle builds SHA-256 Merkle trees over chunks of data and
+// produces inclusion proofs for individual chunks.
+//
+// Leaves are hashed as H(0x00 || chunk) and inner nodes as
+// H(0x01 || left || right) so a leaf can never be passed off as a node.
+// When a level has an odd number of nodes the last one is promoted as is.
+package merkle
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+)
+
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// Hash is a SHA-256 digest.
+type Hash [sha256.Size]byte
+
+// ErrEmpty is returned when a tree is built without any chunks.
+var ErrEmpty = errors.New("merkle: no chunks")
+
+// ErrIndex is returned by Prove for an out of range chunk index.
+var ErrIndex = errors.New("merkle: chunk index out of range")
+
+// Tree is an immutable Merkle tree. levels[0] holds the leaf hashes and the
+// last level holds the root.
+type Tree struct {
+	levels [][]Hash
+}
+
+// Step is one sibling on the path from a leaf to the root.
+type Step struct {
+	Hash Hash
+	Left bool // sibling sits to the left of the running hash
+}
+
+// Proof shows that the chunk at Index is part of a tree.
+type Proof struct {
+	Index int
+	Steps []Step
+}
+
+// HashLeaf returns the leaf hash of chunk.
+func HashLeaf(chunk []byte) Hash {
+	return sha256.Sum256(append([]byte{leafPrefix}, chunk...))
+}
+
+// HashNode returns the hash of an inner node with the given children.
+func HashNode(left, right Hash) Hash {
+	buf := make([]byte, 0, 1+2*sha256.Size)
+	buf = append(buf, nodePrefix)
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+	return sha256.Sum256(buf)
+}
+
+// New builds a tree over chunks.
+func New(chunks [][]byte) (*Tree, error) {
+	if len(chunks) == 0 {
+		return nil, ErrEmpty
+	}
+	leaves := make([]Hash, len(chunks))
+	for i, c := range chunks {
+		leaves[i] = HashLeaf(c)
+	}
+
+	levels := [][]Hash{leaves}
+	for cur := leaves; len(cur) > 1; {
+		next := make([]Hash, 0, (len(cur)+1)/2)
+		for i := 0; i < len(cur); i += 2 {
+			if i+1 == len(cur) {
+				next = append(next, cur[i])
+				continue
+			}
+			next = append(next, HashNode(cur[i], cur[i+1]))
+		}
+		levels = append(levels, next)
+		cur = next
+	}
+	return &Tree{levels: levels}, nil
+}
+
+// FromReader splits r into chunks of chunkSize bytes (the last one may be
+// shorter) and builds a tree over them, e.g. for a file on disk.
+func FromReader(r io.Reader, chunkSize int) (*Tree, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("merkle: chunk size must be positive")
+	}
+	br := bufio.NewReader(r)
+	var chunks [][]byte
+	for {
+		buf := make([]byte, chunkSize)
+		n, err := io.ReadFull(br, buf)
+		if n > 0 {
+			chunks = append(chunks, buf[:n])
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return New(chunks)
+}
+
+// FromRecords builds a tree with one leaf per record, using encode to turn
+// each record into bytes (for example json.Marshal on a slice of users).
+func FromRecords[T any](records []T, encode func(T) ([]byte, error)) (*Tree, error) {
+	chunks := make([][]byte, len(records))
+	for i, rec := range records {
+		b, err := encode(rec)
+		if err != nil {
+			return nil, err
+		}
+		chunks[i] = b
+	}
+	return New(chunks)
+}
+
+// Root returns the root hash.
+func (t *Tree) Root() Hash {
+	top := t.levels[len(t.levels)-1]
+	return top[0]
+}
+
+// Len returns the number of leaves.
+func (t *Tree) Len() int {
+	return len(t.levels[0])
+}
+
+// Prove returns an inclusion proof for the chunk at index.
+func (t *Tree) Prove(index int) (Proof, error) {
+	if index < 0 || index >= t.Len() {
+		return Proof{}, ErrIndex
+	}
+	p := Proof{Index: index}
+	i := index
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sib := i ^ 1
+		if sib < len(level) {
+			p.Steps = append(p.Steps, Step{Hash: level[sib], Left: sib < i})
+		}
+		i /= 2
+	}
+	return p, nil
+}
+
+// Verify reports whether chunk, combined with proof, hashes up to root.
+func Verify(root Hash, chunk []byte, proof Proof) bool {
+	h := HashLeaf(chunk)
+	for _, s := range proof.Steps {
+		if s.Left {
+			h = HashNode(s.Hash, h)
+		} else {
+			h = HashNode(h, s.Hash)
+		}
+	}
+	return bytes.Equal(h[:], root[:])
+}
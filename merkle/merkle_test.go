@@ -0,0 +1,133 @@
+package merkle_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/merkle"
+)
+
+// leaf and node spell the hashing out by hand, independently of the package.
+func leaf(chunk string) merkle.Hash {
+	return sha256.Sum256(append([]byte{0x00}, chunk...))
+}
+
+func node(l, r merkle.Hash) merkle.Hash {
+	return sha256.Sum256(append(append([]byte{0x01}, l[:]...), r[:]...))
+}
+
+func chunks(n int) [][]byte {
+	out := make([][]byte, n)
+	for i := range out {
+		out[i] = fmt.Appendf(nil, "chunk %d", i)
+	}
+	return out
+}
+
+func TestRoot(t *testing.T) {
+	a, b, c := leaf("chunk 0"), leaf("chunk 1"), leaf("chunk 2")
+	tests := []struct {
+		n    int
+		want merkle.Hash
+	}{
+		{1, a},
+		{2, node(a, b)},
+		{3, node(node(a, b), c)}, // the odd leaf is promoted unhashed
+	}
+	for _, tt := range tests {
+		tree, err := merkle.New(chunks(tt.n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tree.Root(); got != tt.want {
+			t.Errorf("%d leaves: root %x, want %x", tt.n, got, tt.want)
+		}
+		if tree.Len() != tt.n {
+			t.Errorf("Len = %d, want %d", tree.Len(), tt.n)
+		}
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		cs := chunks(n)
+		tree, err := merkle.New(cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := tree.Root()
+		for i := range cs {
+			p, err := tree.Prove(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !merkle.Verify(root, cs[i], p) {
+				t.Fatalf("n=%d: proof for %d does not verify", n, i)
+			}
+			if merkle.Verify(root, []byte("tampered"), p) {
+				t.Errorf("n=%d: tampered chunk %d verified", n, i)
+			}
+			// the proof of one index does not vouch for the chunk at another
+			for j := range cs {
+				if j != i && merkle.Verify(root, cs[j], p) {
+					t.Errorf("n=%d: chunk %d verified with the proof for %d", n, j, i)
+				}
+			}
+			for s := range p.Steps {
+				flipped := merkle.Proof{Index: p.Index, Steps: append([]merkle.Step(nil), p.Steps...)}
+				flipped.Steps[s].Left = !flipped.Steps[s].Left
+				if merkle.Verify(root, cs[i], flipped) {
+					t.Errorf("n=%d: chunk %d verified with step %d on the wrong side", n, i, s)
+				}
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := merkle.New(nil); !errors.Is(err, merkle.ErrEmpty) {
+		t.Errorf("New(nil) = %v", err)
+	}
+	tree, _ := merkle.New(chunks(3))
+	for _, i := range []int{-1, 3} {
+		if _, err := tree.Prove(i); !errors.Is(err, merkle.ErrIndex) {
+			t.Errorf("Prove(%d) = %v", i, err)
+		}
+	}
+	if _, err := merkle.FromReader(strings.NewReader("x"), 0); err == nil {
+		t.Error("FromReader accepted chunk size 0")
+	}
+}
+
+func TestFromReader(t *testing.T) {
+	data := []byte("abcdefghij")
+	tree, err := merkle.FromReader(bytes.NewReader(data), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := merkle.New([][]byte{[]byte("abcd"), []byte("efgh"), []byte("ij")})
+	if tree.Root() != want.Root() {
+		t.Error("FromReader and New disagree")
+	}
+	if _, err := merkle.FromReader(bytes.NewReader(nil), 4); !errors.Is(err, merkle.ErrEmpty) {
+		t.Errorf("empty reader: %v", err)
+	}
+}
+
+func TestFromRecords(t *testing.T) {
+	tree, err := merkle.FromRecords([]int{1, 2}, func(v int) ([]byte, error) { return fmt.Appendf(nil, "%d", v), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Root() != node(leaf("1"), leaf("2")) {
+		t.Error("wrong root from records")
+	}
+	boom := errors.New("boom")
+	if _, err := merkle.FromRecords([]int{1}, func(int) ([]byte, error) { return nil, boom }); err != boom {
+		t.Errorf("encode error = %v", err)
+	}
+}
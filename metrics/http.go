@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTPMetrics records request counts and latencies.
+type HTTPMetrics struct {
+	requests *Counter
+	duration *Histogram
+	inFlight *Gauge
+}
+
+// NewHTTPMetrics registers http_requests_total, http_request_duration_seconds
+// and http_requests_in_flight in r.
+func NewHTTPMetrics(r *Registry) *HTTPMetrics {
+	return &HTTPMetrics{
+		requests: r.NewCounter("http_requests_total", "Total HTTP requests.", "method", "route", "code"),
+		duration: r.NewHistogram("http_request_duration_seconds", "HTTP request latency.", nil, "method", "route"),
+		inFlight: r.NewGauge("http_requests_in_flight", "HTTP requests being served."),
+	}
+}
+
+type codeRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (c *codeRecorder) WriteHeader(code int) {
+	if c.code == 0 {
+		c.code = code
+	}
+	c.ResponseWriter.WriteHeader(code)
+}
+
+func (c *codeRecorder) Write(b []byte) (int, error) {
+	if c.code == 0 {
+		c.code = http.StatusOK
+	}
+	return c.ResponseWriter.Write(b)
+}
+
+func (c *codeRecorder) Unwrap() http.ResponseWriter { return c.ResponseWriter }
+
+// Middleware records every request. The route label is the ServeMux
+// pattern that matched (e.g. "GET /users/{id}"), so URLs with IDs do not
+// explode the number of series; unmatched requests use "unmatched".
+func (m *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		start := time.Now()
+		rec := &codeRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.code == 0 {
+			rec.code = http.StatusOK
+		}
+
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		m.requests.Inc(r.Method, route, strconv.Itoa(rec.code))
+		m.duration.Observe(time.Since(start).Seconds(), r.Method, route)
+	})
+}
@@ -0,0 +1,300 @@
+// Package metrics implements counters, gauges and histograms and exposes
+// them in the Prometheus text exposition format (version 0.0.4) without
+// any external dependency.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds a set of metrics and renders them for scraping.
+type Registry struct {
+	mu      sync.Mutex
+	metrics map[string]metric
+}
+
+type metric interface {
+	write(w io.Writer)
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]metric)}
+}
+
+// Default is the registry used by the package level helpers.
+var Default = NewRegistry()
+
+func (r *Registry) register(name string, m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.metrics[name]; dup {
+		panic("metrics: duplicate metric " + name)
+	}
+	r.metrics[name] = m
+}
+
+// WriteText writes every metric, sorted by name, in the text format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.metrics))
+	for n := range r.metrics {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	ms := make([]metric, len(names))
+	for i, n := range names {
+		ms[i] = r.metrics[n]
+	}
+	r.mu.Unlock()
+
+	ew := &errWriter{w: w}
+	for _, m := range ms {
+		m.write(ew)
+	}
+	return ew.err
+}
+
+// Handler serves the registry at a /metrics endpoint.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteText(w)
+	})
+}
+
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	n, err := e.w.Write(p)
+	e.err = err
+	return n, err
+}
+
+// desc is the identity shared by all series of a metric.
+type desc struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+func (d *desc) header(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+}
+
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s: got %d label values, want %d", d.name, len(values), len(d.labels)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// labelString renders {a="x",b="y"} for the label values encoded in key,
+// with extra appended as a final label pair if non-empty.
+func (d *desc) labelString(key string, extra ...string) string {
+	var pairs []string
+	if len(d.labels) > 0 {
+		for i, v := range strings.Split(key, "\xff") {
+			pairs = append(pairs, d.labels[i]+`="`+escapeLabel(v)+`"`)
+		}
+	}
+	if len(extra) == 2 {
+		pairs = append(pairs, extra[0]+`="`+escapeLabel(extra[1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// series is a set of float values keyed by label values.
+type series struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func (s *series) add(v float64, labels []string) {
+	k := s.key(labels)
+	s.mu.Lock()
+	s.values[k] += v
+	s.mu.Unlock()
+}
+
+func (s *series) set(v float64, labels []string) {
+	k := s.key(labels)
+	s.mu.Lock()
+	s.values[k] = v
+	s.mu.Unlock()
+}
+
+func (s *series) get(labels []string) float64 {
+	k := s.key(labels)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[k]
+}
+
+func (s *series) write(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header(w)
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s%s %s\n", s.name, s.labelString(k), formatFloat(s.values[k]))
+	}
+}
+
+// Counter is a monotonically increasing value, optionally partitioned by
+// labels. Label values are passed in the order the label names were given.
+type Counter struct{ s *series }
+
+// NewCounter registers a counter in r.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{s: &series{desc: desc{name, help, "counter", labels}, values: make(map[string]float64)}}
+	r.register(name, c.s)
+	return c
+}
+
+// Inc adds one.
+func (c *Counter) Inc(labels ...string) { c.s.add(1, labels) }
+
+// Add adds v, which must not be negative.
+func (c *Counter) Add(v float64, labels ...string) {
+	if v < 0 {
+		panic("metrics: counter cannot decrease")
+	}
+	c.s.add(v, labels)
+}
+
+// Value returns the current value.
+func (c *Counter) Value(labels ...string) float64 { return c.s.get(labels) }
+
+// Gauge is a value that can go up and down.
+type Gauge struct{ s *series }
+
+// NewGauge registers a gauge in r.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	g := &Gauge{s: &series{desc: desc{name, help, "gauge", labels}, values: make(map[string]float64)}}
+	r.register(name, g.s)
+	return g
+}
+
+func (g *Gauge) Set(v float64, labels ...string) { g.s.set(v, labels) }
+func (g *Gauge) Add(v float64, labels ...string) { g.s.add(v, labels) }
+func (g *Gauge) Inc(labels ...string)            { g.s.add(1, labels) }
+func (g *Gauge) Dec(labels ...string)            { g.s.add(-1, labels) }
+
+// Value returns the current value.
+func (g *Gauge) Value(labels ...string) float64 { return g.s.get(labels) }
+
+// DefBuckets are latency buckets in seconds, the same as Prometheus clients.
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Histogram counts observations in cumulative buckets.
+type Histogram struct {
+	desc
+	buckets []float64
+
+	mu   sync.Mutex
+	data map[string]*histData
+}
+
+type histData struct {
+	counts []uint64 // per bucket, not cumulative; last is +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram registers a histogram with the given upper bounds (nil means
+// DefBuckets) in r.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if buckets == nil {
+		buckets = DefBuckets
+	}
+	h := &Histogram{
+		desc:    desc{name, help, "histogram", labels},
+		buckets: slices.Sorted(slices.Values(buckets)),
+		data:    make(map[string]*histData),
+	}
+	r.register(name, h)
+	return h
+}
+
+// Observe records one value.
+func (h *Histogram) Observe(v float64, labels ...string) {
+	k := h.key(labels)
+	i, _ := slices.BinarySearch(h.buckets, v) // first bucket with bound >= v
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	d := h.data[k]
+	if d == nil {
+		d = &histData{counts: make([]uint64, len(h.buckets)+1)}
+		h.data[k] = d
+	}
+	d.counts[i]++
+	d.sum += v
+	d.count++
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.header(w)
+	keys := make([]string, 0, len(h.data))
+	for k := range h.data {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		d := h.data[k]
+		var cum uint64
+		for i, b := range h.buckets {
+			cum += d.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(k, "le", formatFloat(b)), cum)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelString(k, "le", "+Inf"), d.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelString(k), formatFloat(d.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelString(k), d.count)
+	}
+}
@@ -0,0 +1,154 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/armaanepiic/Golang/metrics"
+)
+
+func scrape(t *testing.T, r *metrics.Registry) string {
+	t.Helper()
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func check(t *testing.T, got, want string) {
+	t.Helper()
+	want = strings.TrimPrefix(want, "\n")
+	if got != want {
+		t.Errorf("scrape:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounter("jobs_total", "Jobs run.", "queue", "result")
+	c.Inc("mail", "ok")
+	c.Inc("mail", "ok")
+	c.Add(2.5, "batch", "failed")
+
+	check(t, scrape(t, r), `
+# HELP jobs_total Jobs run.
+# TYPE jobs_total counter
+jobs_total{queue="batch",result="failed"} 2.5
+jobs_total{queue="mail",result="ok"} 2
+`)
+	if v := c.Value("mail", "ok"); v != 2 {
+		t.Errorf("Value = %v", v)
+	}
+}
+
+func TestGauge(t *testing.T) {
+	r := metrics.NewRegistry()
+	g := r.NewGauge("temperature_celsius", "Current temperature.\nIn the server room.")
+	g.Set(21)
+	g.Inc()
+	g.Add(-0.5)
+	g.Dec()
+
+	check(t, scrape(t, r), `
+# HELP temperature_celsius Current temperature.\nIn the server room.
+# TYPE temperature_celsius gauge
+temperature_celsius 20.5
+`)
+}
+
+func TestHistogram(t *testing.T) {
+	r := metrics.NewRegistry()
+	h := r.NewHistogram("latency_seconds", `Latency in "seconds".`, []float64{0.5, 0.1, 1}, "path")
+	for _, v := range []float64{0.05, 0.1, 0.3, 0.7, 3} {
+		h.Observe(v, "/a")
+	}
+	h.Observe(0.2, "say \"hi\"\\\n")
+
+	check(t, scrape(t, r), `
+# HELP latency_seconds Latency in "seconds".
+# TYPE latency_seconds histogram
+latency_seconds_bucket{path="/a",le="0.1"} 2
+latency_seconds_bucket{path="/a",le="0.5"} 3
+latency_seconds_bucket{path="/a",le="1"} 4
+latency_seconds_bucket{path="/a",le="+Inf"} 5
+latency_seconds_sum{path="/a"} 4.15
+latency_seconds_count{path="/a"} 5
+latency_seconds_bucket{path="say \"hi\"\\\n",le="0.1"} 0
+latency_seconds_bucket{path="say \"hi\"\\\n",le="0.5"} 1
+latency_seconds_bucket{path="say \"hi\"\\\n",le="1"} 1
+latency_seconds_bucket{path="say \"hi\"\\\n",le="+Inf"} 1
+latency_seconds_sum{path="say \"hi\"\\\n"} 0.2
+latency_seconds_count{path="say \"hi\"\\\n"} 1
+`)
+}
+
+func TestSortedByName(t *testing.T) {
+	r := metrics.NewRegistry()
+	r.NewGauge("b", "B.").Set(1)
+	r.NewCounter("a", "A.").Inc()
+	check(t, scrape(t, r), `
+# HELP a A.
+# TYPE a counter
+a 1
+# HELP b B.
+# TYPE b gauge
+b 1
+`)
+}
+
+func TestMisuse(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounter("x_total", "X.", "label")
+	for name, f := range map[string]func(){
+		"duplicate name":    func() { r.NewGauge("x_total", "again") },
+		"wrong label count": func() { c.Inc() },
+		"negative add":      func() { c.Add(-1, "v") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := metrics.NewRegistry()
+	r.NewCounter("up", "Up.").Inc()
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "\nup 1\n") {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
+
+func TestHTTPMetrics(t *testing.T) {
+	r := metrics.NewRegistry()
+	m := metrics.NewHTTPMetrics(r)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	h := m.Middleware(mux)
+	for _, path := range []string{"/users/1", "/users/2", "/nope"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+	out := scrape(t, r)
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="GET /users/{id}",code="200"} 2`,
+		`http_requests_total{method="GET",route="unmatched",code="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="GET /users/{id}"} 2`,
+		"http_requests_in_flight 0",
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("scrape lacks %s:\n%s", want, out)
+		}
+	}
+}
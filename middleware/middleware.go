@@ -0,0 +1,205 @@
+// Package middleware provides composable net/http middleware.
+//
+// A middleware is a func(http.Handler) http.Handler. Chain composes them so
+// that the first one listed is the outermost: it sees the request first and
+// the response last.
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/logx"
+	"github.com/armaanepiic/Golang/recoverx"
+	"github.com/armaanepiic/Golang/trace"
+)
+
+// Middleware wraps a handler with extra behaviour.
+type Middleware = func(http.Handler) http.Handler
+
+// Chain composes mws so that Chain(a, b, c)(h) == a(b(c(h))).
+func Chain(mws ...Middleware) Middleware {
+	return func(h http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			h = mws[i](h)
+		}
+		return h
+	}
+}
+
+// RequestID reuses the client's X-Request-ID header if it is sane, or
+// generates a new ID otherwise. The ID is echoed in the response, stored
+// for trace.FromContext and added to every log line written through logx.
+// Put it first in a Chain so the other middleware can see the ID.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(trace.Header)
+		if !trace.ValidID(id) {
+			id = trace.NewID()
+		}
+		w.Header().Set(trace.Header, id)
+
+		ctx := trace.NewContext(r.Context(), id)
+		ctx = logx.ContextWith(ctx, "request_id", id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// statusRecorder remembers the status code and size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Logging logs one line per request: method, path, status, size and
+// duration. A nil logger uses logx.With on the request context.
+func Logging(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+			requestLogger(logger, r).Info("request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", rec.status,
+				"bytes", rec.bytes,
+				"duration", time.Since(start),
+			)
+		})
+	}
+}
+
+// Recover answers 500 Internal Server Error when the handler panics and
+// logs the panic with its stack. http.ErrAbortHandler is re-panicked, as
+// net/http expects. A nil logger uses logx.With on the request context.
+func Recover(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := recoverx.Do(func() { next.ServeHTTP(w, r) })
+			if err == nil {
+				return
+			}
+			pe := err.(*recoverx.PanicError)
+			if pe.Value == http.ErrAbortHandler {
+				panic(pe.Value)
+			}
+			requestLogger(logger, r).Error("panic",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"panic", pe.Value,
+				"stack", string(pe.Stack),
+			)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		})
+	}
+}
+
+func requestLogger(logger *slog.Logger, r *http.Request) *slog.Logger {
+	if logger != nil {
+		return logger
+	}
+	return logx.With(r.Context())
+}
+
+// CORSOptions configures CORS.
+type CORSOptions struct {
+	AllowedOrigins []string // "*" allows any origin
+	AllowedMethods []string // default GET, POST, PUT, PATCH, DELETE
+	AllowedHeaders []string // default Content-Type, Authorization
+	MaxAge         time.Duration
+}
+
+// CORS adds Cross-Origin Resource Sharing headers and answers preflight
+// OPTIONS requests itself.
+func CORS(opts CORSOptions) Middleware {
+	if len(opts.AllowedMethods) == 0 {
+		opts.AllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	}
+	if len(opts.AllowedHeaders) == 0 {
+		opts.AllowedHeaders = []string{"Content-Type", "Authorization"}
+	}
+	methods := strings.Join(opts.AllowedMethods, ", ")
+	headers := strings.Join(opts.AllowedHeaders, ", ")
+
+	allowed := func(origin string) bool {
+		for _, o := range opts.AllowedOrigins {
+			if o == "*" || strings.EqualFold(o, origin) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Origin")
+			if !allowed(origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+				if opts.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Timeout cancels the request context after d and answers 503 Service
+// Unavailable if the handler has not written a response by then.
+// Requests that accept text/event-stream are passed through untouched:
+// the timeout handler buffers the whole response, which breaks streaming.
+func Timeout(d time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		th := http.TimeoutHandler(next, d, "request timed out")
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			th.ServeHTTP(w, r)
+		})
+	}
+}
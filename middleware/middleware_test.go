@@ -0,0 +1,182 @@
+package middleware_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/middleware"
+	"github.com/armaanepiic/Golang/trace"
+)
+
+func ok(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) }
+
+func serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	mark := func(name string) middleware.Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name+" in")
+				next.ServeHTTP(w, r)
+				calls = append(calls, name+" out")
+			})
+		}
+	}
+	h := middleware.Chain(mark("a"), mark("b"), mark("c"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	}))
+	serve(h, httptest.NewRequest("GET", "/", nil))
+
+	want := "a in,b in,c in,handler,c out,b out,a out"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("order %s, want %s", got, want)
+	}
+
+	calls = nil
+	serve(middleware.Chain()(http.HandlerFunc(ok)), httptest.NewRequest("GET", "/", nil))
+	if len(calls) != 0 {
+		t.Fatal("an empty chain ran middleware")
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	h := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = trace.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(trace.Header, "client-id-123")
+	rec := serve(h, req)
+	if got := rec.Header().Get(trace.Header); got != "client-id-123" || seen != got {
+		t.Fatalf("echoed %q, handler saw %q", got, seen)
+	}
+
+	for _, bad := range []string{"", strings.Repeat("x", 500), "bad id\n"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(trace.Header, bad)
+		rec := serve(h, req)
+		got := rec.Header().Get(trace.Header)
+		if got == bad || !trace.ValidID(got) || seen != got {
+			t.Errorf("header %q: echoed %q, handler saw %q", bad, got, seen)
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	h := middleware.Recover(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	rec := serve(h, httptest.NewRequest("GET", "/x", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if !strings.Contains(logs.String(), "panic=boom") || !strings.Contains(logs.String(), "path=/x") {
+		t.Errorf("log = %s", logs.String())
+	}
+
+	abort := middleware.Recover(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Fatalf("recovered %v, want http.ErrAbortHandler re-panicked", v)
+		}
+	}()
+	serve(abort, httptest.NewRequest("GET", "/", nil))
+	t.Fatal("ErrAbortHandler was swallowed")
+}
+
+func TestCORS(t *testing.T) {
+	h := middleware.CORS(middleware.CORSOptions{
+		AllowedOrigins: []string{"https://app.example"},
+		MaxAge:         time.Hour,
+	})(http.HandlerFunc(ok))
+
+	req := httptest.NewRequest("OPTIONS", "/users", nil)
+	req.Header.Set("Origin", "https://app.example")
+	req.Header.Set("Access-Control-Request-Method", "DELETE")
+	rec := serve(h, req)
+	if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+		t.Fatalf("preflight = %d %q", rec.Code, rec.Body.String())
+	}
+	for k, want := range map[string]string{
+		"Access-Control-Allow-Origin":  "https://app.example",
+		"Access-Control-Allow-Methods": "GET, POST, PUT, PATCH, DELETE",
+		"Access-Control-Allow-Headers": "Content-Type, Authorization",
+		"Access-Control-Max-Age":       "3600",
+		"Vary":                         "Origin",
+	} {
+		if got := rec.Header().Get(k); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/users", nil)
+	req.Header.Set("Origin", "https://app.example")
+	rec = serve(h, req)
+	if rec.Body.String() != "ok" || rec.Header().Get("Access-Control-Allow-Origin") != "https://app.example" {
+		t.Errorf("simple request = %q, %v", rec.Body.String(), rec.Header())
+	}
+
+	req = httptest.NewRequest("OPTIONS", "/users", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	req.Header.Set("Access-Control-Request-Method", "DELETE")
+	rec = serve(h, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "" || rec.Code == http.StatusNoContent {
+		t.Errorf("foreign origin allowed: %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+			w.Write([]byte("late"))
+		}
+	})
+	h := middleware.Timeout(20 * time.Millisecond)(slow)
+
+	rec := serve(h, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusServiceUnavailable || rec.Body.String() != "request timed out" {
+		t.Fatalf("timed out = %d %q", rec.Code, rec.Body.String())
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	rec = serve(h, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != "late" {
+		t.Fatalf("event stream = %d %q, want it to bypass the timeout", rec.Code, rec.Body.String())
+	}
+
+	if rec := serve(middleware.Timeout(time.Second)(http.HandlerFunc(ok)), httptest.NewRequest("GET", "/", nil)); rec.Body.String() != "ok" {
+		t.Errorf("fast handler = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestLogging(t *testing.T) {
+	var logs bytes.Buffer
+	h := middleware.Logging(slog.New(slog.NewTextHandler(&logs, nil)))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("short"))
+	}))
+	serve(h, httptest.NewRequest("PUT", "/pot", nil))
+	for _, want := range []string{"method=PUT", "path=/pot", "status=418", "bytes=5"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log lacks %s: %s", want, logs.String())
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package mini is a tree-walking interpreter for a tiny scripting
+// language with integers, strings, booleans, let-bindings, if/else and
+// first-class functions with closures:
+//
+//	let fact = fn(n) {
+//		if (n < 2) { 1 } else { n * fact(n - 1) }
+//	};
+//	print("10! =", fact(10));
+//
+// Source goes through lex (tokens), the parser here (an AST of the types
+// in this file) and Eval, which walks the tree.
+package mini
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/armaanepiic/Golang/lex"
+)
+
+// Node is any AST node.
+type Node interface {
+	Pos() lex.Pos
+	String() string
+}
+
+// Stmt is a statement; Expr is an expression. Both are Nodes; the
+// marker methods keep them apart at compile time.
+type (
+	Stmt interface {
+		Node
+		stmt()
+	}
+	Expr interface {
+		Node
+		expr()
+	}
+)
+
+// Program is a whole source file.
+type Program struct {
+	Stmts []Stmt
+}
+
+// Statements.
+type (
+	LetStmt struct {
+		At    lex.Pos
+		Name  string
+		Value Expr
+	}
+	ReturnStmt struct {
+		At    lex.Pos
+		Value Expr // nil for a bare return
+	}
+	ExprStmt struct {
+		X Expr
+	}
+)
+
+// Expressions.
+type (
+	Ident struct {
+		At   lex.Pos
+		Name string
+	}
+	IntLit struct {
+		At    lex.Pos
+		Value int64
+	}
+	StringLit struct {
+		At    lex.Pos
+		Value string
+	}
+	BoolLit struct {
+		At    lex.Pos
+		Value bool
+	}
+	Prefix struct {
+		At lex.Pos
+		Op string
+		X  Expr
+	}
+	Infix struct {
+		At   lex.Pos // of the operator
+		Op   string
+		X, Y Expr
+	}
+	Block struct {
+		At    lex.Pos
+		Stmts []Stmt
+	}
+	IfExpr struct {
+		At   lex.Pos
+		Cond Expr
+		Then *Block
+		Else Expr // *Block, *IfExpr (else if) or nil
+	}
+	FuncLit struct {
+		At     lex.Pos
+		Params []string
+		Body   *Block
+	}
+	Call struct {
+		At   lex.Pos // of the (
+		Fn   Expr
+		Args []Expr
+	}
+)
+
+func (*LetStmt) stmt()    {}
+func (*ReturnStmt) stmt() {}
+func (*ExprStmt) stmt()   {}
+
+func (*Ident) expr()     {}
+func (*IntLit) expr()    {}
+func (*StringLit) expr() {}
+func (*BoolLit) expr()   {}
+func (*Prefix) expr()    {}
+func (*Infix) expr()     {}
+func (*Block) expr()     {}
+func (*IfExpr) expr()    {}
+func (*FuncLit) expr()   {}
+func (*Call) expr()      {}
+
+func (s *LetStmt) Pos() lex.Pos    { return s.At }
+func (s *ReturnStmt) Pos() lex.Pos { return s.At }
+func (s *ExprStmt) Pos() lex.Pos   { return s.X.Pos() }
+func (e *Ident) Pos() lex.Pos      { return e.At }
+func (e *IntLit) Pos() lex.Pos     { return e.At }
+func (e *StringLit) Pos() lex.Pos  { return e.At }
+func (e *BoolLit) Pos() lex.Pos    { return e.At }
+func (e *Prefix) Pos() lex.Pos     { return e.At }
+func (e *Infix) Pos() lex.Pos      { return e.At }
+func (e *Block) Pos() lex.Pos      { return e.At }
+func (e *IfExpr) Pos() lex.Pos     { return e.At }
+func (e *FuncLit) Pos() lex.Pos    { return e.At }
+func (e *Call) Pos() lex.Pos       { return e.At }
+
+// String methods print the tree back as fully parenthesised source,
+// which makes precedence visible.
+
+func (p *Program) String() string {
+	var b strings.Builder
+	for _, s := range p.Stmts {
+		b.WriteString(s.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (s *LetStmt) String() string { return "let " + s.Name + " = " + s.Value.String() + ";" }
+
+func (s *ReturnStmt) String() string {
+	if s.Value == nil {
+		return "return;"
+	}
+	return "return " + s.Value.String() + ";"
+}
+
+func (s *ExprStmt) String() string { return s.X.String() + ";" }
+func (e *Ident) String() string    { return e.Name }
+func (e *IntLit) String() string   { return strconv.FormatInt(e.Value, 10) }
+func (e *StringLit) String() string {
+	return strconv.Quote(e.Value)
+}
+func (e *BoolLit) String() string { return strconv.FormatBool(e.Value) }
+func (e *Prefix) String() string  { return "(" + e.Op + e.X.String() + ")" }
+func (e *Infix) String() string {
+	return "(" + e.X.String() + " " + e.Op + " " + e.Y.String() + ")"
+}
+
+func (e *Block) String() string {
+	parts := make([]string, len(e.Stmts))
+	for i, s := range e.Stmts {
+		parts[i] = s.String()
+	}
+	return "{ " + strings.Join(parts, " ") + " }"
+}
+
+func (e *IfExpr) String() string {
+	s := "if (" + e.Cond.String() + ") " + e.Then.String()
+	if e.Else != nil {
+		s += " else " + e.Else.String()
+	}
+	return s
+}
+
+func (e *FuncLit) String() string {
+	return "fn(" + strings.Join(e.Params, ", ") + ") " + e.Body.String()
+}
+
+func (e *Call) String() string {
+	args := make([]string, len(e.Args))
+	for i, a := range e.Args {
+		args[i] = a.String()
+	}
+	return e.Fn.String() + "(" + strings.Join(args, ", ") + ")"
+}
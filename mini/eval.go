@@ -0,0 +1,299 @@
+package mini
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/armaanepiic/Golang/lex"
+)
+
+// DefaultMaxDepth bounds call nesting so runaway recursion is an error
+// rather than a Go stack overflow.
+const DefaultMaxDepth = 10000
+
+// Interpreter runs programs against a persistent global scope, so a REPL
+// can feed it one line at a time.
+type Interpreter struct {
+	Out      io.Writer // where print writes; os.Stdout if nil
+	Globals  *Env
+	MaxDepth int
+
+	depth int
+}
+
+// New returns an interpreter whose globals hold the builtins.
+func New(out io.Writer) *Interpreter {
+	if out == nil {
+		out = os.Stdout
+	}
+	g := NewEnv(nil)
+	for _, b := range builtins {
+		g.Set(b.Name, b)
+	}
+	return &Interpreter{Out: out, Globals: g, MaxDepth: DefaultMaxDepth}
+}
+
+// Run parses and evaluates src, returning the value of the last
+// statement.
+func (in *Interpreter) Run(src string) (Value, error) {
+	prog, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return in.Eval(prog)
+}
+
+// Eval evaluates prog in the global scope. A top-level return ends the
+// program with its value.
+func (in *Interpreter) Eval(prog *Program) (Value, error) {
+	in.depth = 0
+	v, err := in.stmts(prog.Stmts, in.Globals)
+	if r, ok := err.(*returnSignal); ok {
+		return r.v, nil
+	}
+	return v, err
+}
+
+// returnSignal travels up the error path from a return statement to the
+// enclosing call.
+type returnSignal struct{ v Value }
+
+func (*returnSignal) Error() string { return "return outside function" }
+
+func errorf(p lex.Pos, format string, args ...any) error {
+	return &Error{p, fmt.Sprintf(format, args...)}
+}
+
+func (in *Interpreter) stmts(list []Stmt, env *Env) (Value, error) {
+	var last Value = Nil
+	for _, s := range list {
+		v, err := in.stmt(s, env)
+		if err != nil {
+			return nil, err
+		}
+		last = v
+	}
+	return last, nil
+}
+
+func (in *Interpreter) stmt(s Stmt, env *Env) (Value, error) {
+	switch s := s.(type) {
+	case *LetStmt:
+		v, err := in.expr(s.Value, env)
+		if err != nil {
+			return nil, err
+		}
+		env.Set(s.Name, v)
+		return Nil, nil
+	case *ReturnStmt:
+		var v Value = Nil
+		if s.Value != nil {
+			var err error
+			if v, err = in.expr(s.Value, env); err != nil {
+				return nil, err
+			}
+		}
+		return nil, &returnSignal{v}
+	case *ExprStmt:
+		return in.expr(s.X, env)
+	}
+	return nil, errorf(s.Pos(), "unknown statement %T", s)
+}
+
+func (in *Interpreter) expr(e Expr, env *Env) (Value, error) {
+	switch e := e.(type) {
+	case *IntLit:
+		return Int(e.Value), nil
+	case *StringLit:
+		return String(e.Value), nil
+	case *BoolLit:
+		return Bool(e.Value), nil
+	case *Ident:
+		if v, ok := env.Get(e.Name); ok {
+			return v, nil
+		}
+		return nil, errorf(e.At, "undefined: %s", e.Name)
+	case *Prefix:
+		return in.prefix(e, env)
+	case *Infix:
+		return in.infix(e, env)
+	case *Block:
+		return in.stmts(e.Stmts, NewEnv(env))
+	case *IfExpr:
+		cond, err := in.expr(e.Cond, env)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := cond.(Bool)
+		if !ok {
+			return nil, errorf(e.Cond.Pos(), "if condition is %s, not bool", cond.Type())
+		}
+		if b {
+			return in.expr(e.Then, env)
+		}
+		if e.Else != nil {
+			return in.expr(e.Else, env)
+		}
+		return Nil, nil
+	case *FuncLit:
+		return &Func{Lit: e, Env: env}, nil
+	case *Call:
+		return in.call(e, env)
+	}
+	return nil, errorf(e.Pos(), "unknown expression %T", e)
+}
+
+func (in *Interpreter) prefix(e *Prefix, env *Env) (Value, error) {
+	x, err := in.expr(e.X, env)
+	if err != nil {
+		return nil, err
+	}
+	switch v := x.(type) {
+	case Int:
+		if e.Op == "-" {
+			return -v, nil
+		}
+	case Bool:
+		if e.Op == "!" {
+			return !v, nil
+		}
+	}
+	return nil, errorf(e.At, "invalid operation: %s%s", e.Op, x.Type())
+}
+
+func (in *Interpreter) infix(e *Infix, env *Env) (Value, error) {
+	x, err := in.expr(e.X, env)
+	if err != nil {
+		return nil, err
+	}
+	if e.Op == "&&" || e.Op == "||" {
+		return in.logical(e, x, env)
+	}
+	y, err := in.expr(e.Y, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch a := x.(type) {
+	case Int:
+		if b, ok := y.(Int); ok {
+			return intOp(e, a, b)
+		}
+	case String:
+		if b, ok := y.(String); ok {
+			switch e.Op {
+			case "+":
+				return a + b, nil
+			case "<":
+				return Bool(a < b), nil
+			case ">":
+				return Bool(a > b), nil
+			case "<=":
+				return Bool(a <= b), nil
+			case ">=":
+				return Bool(a >= b), nil
+			}
+		}
+	}
+	// values of different types are never equal
+	switch e.Op {
+	case "==":
+		return Bool(x == y), nil
+	case "!=":
+		return Bool(x != y), nil
+	}
+	return nil, errorf(e.At, "invalid operation: %s %s %s", x.Type(), e.Op, y.Type())
+}
+
+func (in *Interpreter) logical(e *Infix, x Value, env *Env) (Value, error) {
+	a, ok := x.(Bool)
+	if !ok {
+		return nil, errorf(e.At, "invalid operation: %s %s", x.Type(), e.Op)
+	}
+	if e.Op == "&&" && !bool(a) || e.Op == "||" && bool(a) {
+		return a, nil
+	}
+	y, err := in.expr(e.Y, env)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := y.(Bool); !ok {
+		return nil, errorf(e.At, "invalid operation: bool %s %s", e.Op, y.Type())
+	}
+	return y, nil
+}
+
+func intOp(e *Infix, a, b Int) (Value, error) {
+	switch e.Op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/", "%":
+		if b == 0 {
+			return nil, errorf(e.At, "division by zero")
+		}
+		if e.Op == "/" {
+			return a / b, nil
+		}
+		return a % b, nil
+	case "==":
+		return Bool(a == b), nil
+	case "!=":
+		return Bool(a != b), nil
+	case "<":
+		return Bool(a < b), nil
+	case ">":
+		return Bool(a > b), nil
+	case "<=":
+		return Bool(a <= b), nil
+	case ">=":
+		return Bool(a >= b), nil
+	}
+	return nil, errorf(e.At, "invalid operation: int %s int", e.Op)
+}
+
+func (in *Interpreter) call(e *Call, env *Env) (Value, error) {
+	fn, err := in.expr(e.Fn, env)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]Value, len(e.Args))
+	for i, a := range e.Args {
+		if args[i], err = in.expr(a, env); err != nil {
+			return nil, err
+		}
+	}
+
+	switch f := fn.(type) {
+	case *Builtin:
+		v, err := f.Fn(in, args)
+		if err != nil {
+			return nil, &Error{e.At, err.Error()}
+		}
+		return v, nil
+	case *Func:
+		if len(args) != len(f.Lit.Params) {
+			return nil, errorf(e.At, "%s called with %d arguments, want %d", e.Fn, len(args), len(f.Lit.Params))
+		}
+		if in.depth >= in.MaxDepth {
+			return nil, errorf(e.At, "call depth exceeds %d", in.MaxDepth)
+		}
+		in.depth++
+		defer func() { in.depth-- }()
+
+		local := NewEnv(f.Env)
+		for i, p := range f.Lit.Params {
+			local.Set(p, args[i])
+		}
+		v, err := in.stmts(f.Lit.Body.Stmts, local)
+		if r, ok := err.(*returnSignal); ok {
+			return r.v, nil
+		}
+		return v, err
+	}
+	return nil, errorf(e.At, "cannot call %s", fn.Type())
+}
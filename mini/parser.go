@@ -0,0 +1,287 @@
+package mini
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/armaanepiic/Golang/lex"
+)
+
+// Error is a syntax or runtime error with the position it happened at.
+type Error struct {
+	Pos lex.Pos
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+var syntax = lex.Config{
+	Keywords: []string{"let", "fn", "if", "else", "return", "true", "false"},
+	Operators: []string{
+		"==", "!=", "<=", ">=", "&&", "||",
+		"+", "-", "*", "/", "%", "<", ">", "!", "=",
+		"(", ")", "{", "}", ",", ";",
+	},
+	LineComment: "//",
+}
+
+// binding powers for infix operators; calls bind tightest
+var infix = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, ">": 4, "<=": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+const (
+	prefixPower = 7
+	callPower   = 8
+)
+
+type parser struct {
+	toks []lex.Token
+	i    int
+	errs []error
+}
+
+// bail unwinds the parser to the statement level after an error.
+type bail struct{}
+
+// Parse parses a program. If there are syntax errors it returns all of
+// them, joined, with a nil program.
+func Parse(src string) (*Program, error) {
+	l := lex.New(src, syntax)
+	p := &parser{toks: l.All()}
+	for _, e := range l.Errors() {
+		p.errs = append(p.errs, &Error{e.Pos, e.Msg})
+	}
+	prog := &Program{}
+	for p.peek().Kind != lex.EOF {
+		if s := p.statement(); s != nil {
+			prog.Stmts = append(prog.Stmts, s)
+		}
+	}
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+	return prog, nil
+}
+
+func (p *parser) peek() lex.Token { return p.toks[p.i] }
+
+func (p *parser) next() lex.Token {
+	t := p.toks[p.i]
+	if t.Kind != lex.EOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) fail(t lex.Token, format string, args ...any) {
+	p.errs = append(p.errs, &Error{t.Pos, fmt.Sprintf(format, args...)})
+	panic(bail{})
+}
+
+// expect consumes the operator or keyword op. Like every failure path it
+// leaves the offending token unread, so recovery can stop at it.
+func (p *parser) expect(op string) lex.Token {
+	if t := p.peek(); !t.Is(op) {
+		p.fail(t, "expected %q, found %s", op, describe(t))
+	}
+	return p.next()
+}
+
+func (p *parser) ident(what string) string {
+	t := p.peek()
+	if t.Kind != lex.Ident {
+		p.fail(t, "expected %s, found %s", what, describe(t))
+	}
+	return p.next().Text
+}
+
+func describe(t lex.Token) string {
+	if t.Kind == lex.EOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.Raw)
+}
+
+// statement parses one statement. On a syntax error it records it, skips
+// past the next ; or } and returns nil, so later errors are found too.
+func (p *parser) statement() (s Stmt) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(bail); !ok {
+				panic(r)
+			}
+			for t := p.peek(); t.Kind != lex.EOF; t = p.peek() {
+				p.next()
+				if t.Is(";") || t.Is("}") {
+					break
+				}
+			}
+			s = nil
+		}
+	}()
+
+	t := p.peek()
+	switch {
+	case t.Is(";"):
+		p.next() // empty statement
+		return nil
+	case t.Is("let"):
+		p.next()
+		name := p.ident("a name after let")
+		p.expect("=")
+		s = &LetStmt{At: t.Pos, Name: name, Value: p.expr(0)}
+	case t.Is("return"):
+		p.next()
+		rs := &ReturnStmt{At: t.Pos}
+		if n := p.peek(); !n.Is(";") && !n.Is("}") && n.Kind != lex.EOF {
+			rs.Value = p.expr(0)
+		}
+		s = rs
+	default:
+		s = &ExprStmt{X: p.expr(0)}
+	}
+	p.endStatement(s)
+	return s
+}
+
+// endStatement requires a ; unless the statement is followed by } or the
+// end of input, or ends with a block itself (if, fn).
+func (p *parser) endStatement(s Stmt) {
+	t := p.peek()
+	switch {
+	case t.Is(";"):
+		p.next()
+	case t.Is("}"), t.Kind == lex.EOF:
+	case p.toks[p.i-1].Is("}"):
+	default:
+		p.fail(t, "expected ; after statement, found %s", describe(t))
+	}
+}
+
+// expr is a Pratt parser: it parses operators that bind tighter than
+// minPower.
+func (p *parser) expr(minPower int) Expr {
+	left := p.prefix()
+	for {
+		t := p.peek()
+		if t.Is("(") && callPower > minPower {
+			left = p.call(left)
+			continue
+		}
+		power, ok := infix[t.Text]
+		if t.Kind != lex.Op || !ok || power <= minPower {
+			return left
+		}
+		p.next()
+		left = &Infix{At: t.Pos, Op: t.Text, X: left, Y: p.expr(power)}
+	}
+}
+
+func (p *parser) prefix() Expr {
+	switch t := p.peek(); {
+	case t.Kind == lex.EOF:
+		p.fail(t, "unexpected end of input")
+	case t.Is("{"):
+		return p.block()
+	}
+	t := p.next()
+	switch {
+	case t.Kind == lex.Illegal:
+		panic(bail{}) // already reported by the lexer
+	case t.Kind == lex.Int:
+		n, err := strconv.ParseInt(t.Text, 10, 64)
+		if err != nil {
+			p.fail(t, "integer %s out of range", t.Text)
+		}
+		return &IntLit{At: t.Pos, Value: n}
+	case t.Kind == lex.Float:
+		p.fail(t, "only integers are supported")
+	case t.Kind == lex.String:
+		return &StringLit{At: t.Pos, Value: t.Text}
+	case t.Kind == lex.Ident:
+		return &Ident{At: t.Pos, Name: t.Text}
+	case t.Is("true"), t.Is("false"):
+		return &BoolLit{At: t.Pos, Value: t.Text == "true"}
+	case t.Is("-"), t.Is("!"):
+		return &Prefix{At: t.Pos, Op: t.Text, X: p.expr(prefixPower)}
+	case t.Is("("):
+		x := p.expr(0)
+		p.expect(")")
+		return x
+	case t.Is("if"):
+		return p.ifExpr(t)
+	case t.Is("fn"):
+		return p.funcLit(t)
+	}
+	p.i-- // leave t unread so recovery can stop at a ; or }
+	p.fail(t, "unexpected %s", describe(t))
+	return nil
+}
+
+func (p *parser) block() *Block {
+	open := p.expect("{")
+	b := &Block{At: open.Pos}
+	for !p.peek().Is("}") {
+		if p.peek().Kind == lex.EOF {
+			p.fail(p.peek(), "missing } for block opened at %s", open.Pos)
+		}
+		if s := p.statement(); s != nil {
+			b.Stmts = append(b.Stmts, s)
+		}
+	}
+	p.next()
+	return b
+}
+
+func (p *parser) ifExpr(t lex.Token) Expr {
+	p.expect("(")
+	e := &IfExpr{At: t.Pos, Cond: p.expr(0)}
+	p.expect(")")
+	e.Then = p.block()
+	if p.peek().Is("else") {
+		p.next()
+		if n := p.peek(); n.Is("if") {
+			p.next()
+			e.Else = p.ifExpr(n)
+		} else {
+			e.Else = p.block()
+		}
+	}
+	return e
+}
+
+func (p *parser) funcLit(t lex.Token) Expr {
+	f := &FuncLit{At: t.Pos}
+	p.expect("(")
+	for !p.peek().Is(")") {
+		if len(f.Params) > 0 {
+			p.expect(",")
+		}
+		f.Params = append(f.Params, p.ident("a parameter name"))
+	}
+	p.next()
+	f.Body = p.block()
+	return f
+}
+
+func (p *parser) call(fn Expr) Expr {
+	open := p.expect("(")
+	c := &Call{At: open.Pos, Fn: fn}
+	for !p.peek().Is(")") {
+		if len(c.Args) > 0 {
+			p.expect(",")
+		}
+		c.Args = append(c.Args, p.expr(0))
+	}
+	p.next()
+	return c
+}
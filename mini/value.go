@@ -0,0 +1,127 @@
+package mini
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Value is a runtime value: Int, String, Bool, *Func, *Builtin or Nil.
+type Value interface {
+	Type() string
+	String() string
+}
+
+type (
+	Int    int64
+	String string
+	Bool   bool
+	// Func is a closure: a function literal plus the environment it was
+	// created in.
+	Func struct {
+		Lit *FuncLit
+		Env *Env
+	}
+	// Builtin is a function implemented in Go.
+	Builtin struct {
+		Name string
+		Fn   func(in *Interpreter, args []Value) (Value, error)
+	}
+	nilValue struct{}
+)
+
+// Nil is the value of statements, bare returns and if without else.
+var Nil Value = nilValue{}
+
+func (Int) Type() string      { return "int" }
+func (String) Type() string   { return "string" }
+func (Bool) Type() string     { return "bool" }
+func (*Func) Type() string    { return "fn" }
+func (*Builtin) Type() string { return "builtin" }
+func (nilValue) Type() string { return "nil" }
+
+func (v Int) String() string      { return strconv.FormatInt(int64(v), 10) }
+func (v String) String() string   { return string(v) }
+func (v Bool) String() string     { return strconv.FormatBool(bool(v)) }
+func (f *Func) String() string    { return "fn(" + strings.Join(f.Lit.Params, ", ") + ")" }
+func (b *Builtin) String() string { return "builtin " + b.Name }
+func (nilValue) String() string   { return "nil" }
+
+// Inspect formats v the way the REPL shows results: strings quoted.
+func Inspect(v Value) string {
+	if s, ok := v.(String); ok {
+		return strconv.Quote(string(s))
+	}
+	return v.String()
+}
+
+// Env is a lexical scope.
+type Env struct {
+	vars   map[string]Value
+	parent *Env
+}
+
+// NewEnv returns a scope nested in parent, which may be nil.
+func NewEnv(parent *Env) *Env {
+	return &Env{vars: make(map[string]Value), parent: parent}
+}
+
+// Get looks name up in e and its parents.
+func (e *Env) Get(name string) (Value, bool) {
+	for ; e != nil; e = e.parent {
+		if v, ok := e.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Set binds name in e itself, shadowing any outer binding.
+func (e *Env) Set(name string, v Value) {
+	e.vars[name] = v
+}
+
+var builtins = []*Builtin{
+	{"print", func(in *Interpreter, args []Value) (Value, error) {
+		parts := make([]string, len(args))
+		for i, a := range args {
+			parts[i] = a.String()
+		}
+		_, err := io.WriteString(in.Out, strings.Join(parts, " ")+"\n")
+		return Nil, err
+	}},
+	{"len", func(_ *Interpreter, args []Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("len takes 1 argument, got %d", len(args))
+		}
+		s, ok := args[0].(String)
+		if !ok {
+			return nil, fmt.Errorf("len of %s", args[0].Type())
+		}
+		return Int(utf8.RuneCountInString(string(s))), nil
+	}},
+	{"str", func(_ *Interpreter, args []Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("str takes 1 argument, got %d", len(args))
+		}
+		return String(args[0].String()), nil
+	}},
+	{"int", func(_ *Interpreter, args []Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("int takes 1 argument, got %d", len(args))
+		}
+		switch v := args[0].(type) {
+		case Int:
+			return v, nil
+		case String:
+			n, err := strconv.ParseInt(strings.TrimSpace(string(v)), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("int: %q is not an integer", string(v))
+			}
+			return Int(n), nil
+		}
+		return nil, fmt.Errorf("int of %s", args[0].Type())
+	}},
+}
@@ -0,0 +1,130 @@
+// Package tcpecho is a line based TCP echo server and client.
+//
+// Every line a client sends is written straight back. The server handles
+// each connection on its own goroutine and drops connections that stay
+// idle longer than the read timeout.
+package tcpecho
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"time"
+)
+
+// Server echoes lines back to its clients.
+type Server struct {
+	// ReadTimeout closes a connection that sends nothing for this long.
+	// Zero means connections may stay idle forever.
+	ReadTimeout time.Duration
+
+	mu       sync.Mutex
+	ln       net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+	handlers sync.WaitGroup
+}
+
+// ListenAndServe listens on addr ("127.0.0.1:0" picks a free port) and
+// serves until Close is called. Use Addr to learn the chosen port.
+func (s *Server) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ln)
+}
+
+// Serve accepts connections on ln until Close is called.
+func (s *Server) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		ln.Close()
+		return net.ErrClosed
+	}
+	s.ln = ln
+	s.conns = make(map[net.Conn]struct{})
+	s.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return net.ErrClosed
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			// Close ran between Accept and here and has already swept
+			// s.conns; tracking conn now would leak it.
+			s.mu.Unlock()
+			conn.Close()
+			return net.ErrClosed
+		}
+		s.conns[conn] = struct{}{}
+		s.handlers.Add(1)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+// Addr returns the listening address, or nil before Serve is running.
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ln == nil {
+		return nil
+	}
+	return s.ln.Addr()
+}
+
+// Close stops the listener, closes every open connection and waits for
+// their goroutines to exit.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	var err error
+	if s.ln != nil {
+		err = s.ln.Close()
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+
+	s.handlers.Wait()
+	return err
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		s.handlers.Done()
+	}()
+
+	r := bufio.NewReader(conn)
+	for {
+		if s.ReadTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(s.ReadTimeout))
+		}
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, werr := conn.Write(line); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return // EOF, idle timeout or closed by Close
+		}
+	}
+}
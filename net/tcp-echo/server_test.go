@@ -0,0 +1,156 @@
+package tcpecho
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// start serves on a random localhost port and closes the server when the
+// test ends.
+func start(t *testing.T, s *Server) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ln) }()
+	t.Cleanup(func() {
+		s.Close()
+		if err := <-done; !errors.Is(err, net.ErrClosed) {
+			t.Errorf("Serve = %v, want net.ErrClosed", err)
+		}
+	})
+	return ln.Addr().String()
+}
+
+func TestEcho(t *testing.T) {
+	addr := start(t, &Server{})
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := Dial(addr, time.Second)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer c.Close()
+			for _, msg := range []string{"hello", "", "client " + string(rune('a'+i))} {
+				got, err := c.Echo(msg)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if got != msg {
+					t.Errorf("Echo(%q) = %q", msg, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestEchoRejectsNewline(t *testing.T) {
+	addr := start(t, &Server{})
+	c, err := Dial(addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.Echo("a\nb"); err == nil {
+		t.Fatal("Echo with a newline succeeded")
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	addr := start(t, &Server{ReadTimeout: 50 * time.Millisecond})
+	c, err := Dial(addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	time.Sleep(200 * time.Millisecond)
+	if _, err := c.Echo("late"); err == nil {
+		t.Fatal("Echo on an idle connection succeeded, want the server to have closed it")
+	}
+}
+
+func TestCloseDropsClients(t *testing.T) {
+	s := &Server{}
+	addr := start(t, s)
+	c, err := Dial(addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.Echo("ping"); err != nil {
+		t.Fatal(err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return while a client was connected")
+	}
+	if _, err := c.Echo("ping"); err == nil {
+		t.Fatal("Echo after Close succeeded")
+	}
+}
+
+// TestCloseWhileDialing closes the server while clients keep connecting.
+// A connection accepted just as Close runs must be closed rather than
+// left open, or Close would return with a handler still running.
+func TestCloseWhileDialing(t *testing.T) {
+	for range 20 {
+		s := &Server{}
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := make(chan error, 1)
+		go func() { done <- s.Serve(ln) }()
+
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					if c, err := Dial(ln.Addr().String(), time.Second); err == nil {
+						c.Close()
+					}
+				}
+			}()
+		}
+		time.Sleep(5 * time.Millisecond)
+		s.Close()
+		close(stop)
+		wg.Wait()
+		if err := <-done; !errors.Is(err, net.ErrClosed) {
+			t.Fatalf("Serve = %v, want net.ErrClosed", err)
+		}
+		s.mu.Lock()
+		n := len(s.conns)
+		s.mu.Unlock()
+		if n != 0 {
+			t.Fatalf("%d connections still tracked after Close", n)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package udp implements a tiny request/response ping protocol over UDP.
+//
+// A ping is a 12 byte datagram: the magic "PING", then a big-endian uint64
+// sequence number. The server answers with "PONG" and the same sequence
+// number. Because UDP may lose or reorder datagrams, the client matches
+// replies by sequence number and gives up on a ping after a timeout.
+package udp
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+)
+
+const packetSize = 12
+
+var (
+	magicPing = [4]byte{'P', 'I', 'N', 'G'}
+	magicPong = [4]byte{'P', 'O', 'N', 'G'}
+)
+
+func encode(magic [4]byte, seq uint64) []byte {
+	b := make([]byte, packetSize)
+	copy(b, magic[:])
+	binary.BigEndian.PutUint64(b[4:], seq)
+	return b
+}
+
+func decode(b []byte, magic [4]byte) (uint64, bool) {
+	if len(b) != packetSize || [4]byte(b[:4]) != magic {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(b[4:]), true
+}
+
+// Server answers pings. Set Loss to simulate an unreliable network.
+type Server struct {
+	// Loss is the probability in [0, 1] that a ping is silently dropped.
+	Loss float64
+
+	// Rand supplies randomness for Loss; nil uses math/rand/v2.
+	Rand *rand.Rand
+
+	mu   sync.Mutex
+	conn net.PacketConn
+}
+
+// Serve answers pings arriving on conn until it is closed.
+func (s *Server) Serve(conn net.PacketConn) error {
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	buf := make([]byte, 64)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		seq, ok := decode(buf[:n], magicPing)
+		if !ok || s.drop() {
+			continue
+		}
+		conn.WriteTo(encode(magicPong, seq), addr)
+	}
+}
+
+// Close stops Serve.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+func (s *Server) drop() bool {
+	if s.Loss <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Rand != nil {
+		return s.Rand.Float64() < s.Loss
+	}
+	return rand.Float64() < s.Loss
+}
+
+// Ping sends count pings to addr, one every interval, and waits up to
+// timeout for each reply. Late replies to earlier pings are ignored.
+func Ping(addr string, count int, interval, timeout time.Duration) (*Stats, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	st := &Stats{}
+	buf := make([]byte, 64)
+	for seq := uint64(1); seq <= uint64(count); seq++ {
+		start := time.Now()
+		if _, err := conn.Write(encode(magicPing, seq)); err != nil {
+			return st, err
+		}
+		st.Sent++
+
+		conn.SetReadDeadline(start.Add(timeout))
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				var ne net.Error
+				if errors.As(err, &ne) && ne.Timeout() {
+					break // lost
+				}
+				return st, err
+			}
+			if got, ok := decode(buf[:n], magicPong); ok && got == seq {
+				st.RTTs = append(st.RTTs, time.Since(start))
+				break
+			}
+		}
+
+		if seq < uint64(count) {
+			time.Sleep(max(0, interval-time.Since(start)))
+		}
+	}
+	return st, nil
+}
@@ -0,0 +1,96 @@
+package udp
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Stats summarises a ping session.
+type Stats struct {
+	Sent int
+	RTTs []time.Duration // one per received reply, in send order
+}
+
+// Received returns the number of pings that got an answer.
+func (s *Stats) Received() int { return len(s.RTTs) }
+
+// Loss returns the fraction of pings without an answer.
+func (s *Stats) Loss() float64 {
+	if s.Sent == 0 {
+		return 0
+	}
+	return float64(s.Sent-s.Received()) / float64(s.Sent)
+}
+
+// Bucket is one bar of a latency histogram: RTTs below Upper (and at or
+// above the previous bucket's Upper).
+type Bucket struct {
+	Upper time.Duration
+	Count int
+}
+
+// Histogram sorts the RTTs into buckets with the given upper bounds. A
+// final bucket with Upper = -1 collects everything above the last bound.
+func (s *Stats) Histogram(bounds ...time.Duration) []Bucket {
+	bounds = slices.Sorted(slices.Values(bounds))
+	buckets := make([]Bucket, len(bounds)+1)
+	for i, b := range bounds {
+		buckets[i].Upper = b
+	}
+	buckets[len(bounds)].Upper = -1
+
+	for _, rtt := range s.RTTs {
+		i, _ := slices.BinarySearchFunc(bounds, rtt, func(b, rtt time.Duration) int {
+			if b <= rtt {
+				return -1
+			}
+			return 1
+		})
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+// DefaultBounds are the histogram buckets used by Report.
+var DefaultBounds = []time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+}
+
+// Report writes a ping(8) style summary and a latency histogram to w.
+func (s *Stats) Report(w io.Writer) {
+	fmt.Fprintf(w, "%d sent, %d received, %.1f%% loss\n", s.Sent, s.Received(), s.Loss()*100)
+	if s.Received() == 0 {
+		return
+	}
+
+	sorted := slices.Clone(s.RTTs)
+	slices.Sort(sorted)
+	var total time.Duration
+	for _, r := range sorted {
+		total += r
+	}
+	fmt.Fprintf(w, "rtt min/avg/max = %v/%v/%v\n",
+		sorted[0], total/time.Duration(len(sorted)), sorted[len(sorted)-1])
+
+	prev := time.Duration(0)
+	for _, b := range s.Histogram(DefaultBounds...) {
+		label := fmt.Sprintf("%v-%v", prev, b.Upper)
+		if b.Upper < 0 {
+			label = fmt.Sprintf(">=%v", prev)
+		}
+		fmt.Fprintf(w, "%14s | %-40s %d\n", label, strings.Repeat("#", barLen(b.Count, len(sorted))), b.Count)
+		prev = b.Upper
+	}
+}
+
+func barLen(n, total int) int {
+	return n * 40 / total
+}
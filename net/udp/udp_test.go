@@ -0,0 +1,128 @@
+package udp
+
+import (
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serve starts a Server with the given loss on a localhost port.
+func serve(t *testing.T, srv *Server) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(conn) }()
+	t.Cleanup(func() {
+		conn.Close()
+		if err := <-done; err != nil {
+			t.Errorf("Serve = %v", err)
+		}
+	})
+	return conn.LocalAddr().String()
+}
+
+func TestPingNoLoss(t *testing.T) {
+	addr := serve(t, &Server{})
+	st, err := Ping(addr, 5, time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Sent != 5 || st.Received() != 5 || st.Loss() != 0 {
+		t.Fatalf("sent %d, received %d, loss %v", st.Sent, st.Received(), st.Loss())
+	}
+	n := 0
+	for _, b := range st.Histogram(DefaultBounds...) {
+		n += b.Count
+	}
+	if n != 5 {
+		t.Errorf("histogram holds %d RTTs, want 5", n)
+	}
+}
+
+func TestPingTotalLoss(t *testing.T) {
+	addr := serve(t, &Server{Loss: 1})
+	st, err := Ping(addr, 3, 0, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Sent != 3 || st.Received() != 0 || st.Loss() != 1 {
+		t.Fatalf("sent %d, received %d, loss %v", st.Sent, st.Received(), st.Loss())
+	}
+	var b strings.Builder
+	st.Report(&b)
+	if b.String() != "3 sent, 0 received, 100.0% loss\n" {
+		t.Errorf("report = %q", b.String())
+	}
+}
+
+func TestPingPartialLoss(t *testing.T) {
+	addr := serve(t, &Server{Loss: 0.5, Rand: rand.New(rand.NewPCG(1, 2))})
+	st, err := Ping(addr, 20, 0, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Sent != 20 || st.Received() == 0 || st.Received() == 20 {
+		t.Fatalf("sent %d, received %d with 50%% loss", st.Sent, st.Received())
+	}
+}
+
+func TestServerIgnoresGarbage(t *testing.T) {
+	addr := serve(t, &Server{})
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.Write([]byte("hello"))
+	conn.Write(encode(magicPong, 1)) // a reply is not a request
+	conn.Write(encode(magicPing, 42))
+
+	buf := make([]byte, 64)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seq, ok := decode(buf[:n], magicPong); !ok || seq != 42 {
+		t.Fatalf("reply %q", buf[:n])
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	ms := time.Millisecond
+	st := &Stats{Sent: 7, RTTs: []time.Duration{0, ms / 2, ms, 3 * ms, 5 * ms, 9 * ms, time.Second}}
+	got := st.Histogram(5*ms, ms) // bounds are sorted
+	want := []Bucket{
+		{ms, 2},     // below 1ms
+		{5 * ms, 2}, // 1ms (a bound starts the next bucket) and 3ms
+		{-1, 3},     // 5ms and up
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Histogram = %v, want %v", got, want)
+	}
+	if st.Loss() != 0 || (&Stats{}).Loss() != 0 {
+		t.Error("Loss wrong")
+	}
+}
+
+func TestReport(t *testing.T) {
+	st := &Stats{Sent: 4, RTTs: []time.Duration{200 * time.Microsecond, 2 * time.Millisecond, 20 * time.Millisecond}}
+	var b strings.Builder
+	st.Report(&b)
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if lines[0] != "4 sent, 3 received, 25.0% loss" || lines[1] != "rtt min/avg/max = 200µs/7.4ms/20ms" {
+		t.Fatalf("report:\n%s", b.String())
+	}
+	if len(lines) != 2+len(DefaultBounds)+1 {
+		t.Fatalf("%d histogram rows", len(lines)-2)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(lines[3]), "100µs-250µs | "+strings.Repeat("#", 13)) {
+		t.Errorf("row = %q", lines[3])
+	}
+}
@@ -0,0 +1,144 @@
+// Package netdiag answers "why can't I reach that host?": it looks up DNS
+// records and times the TCP and TLS handshakes to a port.
+package netdiag
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Resolver is the part of *net.Resolver netdiag uses. Tests can pass a
+// fake.
+type Resolver interface {
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+var _ Resolver = (*net.Resolver)(nil)
+
+// Diag runs lookups and probes. The zero value uses net.DefaultResolver
+// and a 5 second timeout per step.
+type Diag struct {
+	Resolver Resolver
+	Timeout  time.Duration
+	Dial     func(ctx context.Context, network, addr string) (net.Conn, error) // nil = net.Dialer
+}
+
+func (d *Diag) resolver() Resolver {
+	if d.Resolver != nil {
+		return d.Resolver
+	}
+	return net.DefaultResolver
+}
+
+func (d *Diag) timeout() time.Duration {
+	if d.Timeout > 0 {
+		return d.Timeout
+	}
+	return 5 * time.Second
+}
+
+// Records holds the DNS records of a name. A lookup that failed leaves
+// its field empty and an entry in Errors keyed by record type.
+type Records struct {
+	A      []net.IP
+	AAAA   []net.IP
+	MX     []*net.MX
+	TXT    []string
+	Errors map[string]error
+}
+
+// Lookup fetches the A, AAAA, MX and TXT records of host. Each lookup has
+// its own timeout; a failure of one does not stop the others.
+func (d *Diag) Lookup(ctx context.Context, host string) Records {
+	res := d.resolver()
+	recs := Records{Errors: make(map[string]error)}
+
+	run := func(kind string, f func(ctx context.Context) error) {
+		ctx, cancel := context.WithTimeout(ctx, d.timeout())
+		defer cancel()
+		if err := f(ctx); err != nil && !isNotFound(err) {
+			recs.Errors[kind] = err
+		}
+	}
+	run("A", func(ctx context.Context) (err error) {
+		recs.A, err = res.LookupIP(ctx, "ip4", host)
+		return err
+	})
+	run("AAAA", func(ctx context.Context) (err error) {
+		recs.AAAA, err = res.LookupIP(ctx, "ip6", host)
+		return err
+	})
+	run("MX", func(ctx context.Context) (err error) {
+		recs.MX, err = res.LookupMX(ctx, host)
+		return err
+	})
+	run("TXT", func(ctx context.Context) (err error) {
+		recs.TXT, err = res.LookupTXT(ctx, host)
+		return err
+	})
+	return recs
+}
+
+// isNotFound reports whether err just means "no records of this type".
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// Probe is the result of connecting to one address.
+type Probe struct {
+	Addr    string        // ip:port that was dialled
+	Connect time.Duration // TCP handshake
+	TLS     time.Duration // TLS handshake, 0 if not attempted
+	Err     error
+}
+
+// ProbeHost resolves host and dials every address on port, timing the
+// TCP handshake and, if useTLS is set, the TLS handshake too.
+func (d *Diag) ProbeHost(ctx context.Context, host string, port int, useTLS bool) ([]Probe, error) {
+	lctx, cancel := context.WithTimeout(ctx, d.timeout())
+	ips, err := d.resolver().LookupIP(lctx, "ip", host)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	probes := make([]Probe, 0, len(ips))
+	for _, ip := range ips {
+		addr := net.JoinHostPort(ip.String(), strconv.Itoa(port))
+		probes = append(probes, d.probe(ctx, host, addr, useTLS))
+	}
+	return probes, nil
+}
+
+func (d *Diag) probe(ctx context.Context, host, addr string, useTLS bool) Probe {
+	p := Probe{Addr: addr}
+	ctx, cancel := context.WithTimeout(ctx, d.timeout())
+	defer cancel()
+
+	dial := d.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	start := time.Now()
+	conn, err := dial(ctx, "tcp", addr)
+	p.Connect = time.Since(start)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+	defer conn.Close()
+
+	if useTLS {
+		tc := tls.Client(conn, &tls.Config{ServerName: host})
+		start = time.Now()
+		p.Err = tc.HandshakeContext(ctx)
+		p.TLS = time.Since(start)
+	}
+	return p
+}
@@ -0,0 +1,227 @@
+package netdiag_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/netdiag"
+)
+
+// fakeResolver answers from maps; a name in slow blocks until the
+// context ends.
+type fakeResolver struct {
+	ips  map[string][]net.IP // key: network + " " + host
+	mx   map[string][]*net.MX
+	txt  map[string][]string
+	slow map[string]bool
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (f *fakeResolver) wait(ctx context.Context, name string) error {
+	if f.slow[name] {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (f *fakeResolver) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	if err := f.wait(ctx, host); err != nil {
+		return nil, err
+	}
+	if ips, ok := f.ips[network+" "+host]; ok {
+		return ips, nil
+	}
+	return nil, notFound(host)
+}
+
+func (f *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if err := f.wait(ctx, name); err != nil {
+		return nil, err
+	}
+	if mx, ok := f.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, notFound(name)
+}
+
+func (f *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if err := f.wait(ctx, name); err != nil {
+		return nil, err
+	}
+	if txt, ok := f.txt[name]; ok {
+		return txt, nil
+	}
+	return nil, errors.New("server misbehaving")
+}
+
+var resolver = &fakeResolver{
+	ips: map[string][]net.IP{
+		"ip4 example.test": {net.IPv4(192, 0, 2, 1)},
+		"ip6 example.test": {net.ParseIP("2001:db8::1")},
+		"ip4 v4only.test":  {net.IPv4(192, 0, 2, 2)},
+		"ip local.test":    {net.IPv4(127, 0, 0, 1)},
+	},
+	mx:   map[string][]*net.MX{"example.test": {{Host: "mail.example.test.", Pref: 10}}},
+	txt:  map[string][]string{"example.test": {"v=spf1 -all"}, "v4only.test": nil},
+	slow: map[string]bool{"slow.test": true},
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		host   string
+		want   netdiag.Records
+		errors []string // record types that failed
+	}{
+		{
+			host: "example.test",
+			want: netdiag.Records{
+				A:    []net.IP{net.IPv4(192, 0, 2, 1)},
+				AAAA: []net.IP{net.ParseIP("2001:db8::1")},
+				MX:   []*net.MX{{Host: "mail.example.test.", Pref: 10}},
+				TXT:  []string{"v=spf1 -all"},
+			},
+		},
+		// missing records are not errors
+		{host: "v4only.test", want: netdiag.Records{A: []net.IP{net.IPv4(192, 0, 2, 2)}}},
+		// a failing TXT lookup is reported, the rest still run
+		{host: "nothing.test", errors: []string{"TXT"}},
+		// every lookup hits its own timeout
+		{host: "slow.test", errors: []string{"A", "AAAA", "MX", "TXT"}},
+	}
+	d := &netdiag.Diag{Resolver: resolver, Timeout: 20 * time.Millisecond}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			got := d.Lookup(context.Background(), tt.host)
+			errs := got.Errors
+			got.Errors = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records %+v, want %+v", got, tt.want)
+			}
+			if len(errs) != len(tt.errors) {
+				t.Fatalf("errors %v, want failures of %v", errs, tt.errors)
+			}
+			for _, kind := range tt.errors {
+				if errs[kind] == nil {
+					t.Errorf("no error for %s", kind)
+				}
+			}
+			if tt.host == "slow.test" && !errors.Is(errs["A"], context.DeadlineExceeded) {
+				t.Errorf("slow lookup error = %v, want the timeout", errs["A"])
+			}
+		})
+	}
+}
+
+func TestLookupCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d := &netdiag.Diag{Resolver: resolver, Timeout: time.Minute}
+	start := time.Now()
+	recs := d.Lookup(ctx, "slow.test")
+	if time.Since(start) > 5*time.Second || !errors.Is(recs.Errors["MX"], context.Canceled) {
+		t.Fatalf("cancelled lookup: %v after %v", recs.Errors, time.Since(start))
+	}
+}
+
+func port(t *testing.T, addr string) int {
+	t.Helper()
+	_, p, _ := net.SplitHostPort(addr)
+	n, err := strconv.Atoi(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestProbeHost(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			c.Close()
+		}
+	}()
+
+	d := &netdiag.Diag{Resolver: resolver, Timeout: time.Second}
+	probes, err := d.ProbeHost(context.Background(), "local.test", port(t, ln.Addr().String()), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(probes) != 1 {
+		t.Fatalf("%d probes", len(probes))
+	}
+	p := probes[0]
+	if p.Err != nil || p.Addr != ln.Addr().String() || p.Connect <= 0 || p.TLS != 0 {
+		t.Fatalf("probe %+v", p)
+	}
+
+	if _, err := d.ProbeHost(context.Background(), "nothing.test", 80, false); err == nil {
+		t.Error("probe of an unresolvable host succeeded")
+	}
+}
+
+func TestProbeRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close() // nothing listens there now
+
+	d := &netdiag.Diag{Resolver: resolver, Timeout: time.Second}
+	probes, err := d.ProbeHost(context.Background(), "local.test", port(t, addr), false)
+	if err != nil || len(probes) != 1 || probes[0].Err == nil {
+		t.Fatalf("probes %+v, %v", probes, err)
+	}
+}
+
+func TestProbeTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	// the test certificate is self-signed, so the handshake runs and
+	// fails verification
+	d := &netdiag.Diag{Resolver: resolver, Timeout: time.Second}
+	probes, err := d.ProbeHost(context.Background(), "local.test", port(t, srv.Listener.Addr().String()), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := probes[0]
+	var unknown x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var hostErr x509.HostnameError
+	if p.TLS <= 0 || !(errors.As(p.Err, &unknown) || errors.As(p.Err, &invalid) || errors.As(p.Err, &hostErr)) {
+		t.Fatalf("probe %+v", p)
+	}
+}
+
+func TestCustomDial(t *testing.T) {
+	dialed := ""
+	d := &netdiag.Diag{Resolver: resolver, Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = network + " " + addr
+		return nil, errors.New("no network in this test")
+	}}
+	probes, err := d.ProbeHost(context.Background(), "local.test", 443, false)
+	if err != nil || dialed != "tcp 127.0.0.1:443" || probes[0].Err == nil {
+		t.Fatalf("dialed %q, probes %+v, %v", dialed, probes, err)
+	}
+}
@@ -0,0 +1,163 @@
+// Package notes pulls the explanatory /* ... */ comments out of the
+// example programs so they can be read as study pages, and renders them
+// as Markdown.
+//
+// Line comments and doc comments written with // are left alone: they
+// describe the code next to them, while the block comments are the
+// lesson notes (slice growth rules, compile and execution phases, ...).
+package notes
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Note is one block comment.
+type Note struct {
+	File  string // base name of the source file
+	Line  int    // line of the opening /*
+	Near  string // function or type the note is in or follows, if any
+	Title string // first non-blank line
+	Text  string // the comment without /* */, dedented
+}
+
+// Extract returns the block comments in one Go source file, in order.
+// src is passed to go/parser.ParseFile: nil reads filename.
+func Extract(filename string, src any) ([]Note, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("notes: %w", err)
+	}
+	var notes []Note
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			if !strings.HasPrefix(c.Text, "/*") {
+				continue
+			}
+			text := dedent(strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*"), "*/"))
+			if text == "" {
+				continue
+			}
+			title, _, _ := strings.Cut(text, "\n")
+			notes = append(notes, Note{
+				File:  filepath.Base(filename),
+				Line:  fset.Position(c.Pos()).Line,
+				Near:  near(f, c),
+				Title: strings.Join(strings.Fields(title), " "),
+				Text:  text,
+			})
+		}
+	}
+	return notes, nil
+}
+
+// ExtractDir returns the notes of every non-test .go file in dir, by file
+// name and then line.
+func ExtractDir(dir string) ([]Note, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	var all []Note
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		notes, err := Extract(file, nil)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, notes...)
+	}
+	return all, nil
+}
+
+// near names the declaration that contains c or, failing that, the last
+// one before it: the notes follow the code they explain.
+func near(f *ast.File, c *ast.Comment) string {
+	name := ""
+	for _, d := range f.Decls {
+		if d.Pos() > c.Pos() {
+			break
+		}
+		if n := declName(d); n != "" || c.End() <= d.End() {
+			name = n
+		}
+	}
+	return name
+}
+
+func declName(d ast.Decl) string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			return recvName(d.Recv.List[0].Type) + "." + d.Name.Name
+		}
+		return d.Name.Name
+	case *ast.GenDecl:
+		if d.Tok == token.TYPE && len(d.Specs) > 0 {
+			return d.Specs[0].(*ast.TypeSpec).Name.Name
+		}
+	}
+	return ""
+}
+
+func recvName(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.StarExpr:
+		return recvName(e.X)
+	case *ast.IndexExpr:
+		return recvName(e.X)
+	case *ast.IndexListExpr:
+		return recvName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return "?"
+}
+
+// dedent trims blank lines and trailing space, and removes the
+// indentation common to all non-blank lines.
+func dedent(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	prefix, first := "", true
+	for _, l := range lines {
+		if l == "" {
+			continue
+		}
+		indent := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		if first {
+			prefix, first = indent, false
+		} else {
+			prefix = commonPrefix(prefix, indent)
+		}
+	}
+	for i, l := range lines {
+		lines[i] = strings.TrimPrefix(l, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func commonPrefix(a, b string) string {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
@@ -0,0 +1,64 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Page is the study page for one topic.
+type Page struct {
+	Title    string // usually the topic name
+	Synopsis string
+	Notes    []Note
+}
+
+// Markdown renders the page: a section per source file and, inside it,
+// one per note with the note kept verbatim in a code block, since the
+// notes rely on tab alignment rather than Markdown.
+func (p Page) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", p.Title)
+	if p.Synopsis != "" {
+		fmt.Fprintf(&b, "%s\n\n", p.Synopsis)
+	}
+	file := ""
+	for _, n := range p.Notes {
+		if n.File != file {
+			file = n.File
+			fmt.Fprintf(&b, "## %s\n\n", file)
+		}
+		fmt.Fprintf(&b, "### %s\n\n", n.Title)
+		where := fmt.Sprintf("%s:%d", n.File, n.Line)
+		if n.Near != "" {
+			where += ", " + n.Near
+		}
+		fmt.Fprintf(&b, "*%s*\n\n", where)
+		fence := "```"
+		if strings.Contains(n.Text, fence) {
+			fence = "~~~"
+		}
+		fmt.Fprintf(&b, "%s\n%s\n%s\n\n", fence, n.Text, fence)
+	}
+	return b.String()
+}
+
+// Index renders a contents page linking to each page at link(p).
+func Index(title string, pages []Page, link func(Page) string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	for _, p := range pages {
+		fmt.Fprintf(&b, "- [%s](%s) — %d %s", p.Title, link(p), len(p.Notes), plural(len(p.Notes), "note"))
+		if p.Synopsis != "" {
+			b.WriteString(": " + p.Synopsis)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
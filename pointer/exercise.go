@@ -0,0 +1,11 @@
+package main
+
+// Exercise: implement Swap, then check it with
+//
+//	go run ./cmd/learn check pointer
+
+// Swap exchanges the values a and b point to. It does nothing if either
+// is nil.
+func Swap(a, b *int) {
+	// TODO: your code here
+}
@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/armaanepiic/Golang/internal/ptr"
+)
 
 // pointer
 
@@ -36,6 +40,11 @@ func main() {
 		Salary: 300.34,
 	}
 	printObj(&obj)
+
+	// &30 is not allowed; ptr.To copies the value and returns its address
+	age := ptr.To(30)
+	var missing *int
+	fmt.Println(*age, ptr.Deref(missing), ptr.Or(missing, 18))
 }
 
 /*
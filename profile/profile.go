@@ -0,0 +1,72 @@
+// Package profile writes pprof profiles of a piece of code and serves the
+// runtime's live profiles over HTTP.
+//
+// To see where an example's hot loop spends its time:
+//
+//	err := profile.CPU("cpu.pprof", func() { hotLoop() })
+//	...
+//	go tool pprof -top cpu.pprof
+//
+// and where it allocates:
+//
+//	err := profile.Heap("heap.pprof", func() { hotLoop() })
+//	go tool pprof -sample_index=alloc_space -top heap.pprof
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	rpprof "runtime/pprof"
+)
+
+// CPU runs fn with the CPU profiler on and writes the profile to path.
+// Only one CPU profile can be recorded at a time in a process.
+func CPU(path string, fn func()) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := rpprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return fmt.Errorf("profile: %w", err)
+	}
+	fn()
+	rpprof.StopCPUProfile()
+	return f.Close()
+}
+
+// Heap runs fn and writes a heap profile to path. The profile has both
+// what is still live after fn (inuse_space, inuse_objects) and everything
+// allocated since the program started (alloc_space, alloc_objects); pick
+// one with pprof's -sample_index.
+//
+// The runtime samples one allocation per runtime.MemProfileRate bytes
+// (512 KiB by default). Set it to 1 at the start of main to record every
+// allocation of a small program.
+func Heap(path string, fn func()) error {
+	fn()
+	runtime.GC() // the heap profile reflects the heap as of the last GC
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	return errors.Join(rpprof.WriteHeapProfile(f), f.Close())
+}
+
+// Register adds the net/http/pprof endpoints to mux under /debug/pprof/.
+// (Importing net/http/pprof also puts them on http.DefaultServeMux; the
+// servers in this repo use their own muxes, so that has no effect.) They
+// expose the program's internals and a CPU profile costs real CPU,
+// so serve mux only on a private address.
+func Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /debug/pprof/", pprof.Index) // also serves heap, goroutine, allocs, block, mutex, ...
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("POST /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+}
@@ -0,0 +1,91 @@
+// Profiling: record CPU and heap profiles of two ways to sort, then read
+// them with go tool pprof.
+//
+//	go run ./profiling -out /tmp/prof   # without -out: a new temp dir
+//	go tool pprof -top /tmp/prof/cpu.pprof
+//	go tool pprof -sample_index=alloc_space -top /tmp/prof/heap.pprof
+//	go tool pprof -http=:8080 /tmp/prof/cpu.pprof   # flame graph in the browser
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/armaanepiic/Golang/profile"
+)
+
+// insertionSort is O(n²): fine for a few dozen elements, hopeless for
+// thousands. The CPU profile makes that obvious.
+func insertionSort(s []int) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j] < s[j-1]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// sortCopies allocates a fresh copy for every sort, the kind of garbage a
+// heap profile points at.
+func sortCopies(data []int, rounds int) {
+	for range rounds {
+		c := slices.Clone(data)
+		slices.Sort(c)
+	}
+}
+
+func main() {
+	out := flag.String("out", "", "directory for cpu.pprof and heap.pprof (default: a new temp dir)")
+	n := flag.Int("n", 20000, "elements to sort")
+	flag.Parse()
+	runtime.MemProfileRate = 4096 // finer sampling than the default 512 KiB
+
+	if *out == "" {
+		dir, err := os.MkdirTemp("", "profiling-")
+		if err != nil {
+			fail(err)
+		}
+		*out = dir
+	} else if err := os.MkdirAll(*out, 0o755); err != nil {
+		fail(err)
+	}
+	data := make([]int, *n)
+	for i := range data {
+		data[i] = rand.IntN(1_000_000)
+	}
+
+	cpu := filepath.Join(*out, "cpu.pprof")
+	err := profile.CPU(cpu, func() {
+		for _, sorter := range []struct {
+			name string
+			sort func([]int)
+		}{{"insertionSort", insertionSort}, {"slices.Sort", slices.Sort[[]int]}} {
+			c := slices.Clone(data)
+			start := time.Now()
+			sorter.sort(c)
+			fmt.Printf("%-14s %d ints in %v\n", sorter.name, len(c), time.Since(start))
+		}
+	})
+	if err != nil {
+		fail(err)
+	}
+
+	heap := filepath.Join(*out, "heap.pprof")
+	if err := profile.Heap(heap, func() { sortCopies(data, 200) }); err != nil {
+		fail(err)
+	}
+
+	fmt.Println()
+	fmt.Println("where the time went:   go tool pprof -top", cpu)
+	fmt.Println("where memory went:     go tool pprof -sample_index=alloc_space -top", heap)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,215 @@
+// Package progress records which topics and exercises a learner has
+// completed, and on which days they practised, in a JSON file under the
+// user's config directory.
+//
+// Keys are free-form strings; TopicKey and ExerciseKey build the ones
+// the learn tools use.
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPath is where progress is kept unless told otherwise:
+// golang-learn/progress.json under os.UserConfigDir.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "golang-learn", "progress.json"), nil
+}
+
+// TopicKey is the key for finishing an example topic.
+func TopicKey(topic string) string { return "topic:" + topic }
+
+// ExerciseKey is the key for passing an exercise of a topic.
+func ExerciseKey(topic, exercise string) string { return "exercise:" + topic + "/" + exercise }
+
+// state is the file format.
+type state struct {
+	Done map[string]time.Time `json:"done"`
+	Days []string             `json:"days"` // YYYY-MM-DD with any activity, sorted
+	Last time.Time            `json:"last,omitzero"`
+}
+
+// Tracker is a progress file. It is safe for concurrent use, but not for
+// several processes writing the same file at once.
+type Tracker struct {
+	// Now is the clock; tests can replace it. Days are in Now's location.
+	Now func() time.Time
+
+	path string
+	mu   sync.Mutex
+	st   state
+}
+
+// Open loads the progress file at path. A missing file is empty
+// progress; it is created on the first change.
+func Open(path string) (*Tracker, error) {
+	t := &Tracker{Now: time.Now, path: path, st: state{Done: map[string]time.Time{}}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &t.st); err != nil {
+		return nil, fmt.Errorf("progress: %s: %w", path, err)
+	}
+	if t.st.Done == nil {
+		t.st.Done = map[string]time.Time{}
+	}
+	return t, nil
+}
+
+// Path returns the file the tracker saves to.
+func (t *Tracker) Path() string { return t.path }
+
+// MarkDone records key as completed and today as a practice day, and
+// saves. It reports whether key was new; completing something again
+// still counts towards the streak.
+func (t *Tracker) MarkDone(key string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.Now()
+	_, had := t.st.Done[key]
+	if !had {
+		t.st.Done[key] = now
+	}
+	t.st.Last = now
+	day := now.Format(time.DateOnly)
+	if i, found := slices.BinarySearch(t.st.Days, day); !found {
+		t.st.Days = slices.Insert(t.st.Days, i, day)
+	}
+	return !had, t.save()
+}
+
+// Reset forgets that key was completed. Practice days are kept.
+func (t *Tracker) Reset(key string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.st.Done[key]; !ok {
+		return nil
+	}
+	delete(t.st.Done, key)
+	return t.save()
+}
+
+// IsDone reports whether key has been completed, and when.
+func (t *Tracker) IsDone(key string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	at, ok := t.st.Done[key]
+	return at, ok
+}
+
+// Done returns the completed keys that start with prefix, sorted.
+func (t *Tracker) Done(prefix string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var keys []string
+	for k := range t.st.Done {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Stats summarises progress.
+type Stats struct {
+	Done, Total   int       // of the keys passed to Stats
+	CurrentStreak int       // consecutive practice days up to today (or yesterday)
+	LongestStreak int       // longest run of consecutive practice days
+	Days          int       // practice days overall
+	LastActive    time.Time // zero if never
+}
+
+// Percent is Done out of Total, 0 to 100.
+func (s Stats) Percent() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return s.Done * 100 / s.Total
+}
+
+// Stats counts how many of keys are done and computes the streaks. A
+// streak survives until the end of the day after the last practice day,
+// so it is not lost before the learner has had a chance to practise
+// today.
+func (t *Tracker) Stats(keys []string) Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := Stats{Total: len(keys), Days: len(t.st.Days), LastActive: t.st.Last}
+	for _, k := range keys {
+		if _, ok := t.st.Done[k]; ok {
+			s.Done++
+		}
+	}
+
+	now := t.Now()
+	loc := now.Location()
+	run := 0
+	var prev time.Time
+	for _, d := range t.st.Days {
+		day, err := time.ParseInLocation(time.DateOnly, d, loc)
+		if err != nil {
+			continue
+		}
+		if !prev.IsZero() && day.Equal(prev.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		s.LongestStreak = max(s.LongestStreak, run)
+		prev = day
+	}
+	today := now.Format(time.DateOnly)
+	yesterday := now.AddDate(0, 0, -1).Format(time.DateOnly)
+	if n := len(t.st.Days); n > 0 && (t.st.Days[n-1] == today || t.st.Days[n-1] == yesterday) {
+		s.CurrentStreak = run
+	}
+	return s
+}
+
+// save writes the file atomically: a temporary file renamed over the old
+// one, so a crash never leaves it half written. The caller holds mu.
+func (t *Tracker) save() error {
+	data, err := json.MarshalIndent(t.st, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(t.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".progress-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), t.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package prop
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/armaanepiic/Golang/faker"
+	"github.com/armaanepiic/Golang/internal/userstore"
+)
+
+var extremes = []int{0, 1, -1, math.MaxInt32, math.MinInt32, math.MaxInt, math.MinInt}
+
+// Int generates ints in [-size, size], now and then an extreme value.
+// Failures shrink towards 0.
+func Int() Gen[int] {
+	return Gen[int]{
+		Generate: func(r *rand.Rand, size int) int {
+			if r.IntN(20) == 0 {
+				return extremes[r.IntN(len(extremes))]
+			}
+			return r.IntN(2*size+1) - size
+		},
+		Shrink: func(v int) []int { return shrinkInt(v, 0) },
+	}
+}
+
+// IntRange generates ints in [lo, hi]. Failures shrink towards lo, or
+// towards 0 if the range contains it.
+func IntRange(lo, hi int) Gen[int] {
+	target := lo
+	if lo <= 0 && 0 <= hi {
+		target = 0
+	}
+	return Gen[int]{
+		Generate: func(r *rand.Rand, size int) int { return lo + r.IntN(hi-lo+1) },
+		Shrink:   func(v int) []int { return shrinkInt(v, target) },
+	}
+}
+
+// shrinkInt returns target and then values ever closer to v.
+func shrinkInt(v, target int) []int {
+	var out []int
+	for d := v - target; d != 0; d /= 2 {
+		out = append(out, v-d)
+	}
+	return out
+}
+
+// letters is what String draws from: mostly ASCII, with a few multi-byte
+// runes to catch code that confuses bytes and runes.
+var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 .,-_/éß世界🙂")
+
+// String generates strings of up to size runes. Failures shrink by
+// dropping runes and then by replacing them with 'a'.
+func String() Gen[string] {
+	runes := SliceOf(Gen[rune]{
+		Generate: func(r *rand.Rand, size int) rune { return letters[r.IntN(len(letters))] },
+		Shrink: func(c rune) []rune {
+			if c == 'a' {
+				return nil
+			}
+			return []rune{'a'}
+		},
+	})
+	return Gen[string]{
+		Generate: func(r *rand.Rand, size int) string { return string(runes.Generate(r, size)) },
+		Shrink: func(s string) []string {
+			var out []string
+			for _, c := range runes.Shrink([]rune(s)) {
+				out = append(out, string(c))
+			}
+			return out
+		},
+	}
+}
+
+// SliceOf generates slices of up to size elements from elem. Failures
+// shrink by removing elements (all of them, then halves, then one at a
+// time) and then by shrinking single elements.
+func SliceOf[T any](elem Gen[T]) Gen[[]T] {
+	return Gen[[]T]{
+		Generate: func(r *rand.Rand, size int) []T {
+			s := make([]T, r.IntN(size+1))
+			for i := range s {
+				s[i] = elem.Generate(r, size)
+			}
+			return s
+		},
+		Shrink: func(s []T) [][]T {
+			if len(s) == 0 {
+				return nil
+			}
+			out := [][]T{{}}
+			if len(s) > 1 {
+				out = append(out, slices.Clone(s[:len(s)/2]), slices.Clone(s[len(s)/2:]))
+			}
+			for i := range s {
+				out = append(out, slices.Delete(slices.Clone(s), i, i+1))
+			}
+			if elem.Shrink != nil {
+				for i, v := range s {
+					for _, c := range elem.Shrink(v) {
+						t := slices.Clone(s)
+						t[i] = c
+						out = append(out, t)
+					}
+				}
+			}
+			return out
+		},
+	}
+}
+
+// User generates users with the faker's names and ages and IDs up to
+// size. Failures shrink the ID and age and drop the last name.
+func User() Gen[userstore.User] {
+	return Gen[userstore.User]{
+		Generate: func(r *rand.Rand, size int) userstore.User {
+			return faker.New(r.Uint64()).User(1 + r.IntN(size+1))
+		},
+		Shrink: func(u userstore.User) []userstore.User {
+			var out []userstore.User
+			for _, id := range shrinkInt(u.ID, 1) {
+				out = append(out, userstore.User{ID: id, Name: u.Name, Age: u.Age})
+			}
+			for _, age := range shrinkInt(u.Age, 0) {
+				out = append(out, userstore.User{ID: u.ID, Name: u.Name, Age: age})
+			}
+			if first, _, ok := strings.Cut(u.Name, " "); ok {
+				out = append(out, userstore.User{ID: u.ID, Name: first, Age: u.Age})
+			}
+			return out
+		},
+	}
+}
+
+// Map generates f(v) for values v from g. The results are not shrunk.
+func Map[T, U any](g Gen[T], f func(T) U) Gen[U] {
+	return Gen[U]{Generate: func(r *rand.Rand, size int) U { return f(g.Generate(r, size)) }}
+}
+
+// Pair is two generated values.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// PairOf generates pairs from a and b. Failures shrink the first value,
+// then the second.
+func PairOf[A, B any](a Gen[A], b Gen[B]) Gen[Pair[A, B]] {
+	return Gen[Pair[A, B]]{
+		Generate: func(r *rand.Rand, size int) Pair[A, B] {
+			return Pair[A, B]{a.Generate(r, size), b.Generate(r, size)}
+		},
+		Shrink: func(p Pair[A, B]) []Pair[A, B] {
+			var out []Pair[A, B]
+			if a.Shrink != nil {
+				for _, c := range a.Shrink(p.First) {
+					out = append(out, Pair[A, B]{c, p.Second})
+				}
+			}
+			if b.Shrink != nil {
+				for _, c := range b.Shrink(p.Second) {
+					out = append(out, Pair[A, B]{p.First, c})
+				}
+			}
+			return out
+		},
+	}
+}
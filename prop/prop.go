@@ -0,0 +1,151 @@
+// Package prop is a small property-based testing library in the spirit of
+// testing/quick: instead of writing examples, state something that must
+// hold for every input and let ForAll look for a counterexample among
+// random ones. When it finds one it shrinks it, repeatedly trying simpler
+// inputs that still fail, so the report shows a minimal case:
+//
+//	prop.ForAll(t, prop.SliceOf(prop.Int()), func(s []int) bool {
+//		return len(slicesx.Uniq(s)) <= len(s)
+//	})
+//
+// Failures are reproducible: the report includes the seed, and WithSeed
+// replays it.
+package prop
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// TB is the part of testing.TB that ForAll needs.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Gen generates random values of T and shrinks failing ones.
+type Gen[T any] struct {
+	// Generate returns a random value. size grows from 0 over the runs:
+	// early values are small, later ones bigger.
+	Generate func(r *rand.Rand, size int) T
+
+	// Shrink returns simpler candidates for v, simplest first. Nil means
+	// values of T are not shrunk.
+	Shrink func(v T) []T
+}
+
+// DefaultRuns is how many random inputs ForAll tries.
+const DefaultRuns = 100
+
+// MaxSize is the size passed to Generate on the last run.
+const MaxSize = 100
+
+type config struct {
+	runs       int
+	seed       uint64
+	maxShrinks int
+}
+
+// Option configures ForAll.
+type Option func(*config)
+
+// WithRuns sets the number of random inputs to try.
+func WithRuns(n int) Option {
+	return func(c *config) { c.runs = n }
+}
+
+// WithSeed fixes the random seed, to replay a reported failure.
+func WithSeed(seed uint64) Option {
+	return func(c *config) { c.seed = seed }
+}
+
+// Result describes a falsified property.
+type Result struct {
+	Seed    uint64
+	Run     int // 1-based run that failed
+	Shrinks int // successful shrink steps
+	Input   any // the shrunk counterexample
+	Panic   any // what the predicate panicked with, if it did
+}
+
+func (r *Result) String() string {
+	s := fmt.Sprintf("falsified on run %d after %d shrinks (replay with prop.WithSeed(%d))\ncounterexample: %#v", r.Run, r.Shrinks, r.Seed, r.Input)
+	if r.Panic != nil {
+		s += fmt.Sprintf("\npanic: %v", r.Panic)
+	}
+	return s
+}
+
+// ForAll checks that pred holds for random values from gen and reports
+// the shrunk counterexample through t.Errorf if it doesn't. A panic in
+// pred counts as a failure. It reports whether the property held.
+func ForAll[T any](t TB, gen Gen[T], pred func(T) bool, opts ...Option) bool {
+	t.Helper()
+	if r := Check(gen, pred, opts...); r != nil {
+		t.Errorf("prop: %v", r)
+		return false
+	}
+	return true
+}
+
+// Check is ForAll without a TB: it returns nil if the property held, or
+// the failure.
+func Check[T any](gen Gen[T], pred func(T) bool, opts ...Option) *Result {
+	cfg := config{runs: DefaultRuns, seed: uint64(time.Now().UnixNano()), maxShrinks: 1000}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	r := rand.New(rand.NewPCG(cfg.seed, cfg.seed>>32|cfg.seed<<32))
+	for i := range cfg.runs {
+		size := 0
+		if cfg.runs > 1 {
+			size = i * MaxSize / (cfg.runs - 1)
+		}
+		v := gen.Generate(r, size)
+		ok, p := holds(pred, v)
+		if ok {
+			continue
+		}
+		v, p, n := shrink(gen, pred, v, p, cfg.maxShrinks)
+		return &Result{Seed: cfg.seed, Run: i + 1, Shrinks: n, Input: v, Panic: p}
+	}
+	return nil
+}
+
+// holds calls pred, turning a panic into a failure.
+func holds[T any](pred func(T) bool, v T) (ok bool, panicked any) {
+	defer func() {
+		if p := recover(); p != nil {
+			ok, panicked = false, p
+		}
+	}()
+	return pred(v), nil
+}
+
+// shrink greedily replaces v by its first candidate that still fails,
+// until no candidate fails or the budget runs out.
+func shrink[T any](gen Gen[T], pred func(T) bool, v T, p any, budget int) (T, any, int) {
+	if gen.Shrink == nil {
+		return v, p, 0
+	}
+	steps := 0
+	for tries := 0; tries < budget; {
+		progressed := false
+		for _, c := range gen.Shrink(v) {
+			tries++
+			if ok, cp := holds(pred, c); !ok {
+				v, p, progressed = c, cp, true
+				steps++
+				break
+			}
+			if tries >= budget {
+				break
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return v, p, steps
+}
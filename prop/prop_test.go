@@ -0,0 +1,77 @@
+package prop_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/armaanepiic/Golang/internal/slicesx"
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/prop"
+)
+
+var ints = prop.SliceOf(prop.Int())
+
+func TestSortIsSortedPermutation(t *testing.T) {
+	prop.ForAll(t, ints, func(s []int) bool {
+		sorted := slices.Clone(s)
+		slices.Sort(sorted)
+		if !slices.IsSorted(sorted) {
+			return false
+		}
+		count := map[int]int{}
+		for _, v := range s {
+			count[v]++
+		}
+		for _, v := range sorted {
+			count[v]--
+		}
+		for _, c := range count {
+			if c != 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestSortStableKeepsOrder(t *testing.T) {
+	prop.ForAll(t, prop.SliceOf(prop.User()), func(us []userstore.User) bool {
+		for i := range us {
+			us[i].ID = i + 1 // IDs in input order
+		}
+		slices.SortStableFunc(us, func(a, b userstore.User) int { return cmp.Compare(a.Age, b.Age) })
+		for i := 1; i < len(us); i++ {
+			if us[i-1].Age == us[i].Age && us[i-1].ID > us[i].ID {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// TestShrinks checks a false property: Check must find it and shrink the
+// counterexample down to a pair of equal values.
+func TestShrinks(t *testing.T) {
+	gen := prop.SliceOf(prop.IntRange(0, 9))
+	never := func(s []int) bool { return len(slicesx.Uniq(slices.Clone(s))) == len(s) }
+
+	res := prop.Check(gen, never, prop.WithSeed(1))
+	if res == nil {
+		t.Fatal("Check found no counterexample")
+	}
+	if s, ok := res.Input.([]int); !ok || len(s) != 2 || s[0] != s[1] {
+		t.Fatalf("counterexample not shrunk:\n%s", res)
+	}
+
+	again := prop.Check(gen, never, prop.WithSeed(1))
+	if again == nil || again.String() != res.String() {
+		t.Fatalf("same seed, different result:\n%s\n%s", res, again)
+	}
+}
+
+func TestPassingProperty(t *testing.T) {
+	if res := prop.Check(ints, func(s []int) bool { return len(s) >= 0 }, prop.WithRuns(50)); res != nil {
+		t.Fatalf("unexpected failure:\n%s", res)
+	}
+}
@@ -0,0 +1,159 @@
+// Package proxy is a small load-balancing reverse proxy built on
+// httputil.ReverseProxy.
+//
+// Requests are spread round-robin across the backends. A request without
+// a body is retried on the next backend if the connection to its backend
+// could not be dialed, or, for idempotent methods, if the exchange failed
+// for any other reason: a POST that may have reached the backend is not
+// sent twice.
+// httputil.ReverseProxy strips hop-by-hop headers (Connection, Keep-Alive,
+// Upgrade, ...) in both directions, and the proxy sets X-Forwarded-For,
+// X-Forwarded-Host and X-Forwarded-Proto on the outgoing request.
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// Balancer is an http.Handler that proxies to a pool of backends.
+type Balancer struct {
+	backends  []*url.URL
+	next      atomic.Uint64
+	transport http.RoundTripper
+	rp        *httputil.ReverseProxy
+}
+
+// Option configures a Balancer.
+type Option func(*Balancer)
+
+// WithTransport replaces http.DefaultTransport for talking to backends.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(b *Balancer) { b.transport = rt }
+}
+
+// WithErrorLog sets the logger for proxy errors.
+func WithErrorLog(l *log.Logger) Option {
+	return func(b *Balancer) { b.rp.ErrorLog = l }
+}
+
+type ctxKey struct{}
+
+// route remembers which backend a request started on and the path it had
+// before rewriting, so a retry can rebuild the URL for another backend.
+type route struct {
+	start int
+	in    *url.URL
+}
+
+// New returns a Balancer for the given backend base URLs.
+func New(backends []string, opts ...Option) (*Balancer, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("proxy: no backends")
+	}
+	b := &Balancer{transport: http.DefaultTransport}
+	for _, s := range backends {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("proxy: backend %q: %w", s, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("proxy: backend %q must be an absolute URL", s)
+		}
+		b.backends = append(b.backends, u)
+	}
+	b.rp = &httputil.ReverseProxy{
+		Rewrite:   b.rewrite,
+		Transport: roundTripper(b.roundTrip),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b, nil
+}
+
+func (b *Balancer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.rp.ServeHTTP(w, r)
+}
+
+func (b *Balancer) rewrite(pr *httputil.ProxyRequest) {
+	i := int((b.next.Add(1) - 1) % uint64(len(b.backends)))
+	pr.SetURL(b.backends[i])
+	pr.SetXForwarded()
+
+	in := *pr.In.URL
+	pr.Out = pr.Out.WithContext(context.WithValue(pr.Out.Context(), ctxKey{}, route{start: i, in: &in}))
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// roundTrip sends r to its chosen backend and, while the failure is safe
+// to retry, to the following ones in turn.
+func (b *Balancer) roundTrip(r *http.Request) (*http.Response, error) {
+	res, err := b.transport.RoundTrip(r)
+	rt, ok := r.Context().Value(ctxKey{}).(route)
+	if !ok {
+		return res, err
+	}
+
+	for n := 1; n < len(b.backends) && err != nil && retryable(r, err); n++ {
+		target := b.backends[(rt.start+n)%len(b.backends)]
+		retry := r.Clone(r.Context())
+		retry.URL = rewriteURL(target, rt.in)
+		retry.Host = ""
+		res, err = b.transport.RoundTrip(retry)
+	}
+	return res, err
+}
+
+// retryable reports whether r may be sent to another backend after err.
+// A failed dial means the backend never saw the request; any other error
+// may come after it was processed, so only idempotent methods are resent.
+func retryable(r *http.Request, err error) bool {
+	if !replayable(r) || r.Context().Err() != nil {
+		return false
+	}
+	var op *net.OpError
+	if errors.As(err, &op) && op.Op == "dial" {
+		return true
+	}
+	return idempotent(r.Method)
+}
+
+func replayable(r *http.Request) bool {
+	return r.Body == nil || r.Body == http.NoBody
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewriteURL mirrors ProxyRequest.SetURL for a retry.
+func rewriteURL(target, in *url.URL) *url.URL {
+	u := *in
+	u.Scheme = target.Scheme
+	u.Host = target.Host
+	u.Path = strings.TrimSuffix(target.Path, "/") + "/" + strings.TrimPrefix(in.Path, "/")
+	u.RawPath = ""
+	if target.RawQuery == "" || in.RawQuery == "" {
+		u.RawQuery = target.RawQuery + in.RawQuery
+	} else {
+		u.RawQuery = target.RawQuery + "&" + in.RawQuery
+	}
+	return &u
+}
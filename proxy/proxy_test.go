@@ -0,0 +1,151 @@
+package proxy
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// backend is an httptest server that answers with its name and counts
+// its requests.
+type backend struct {
+	*httptest.Server
+	hits atomic.Int32
+}
+
+func newBackend(t *testing.T, name string) *backend {
+	t.Helper()
+	b := &backend{}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.hits.Add(1)
+		io.WriteString(w, name+" "+r.URL.RequestURI())
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+// newBroken returns a backend that accepts the request and then drops the
+// connection without answering.
+func newBroken(t *testing.T) *backend {
+	t.Helper()
+	b := &backend{}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.hits.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		conn.Close()
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+// deadURL returns the URL of a server that has been shut down, so
+// dialing it is refused.
+func deadURL(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+func newBalancer(t *testing.T, backends ...string) *httptest.Server {
+	t.Helper()
+	b, err := New(backends, WithErrorLog(log.New(io.Discard, "", 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(b)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func send(t *testing.T, method, url string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	return res.StatusCode, string(body)
+}
+
+func TestRoundRobin(t *testing.T) {
+	a, b := newBackend(t, "a"), newBackend(t, "b")
+	lb := newBalancer(t, a.URL, b.URL+"/base")
+
+	var got []string
+	for range 4 {
+		_, body := send(t, http.MethodGet, lb.URL+"/x?q=1")
+		got = append(got, body)
+	}
+	want := "a /x?q=1|b /base/x?q=1|a /x?q=1|b /base/x?q=1"
+	if s := strings.Join(got, "|"); s != want {
+		t.Fatalf("responses %s, want %s", s, want)
+	}
+}
+
+func TestFailoverOnDialError(t *testing.T) {
+	live := newBackend(t, "live")
+	lb := newBalancer(t, deadURL(t), live.URL)
+
+	// every request starting on the dead backend moves on to the live one,
+	// whatever its method
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodGet, http.MethodPost} {
+		code, body := send(t, method, lb.URL+"/p")
+		if code != http.StatusOK || body != "live /p" {
+			t.Fatalf("%s: %d %q, want 200 from the live backend", method, code, body)
+		}
+	}
+	if n := live.hits.Load(); n != 4 {
+		t.Fatalf("live backend got %d requests, want 4", n)
+	}
+}
+
+func TestFailoverAfterSend(t *testing.T) {
+	broken, live := newBroken(t), newBackend(t, "live")
+	lb := newBalancer(t, broken.URL, live.URL)
+
+	// GET starts on the broken backend and is retried on the live one
+	if code, body := send(t, http.MethodGet, lb.URL+"/p"); code != http.StatusOK || body != "live /p" {
+		t.Fatalf("GET: %d %q, want 200 from the live backend", code, body)
+	}
+	send(t, http.MethodGet, lb.URL+"/p") // move the round-robin back to the broken backend
+
+	// POST reached the broken backend, which may have acted on it: no retry
+	if code, _ := send(t, http.MethodPost, lb.URL+"/p"); code != http.StatusBadGateway {
+		t.Fatalf("POST: status %d, want 502", code)
+	}
+	if n := broken.hits.Load(); n != 2 {
+		t.Fatalf("broken backend got %d requests, want 2", n)
+	}
+	if n := live.hits.Load(); n != 2 {
+		t.Fatalf("live backend got %d requests, want 2 (no retried POST)", n)
+	}
+}
+
+func TestAllBackendsDown(t *testing.T) {
+	lb := newBalancer(t, deadURL(t), deadURL(t))
+	if code, _ := send(t, http.MethodGet, lb.URL); code != http.StatusBadGateway {
+		t.Fatalf("status %d, want 502", code)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, backends := range [][]string{nil, {"localhost:8080"}, {"http://%zz"}} {
+		if _, err := New(backends); err == nil {
+			t.Errorf("New(%q) succeeded", backends)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package pubsub is an in-memory, topic based message broker. Every
+// subscriber gets its own buffered channel; what happens when that buffer
+// is full is decided by the broker's Policy.
+package pubsub
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by Publish and Subscribe after Close.
+var ErrClosed = errors.New("pubsub: broker closed")
+
+// Policy says what Publish does when a subscriber's buffer is full.
+type Policy int
+
+const (
+	// Drop skips the slow subscriber; the message is lost for it only.
+	Drop Policy = iota
+	// Block waits until the subscriber has room, it unsubscribes or the
+	// broker is closed. One slow subscriber slows every publisher down.
+	Block
+)
+
+// Broker fans messages of type T out to the subscribers of a topic.
+// Subscribers see messages in the order they were published.
+type Broker[T any] struct {
+	policy Policy
+
+	mu     sync.RWMutex
+	topics map[string]map[*Subscription[T]]struct{}
+	closed bool
+
+	done     chan struct{}
+	doneOnce sync.Once
+
+	pubMu sync.Mutex // serialises Publish so fan-out order is global
+}
+
+// New returns a broker using policy for slow subscribers.
+func New[T any](policy Policy) *Broker[T] {
+	return &Broker[T]{
+		policy: policy,
+		topics: make(map[string]map[*Subscription[T]]struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Subscription is one subscriber to one topic.
+type Subscription[T any] struct {
+	b       *Broker[T]
+	topic   string
+	ch      chan T
+	gone    chan struct{}
+	once    sync.Once
+	dropped int64 // guarded by b.pubMu
+}
+
+// Subscribe registers a subscriber on topic whose channel holds up to
+// buffer undelivered messages.
+func (b *Broker[T]) Subscribe(topic string, buffer int) (*Subscription[T], error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	s := &Subscription[T]{
+		b:     b,
+		topic: topic,
+		ch:    make(chan T, buffer),
+		gone:  make(chan struct{}),
+	}
+	subs := b.topics[topic]
+	if subs == nil {
+		subs = make(map[*Subscription[T]]struct{})
+		b.topics[topic] = subs
+	}
+	subs[s] = struct{}{}
+	return s, nil
+}
+
+// Publish sends msg to every subscriber of topic and returns how many
+// received it.
+func (b *Broker[T]) Publish(topic string, msg T) (int, error) {
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return 0, ErrClosed
+	}
+	n := 0
+	for s := range b.topics[topic] {
+		if b.deliver(s, msg) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (b *Broker[T]) deliver(s *Subscription[T], msg T) bool {
+	if b.policy == Drop {
+		select {
+		case s.ch <- msg:
+			return true
+		default:
+			s.dropped++
+			return false
+		}
+	}
+	select {
+	case s.ch <- msg:
+		return true
+	case <-s.gone:
+	case <-b.done:
+	}
+	return false
+}
+
+// Subscribers returns the number of subscribers on topic.
+func (b *Broker[T]) Subscribers(topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.topics[topic])
+}
+
+// Close unsubscribes everyone, closing their channels. Blocked publishers
+// return. Close is idempotent.
+func (b *Broker[T]) Close() {
+	// release blocked publishers first; they hold the locks we need
+	b.doneOnce.Do(func() { close(b.done) })
+
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, subs := range b.topics {
+		for s := range subs {
+			s.once.Do(func() { close(s.gone) })
+			close(s.ch)
+		}
+	}
+	b.topics = nil
+}
+
+// C returns the channel messages arrive on. It is closed by Unsubscribe
+// or when the broker is closed.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Topic returns the subscribed topic.
+func (s *Subscription[T]) Topic() string {
+	return s.topic
+}
+
+// Dropped returns how many messages were skipped because the buffer was
+// full (Drop policy only).
+func (s *Subscription[T]) Dropped() int64 {
+	s.b.pubMu.Lock()
+	defer s.b.pubMu.Unlock()
+	return s.dropped
+}
+
+// Unsubscribe removes the subscriber and closes its channel. Messages
+// already buffered can still be read. It is safe to call more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	// wake a publisher blocked on us before waiting for the publish lock
+	first := false
+	s.once.Do(func() {
+		close(s.gone)
+		first = true
+	})
+	if !first {
+		return
+	}
+	b := s.b
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return // Close already closed the channel
+	}
+	subs := b.topics[s.topic]
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(b.topics, s.topic)
+	}
+	close(s.ch)
+}
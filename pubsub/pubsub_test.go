@@ -0,0 +1,136 @@
+package pubsub_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/leaktest"
+	"github.com/armaanepiic/Golang/pubsub"
+)
+
+func TestFanOutInOrder(t *testing.T) {
+	defer leaktest.Check(t)()
+	b := pubsub.New[int](pubsub.Block)
+	defer b.Close()
+
+	var subs []*pubsub.Subscription[int]
+	for range 3 {
+		s, err := b.Subscribe("n", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subs = append(subs, s)
+	}
+	other, _ := b.Subscribe("other", 10)
+
+	var wg sync.WaitGroup
+	for _, s := range subs {
+		wg.Go(func() {
+			want := 0
+			for n := range s.C() {
+				if n != want {
+					t.Errorf("got %d, want %d", n, want)
+				}
+				want++
+			}
+			if want != 100 {
+				t.Errorf("got %d messages, want 100", want)
+			}
+		})
+	}
+	for i := range 100 {
+		if n, err := b.Publish("n", i); err != nil || n != 3 {
+			t.Fatalf("Publish = %d, %v; want 3 receivers", n, err)
+		}
+	}
+	for _, s := range subs {
+		s.Unsubscribe()
+	}
+	wg.Wait()
+	if len(other.C()) != 0 {
+		t.Error("a subscriber of another topic got messages")
+	}
+}
+
+func TestDropPolicy(t *testing.T) {
+	b := pubsub.New[string](pubsub.Drop)
+	defer b.Close()
+	s, _ := b.Subscribe("t", 2)
+	for _, m := range []string{"a", "b", "c", "d"} {
+		b.Publish("t", m)
+	}
+	if d := s.Dropped(); d != 2 {
+		t.Fatalf("Dropped = %d, want 2", d)
+	}
+	if a, b := <-s.C(), <-s.C(); a != "a" || b != "b" {
+		t.Fatalf("kept %q %q, want the first two", a, b)
+	}
+}
+
+// TestCloseEndsSubscribers stops every goroutine ranging over a
+// subscription by closing the broker, including a publisher blocked on a
+// subscriber that stopped reading.
+func TestCloseEndsSubscribers(t *testing.T) {
+	defer leaktest.Check(t)()
+	b := pubsub.New[int](pubsub.Block)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		s, err := b.Subscribe("n", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Go(func() {
+			for range s.C() {
+			}
+		})
+	}
+	stuck, _ := b.Subscribe("n", 0) // never read
+	published := make(chan error, 1)
+	go func() {
+		_, err := b.Publish("n", 1)
+		published <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond) // let Publish block on stuck
+	b.Close()
+	if err := <-published; err != nil {
+		t.Fatalf("blocked Publish = %v", err)
+	}
+	wg.Wait()
+	if _, ok := <-stuck.C(); ok {
+		t.Fatal("stuck subscriber's channel is still open")
+	}
+
+	if _, err := b.Publish("n", 2); !errors.Is(err, pubsub.ErrClosed) {
+		t.Errorf("Publish after Close = %v, want ErrClosed", err)
+	}
+	if _, err := b.Subscribe("n", 1); !errors.Is(err, pubsub.ErrClosed) {
+		t.Errorf("Subscribe after Close = %v, want ErrClosed", err)
+	}
+	b.Close() // idempotent
+}
+
+func TestUnsubscribeReleasesPublisher(t *testing.T) {
+	defer leaktest.Check(t)()
+	b := pubsub.New[int](pubsub.Block)
+	defer b.Close()
+	s, _ := b.Subscribe("n", 0)
+
+	published := make(chan int, 1)
+	go func() {
+		n, _ := b.Publish("n", 1)
+		published <- n
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.Unsubscribe()
+	if n := <-published; n != 0 {
+		t.Fatalf("Publish delivered to %d subscribers, want 0", n)
+	}
+	if b.Subscribers("n") != 0 {
+		t.Fatal("subscriber still registered")
+	}
+	s.Unsubscribe() // safe to repeat
+}
@@ -0,0 +1,11 @@
+package quiz
+
+import "embed"
+
+//go:embed banks
+var builtin embed.FS
+
+// Builtin returns the question banks that ship with the package.
+func Builtin() (map[string]*Bank, error) {
+	return LoadFS(builtin, "banks")
+}
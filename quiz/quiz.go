@@ -0,0 +1,166 @@
+// Package quiz runs interview-style Go quizzes from question banks.
+//
+// A bank is a JSON or YAML file of questions for one topic. Questions are
+// either multiple choice or "predict the output": the learner reads a
+// snippet and types what it prints, and the answer is checked against
+// the snippet's real output, so the key can never be wrong:
+//
+//	topic: slices
+//	questions:
+//	  - id: append-alias
+//	    kind: output
+//	    prompt: What does this print?
+//	    code: |
+//	      a := []int{1, 2, 3}
+//	      b := a[:2]
+//	      b = append(b, 9)
+//	      fmt.Println(a)
+package quiz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Kind is the type of a question.
+type Kind string
+
+const (
+	Choice Kind = "choice" // pick one of Choices
+	Output Kind = "output" // predict what Code prints
+)
+
+// Question is one quiz question.
+type Question struct {
+	ID      string   `json:"id"`
+	Kind    Kind     `json:"kind"`
+	Prompt  string   `json:"prompt"`
+	Code    string   `json:"code,omitempty"`
+	Choices []string `json:"choices,omitempty"`
+	Answer  string   `json:"answer,omitempty"` // choice: the letter of the right choice
+	Explain string   `json:"explain,omitempty"`
+}
+
+// Bank is the questions of one topic.
+type Bank struct {
+	Topic     string     `json:"topic"`
+	Questions []Question `json:"questions"`
+}
+
+// Letter is the label of choice i: a, b, c ...
+func Letter(i int) string { return string(rune('a' + i)) }
+
+// Parse decodes a bank. name's extension picks the format: .json, or
+// .yaml/.yml.
+func Parse(name string, data []byte) (*Bank, error) {
+	var b Bank
+	switch ext := path.Ext(name); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, fmt.Errorf("quiz: %s: %w", name, err)
+		}
+	case ".yaml", ".yml":
+		v, err := decodeYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("quiz: %s: %w", name, err)
+		}
+		// the generic tree converts to the structs through JSON
+		raw, _ := json.Marshal(v)
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, fmt.Errorf("quiz: %s: %w", name, err)
+		}
+	default:
+		return nil, fmt.Errorf("quiz: %s: unknown bank format %q", name, ext)
+	}
+	if err := b.validate(); err != nil {
+		return nil, fmt.Errorf("quiz: %s: %w", name, err)
+	}
+	return &b, nil
+}
+
+func (b *Bank) validate() error {
+	if b.Topic == "" {
+		return errors.New("missing topic")
+	}
+	seen := map[string]bool{}
+	for i := range b.Questions {
+		q := &b.Questions[i]
+		if q.ID == "" {
+			return fmt.Errorf("question %d: missing id", i+1)
+		}
+		if seen[q.ID] {
+			return fmt.Errorf("duplicate question id %q", q.ID)
+		}
+		seen[q.ID] = true
+		if q.Prompt == "" {
+			return fmt.Errorf("%s: missing prompt", q.ID)
+		}
+		switch q.Kind {
+		case Choice:
+			if len(q.Choices) < 2 {
+				return fmt.Errorf("%s: a choice question needs at least 2 choices", q.ID)
+			}
+			q.Answer = strings.ToLower(strings.TrimSpace(q.Answer))
+			if len(q.Answer) != 1 || q.Answer[0] < 'a' || int(q.Answer[0]-'a') >= len(q.Choices) {
+				return fmt.Errorf("%s: answer must be a letter from a to %s", q.ID, Letter(len(q.Choices)-1))
+			}
+		case Output:
+			if strings.TrimSpace(q.Code) == "" {
+				return fmt.Errorf("%s: an output question needs code", q.ID)
+			}
+		default:
+			return fmt.Errorf("%s: unknown kind %q", q.ID, q.Kind)
+		}
+	}
+	return nil
+}
+
+// LoadFS loads every .json, .yaml and .yml bank in dir of fsys, keyed by
+// topic. Banks for the same topic are merged.
+func LoadFS(fsys fs.FS, dir string) (map[string]*Bank, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	banks := map[string]*Bank{}
+	for _, e := range entries {
+		switch path.Ext(e.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		name := path.Join(dir, e.Name())
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		b, err := Parse(name, data)
+		if err != nil {
+			return nil, err
+		}
+		if have, ok := banks[b.Topic]; ok {
+			have.Questions = append(have.Questions, b.Questions...)
+			if err := have.validate(); err != nil {
+				return nil, fmt.Errorf("quiz: %s: %w", name, err)
+			}
+		} else {
+			banks[b.Topic] = b
+		}
+	}
+	return banks, nil
+}
+
+// Topics returns the topics of banks, sorted.
+func Topics(banks map[string]*Bank) []string {
+	topics := make([]string, 0, len(banks))
+	for t := range banks {
+		topics = append(topics, t)
+	}
+	slices.Sort(topics)
+	return topics
+}
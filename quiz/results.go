@@ -0,0 +1,68 @@
+package quiz
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// AppDir is the directory under os.UserConfigDir where the learning tools
+// keep their state.
+const AppDir = "golang-learn"
+
+// DefaultResultsPath is where results are recorded unless told otherwise.
+func DefaultResultsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppDir, "quiz-results.jsonl"), nil
+}
+
+// Record appends r to the results file at path, one JSON object per line.
+func Record(path string, r Result) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	line, _ := json.Marshal(r)
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// History reads the results recorded at path, oldest first. A missing
+// file is an empty history.
+func History(path string) ([]Result, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var results []Result
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var r Result
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("quiz: %s line %d: %w", path, n, err)
+		}
+		results = append(results, r)
+	}
+	return results, sc.Err()
+}
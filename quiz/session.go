@@ -0,0 +1,118 @@
+package quiz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/snippet"
+)
+
+// Runner runs a snippet and returns what it printed.
+type Runner func(ctx context.Context, code string) (string, error)
+
+// GoRun is the default Runner: it runs code with snippet.Run, so code
+// may be a whole program or just the body of main. Output is stdout; a
+// snippet that does not build or fails is an error with its stderr.
+func GoRun(ctx context.Context, code string) (string, error) {
+	r, err := snippet.Run(ctx, code)
+	if err != nil {
+		return "", fmt.Errorf("quiz: running snippet: %w", err)
+	}
+	if err := r.Err(); err != nil {
+		return r.Stdout, fmt.Errorf("quiz: running snippet: %w", err)
+	}
+	return r.Stdout, nil
+}
+
+// Verdict is the outcome of one answer.
+type Verdict struct {
+	Correct bool
+	Want    string // the right answer, as shown to the learner
+}
+
+// Check grades answer for q. Output questions run q.Code with run; an
+// answer matches if it has the same words as the output, so line breaks
+// may be typed as spaces. Choice questions take the letter or the full
+// text of the choice.
+func Check(ctx context.Context, q *Question, answer string, run Runner) (Verdict, error) {
+	answer = strings.TrimSpace(answer)
+	switch q.Kind {
+	case Choice:
+		i := int(q.Answer[0] - 'a')
+		want := q.Choices[i]
+		ok := strings.EqualFold(answer, q.Answer) || strings.EqualFold(answer, strings.TrimSpace(want))
+		return Verdict{Correct: ok, Want: q.Answer + ") " + want}, nil
+	case Output:
+		out, err := run(ctx, q.Code)
+		if err != nil {
+			return Verdict{}, err
+		}
+		return Verdict{Correct: sameWords(answer, out), Want: strings.TrimRight(out, "\n")}, nil
+	}
+	return Verdict{}, fmt.Errorf("quiz: unknown kind %q", q.Kind)
+}
+
+func sameWords(a, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
+
+// Result is the score of one finished session.
+type Result struct {
+	Time    time.Time `json:"time"`
+	Topic   string    `json:"topic"`
+	Correct int       `json:"correct"`
+	Total   int       `json:"total"`
+	Missed  []string  `json:"missed,omitempty"` // question IDs
+}
+
+// Percent is the score out of 100.
+func (r Result) Percent() int {
+	if r.Total == 0 {
+		return 0
+	}
+	return r.Correct * 100 / r.Total
+}
+
+// Session asks a sequence of questions and keeps the score.
+type Session struct {
+	Topic     string
+	Questions []Question
+	Run       Runner // GoRun if nil
+
+	result Result
+}
+
+// NewSession returns a session over qs, which it does not modify.
+func NewSession(topic string, qs []Question) *Session {
+	return &Session{Topic: topic, Questions: qs}
+}
+
+// Answer grades answer for question i and adds it to the score.
+func (s *Session) Answer(ctx context.Context, i int, answer string) (Verdict, error) {
+	run := s.Run
+	if run == nil {
+		run = GoRun
+	}
+	q := &s.Questions[i]
+	v, err := Check(ctx, q, answer, run)
+	if err != nil {
+		return v, err
+	}
+	s.result.Total++
+	if v.Correct {
+		s.result.Correct++
+	} else {
+		s.result.Missed = append(s.result.Missed, q.ID)
+	}
+	return v, nil
+}
+
+// Result returns the score so far, stamped with the current time.
+func (s *Session) Result() Result {
+	r := s.result
+	r.Topic = s.Topic
+	r.Time = time.Now()
+	return r
+}
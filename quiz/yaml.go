@@ -0,0 +1,281 @@
+package quiz
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeYAML parses the block-style YAML subset question banks use into
+// maps, slices and strings: nested mappings and "- " lists by
+// indentation, | and |- block scalars (for code), plain, quoted and
+// [a, b] flow scalars, and # comments. All scalars stay strings.
+func decodeYAML(data []byte) (any, error) {
+	p := &yamlParser{lines: strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")}
+	if !p.skip() {
+		return nil, nil
+	}
+	v, err := p.node(p.indent())
+	if err != nil {
+		return nil, err
+	}
+	if p.skip() {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return v, nil
+}
+
+type yamlParser struct {
+	lines []string
+	i     int
+}
+
+// skip moves past blank and comment-only lines and reports whether a line
+// is left.
+func (p *yamlParser) skip() bool {
+	for ; p.i < len(p.lines); p.i++ {
+		t := strings.TrimSpace(p.lines[p.i])
+		if t != "" && t != "---" && !strings.HasPrefix(t, "#") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *yamlParser) indent() int {
+	l := p.lines[p.i]
+	return len(l) - len(strings.TrimLeft(l, " "))
+}
+
+func (p *yamlParser) text() string {
+	return strings.TrimSpace(stripComment(p.lines[p.i]))
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("yaml line %d: %s", p.i+1, fmt.Sprintf(format, args...))
+}
+
+func isItem(t string) bool { return t == "-" || strings.HasPrefix(t, "- ") }
+
+func (p *yamlParser) node(indent int) (any, error) {
+	if strings.HasPrefix(p.lines[p.i], "\t") {
+		return nil, p.errorf("tabs are not allowed for indentation")
+	}
+	t := p.text()
+	if isItem(t) {
+		return p.list(indent)
+	}
+	if _, _, ok := cutKey(t); ok {
+		return p.mapping(indent)
+	}
+	p.i++
+	return scalar(t)
+}
+
+func (p *yamlParser) list(indent int) (any, error) {
+	out := []any{}
+	for p.skip() && p.indent() == indent && isItem(p.text()) {
+		if p.text() == "-" {
+			p.i++
+			if !p.skip() || p.indent() <= indent {
+				out = append(out, "")
+				continue
+			}
+			v, err := p.node(p.indent())
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+			continue
+		}
+		// blank out the dash so "- key: v" parses as a node one level in,
+		// with its following "  key2: v" lines lining up
+		line := p.lines[p.i]
+		p.lines[p.i] = line[:indent] + " " + line[indent+1:]
+		v, err := p.node(p.indent())
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (p *yamlParser) mapping(indent int) (any, error) {
+	out := map[string]any{}
+	for p.skip() {
+		switch n := p.indent(); {
+		case n < indent:
+			return out, nil
+		case n > indent:
+			return nil, p.errorf("unexpected indentation")
+		}
+		t := p.text()
+		if isItem(t) {
+			return out, nil // a list item of an enclosing list
+		}
+		k, v, ok := cutKey(t)
+		if !ok {
+			return nil, p.errorf("expected key: value")
+		}
+		key, err := scalar(k)
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		ks, _ := key.(string)
+		if _, dup := out[ks]; dup {
+			return nil, p.errorf("duplicate key %q", ks)
+		}
+		p.i++
+
+		switch v {
+		case "|", "|-":
+			out[ks] = p.block(indent, v == "|")
+		case "":
+			// the value is the nested node below, or a list at the same
+			// indent; otherwise it is empty
+			if !p.skip() || p.indent() < indent || p.indent() == indent && !isItem(p.text()) {
+				out[ks] = ""
+				continue
+			}
+			child, err := p.node(p.indent())
+			if err != nil {
+				return nil, err
+			}
+			out[ks] = child
+		default:
+			sv, err := scalar(v)
+			if err != nil {
+				return nil, p.errorf("%v", err)
+			}
+			out[ks] = sv
+		}
+	}
+	return out, nil
+}
+
+// block reads the lines of a block scalar: everything indented deeper
+// than parent, with the first line's indentation removed. keepNewline is
+// the difference between | and |-.
+func (p *yamlParser) block(parent int, keepNewline bool) string {
+	var lines []string
+	ind := -1
+	for ; p.i < len(p.lines); p.i++ {
+		l := strings.TrimRight(p.lines[p.i], " \r")
+		if strings.TrimSpace(l) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		n := len(l) - len(strings.TrimLeft(l, " "))
+		if n <= parent {
+			break
+		}
+		if ind < 0 {
+			ind = n
+		}
+		lines = append(lines, l[min(n, ind):])
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	s := strings.Join(lines, "\n")
+	if keepNewline && s != "" {
+		s += "\n"
+	}
+	return s
+}
+
+// cutKey splits "key: value" (or "key:") outside of quotes.
+func cutKey(t string) (key, value string, ok bool) {
+	start := 0
+	if t != "" && (t[0] == '"' || t[0] == '\'') {
+		end := strings.IndexByte(t[1:], t[0])
+		if end < 0 {
+			return "", "", false
+		}
+		start = end + 2
+	}
+	i := strings.Index(t[start:], ": ")
+	switch {
+	case i >= 0:
+		i += start
+		return t[:i], strings.TrimSpace(t[i+2:]), true
+	case strings.HasSuffix(t, ":") && len(t) > 1:
+		return t[:len(t)-1], "", true
+	}
+	return "", "", false
+}
+
+func scalar(s string) (any, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("bad quoted string %s", s)
+		}
+		return v, nil
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return nil, fmt.Errorf("bad quoted string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]"):
+		out := []any{}
+		for _, item := range splitFlow(s[1 : len(s)-1]) {
+			v, err := scalar(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	return s, nil
+}
+
+// splitFlow splits the inside of [a, "b, c"] on commas outside quotes.
+func splitFlow(s string) []string {
+	var out []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			out = append(out, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" || len(out) > 0 {
+		out = append(out, last)
+	}
+	return out
+}
+
+// stripComment removes a # comment that starts a line or follows a space,
+// outside of quoted scalars.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t:[,", line[i-1]) >= 0):
+			quote = c // only a quote that opens a scalar; "what's" is plain
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
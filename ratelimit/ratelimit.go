@@ -0,0 +1,129 @@
+// Package ratelimit implements a token-bucket rate limiter.
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so refill behaviour can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Bucket holds up to burst tokens and refills at rate tokens per second.
+// Each allowed event consumes one token. It is safe for concurrent use.
+type Bucket struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Option configures a Bucket.
+type Option func(*Bucket)
+
+// WithClock makes the bucket read time from c instead of the system clock.
+func WithClock(c Clock) Option {
+	return func(b *Bucket) { b.clock = c }
+}
+
+// New returns a full bucket that allows rate events per second with bursts
+// of up to burst events. A burst below 1 is taken as 1: a bucket that
+// can't hold a whole token would never allow anything.
+func New(rate float64, burst int, opts ...Option) *Bucket {
+	b := &Bucket{
+		clock: realClock{},
+		rate:  rate,
+		burst: float64(max(burst, 1)),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.tokens = b.burst
+	b.last = b.clock.Now()
+	return b
+}
+
+// refill adds the tokens earned since the last call. b.mu must be held.
+func (b *Bucket) refill() {
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+	}
+	b.last = now
+}
+
+// Allow reports whether an event may happen now, consuming a token if so.
+func (b *Bucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+	return false
+}
+
+// Tokens returns the number of tokens currently available.
+func (b *Bucket) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	return b.tokens
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *Bucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		b.refill()
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		var wait time.Duration
+		if b.rate > 0 {
+			wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		}
+		b.mu.Unlock()
+
+		if b.rate <= 0 {
+			<-ctx.Done() // the bucket never refills
+			return ctx.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.clock.After(wait):
+		}
+	}
+}
+
+// Middleware rejects requests with 429 Too Many Requests once b is empty.
+func Middleware(b *Bucket) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !b.Allow() {
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
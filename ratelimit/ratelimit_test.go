@@ -0,0 +1,169 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/ratelimit"
+)
+
+// fakeClock only moves when Advance is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newClock() *fakeClock { return &fakeClock{now: time.Unix(1_700_000_000, 0)} }
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := waiter{c.now.Add(d), make(chan time.Time, 1)}
+	if d <= 0 {
+		w.c <- c.now
+		return w.c
+	}
+	c.waiters = append(c.waiters, w)
+	return w.c
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	kept := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			kept = append(kept, w)
+			continue
+		}
+		w.c <- c.now
+	}
+	c.waiters = kept
+}
+
+// blocked waits until n calls to After are pending.
+func (c *fakeClock) blocked(t *testing.T, n int) {
+	t.Helper()
+	for range 1000 {
+		c.mu.Lock()
+		got := len(c.waiters)
+		c.mu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no call to After after a second")
+}
+
+func TestBurst(t *testing.T) {
+	b := ratelimit.New(1, 3, ratelimit.WithClock(newClock()))
+	for i := range 3 {
+		if !b.Allow() {
+			t.Fatalf("event %d of the burst refused", i+1)
+		}
+	}
+	if b.Allow() {
+		t.Fatal("allowed past the burst")
+	}
+}
+
+func TestRefill(t *testing.T) {
+	clock := newClock()
+	b := ratelimit.New(2, 4, ratelimit.WithClock(clock))
+	for b.Allow() {
+	}
+
+	clock.Advance(250 * time.Millisecond) // half a token
+	if b.Allow() {
+		t.Fatal("allowed with half a token")
+	}
+	clock.Advance(250 * time.Millisecond)
+	if !b.Allow() || b.Allow() {
+		t.Fatal("want exactly one token after 500ms at 2/s")
+	}
+
+	clock.Advance(time.Hour) // refill stops at the burst
+	if got := b.Tokens(); got != 4 {
+		t.Fatalf("Tokens = %v after an hour, want 4", got)
+	}
+}
+
+func TestBurstBelowOne(t *testing.T) {
+	clock := newClock()
+	b := ratelimit.New(1, 0, ratelimit.WithClock(clock))
+	if !b.Allow() || b.Allow() {
+		t.Fatal("burst 0 should hold one token")
+	}
+	clock.Advance(time.Second)
+	if !b.Allow() {
+		t.Fatal("no token after refilling")
+	}
+}
+
+func TestWait(t *testing.T) {
+	clock := newClock()
+	b := ratelimit.New(1, 1, ratelimit.WithClock(clock))
+	b.Allow()
+
+	done := make(chan error, 1)
+	go func() { done <- b.Wait(context.Background()) }()
+	clock.blocked(t, 1)
+	select {
+	case err := <-done:
+		t.Fatalf("Wait returned %v before a token was due", err)
+	default:
+	}
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+}
+
+func TestWaitCancel(t *testing.T) {
+	clock := newClock()
+	b := ratelimit.New(1, 1, ratelimit.WithClock(clock))
+	b.Allow()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- b.Wait(ctx) }()
+	clock.blocked(t, 1)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Wait = %v, want context.Canceled", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	b := ratelimit.New(1, 2, ratelimit.WithClock(newClock()))
+	h := ratelimit.Middleware(b)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i, want := range []int{200, 200, 429} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+		if rec.Code != want {
+			t.Fatalf("request %d: status %d, want %d", i+1, rec.Code, want)
+		}
+		if want == 429 && rec.Header().Get("Retry-After") != "1" {
+			t.Errorf("Retry-After = %q", rec.Header().Get("Retry-After"))
+		}
+	}
+}
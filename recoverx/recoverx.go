@@ -0,0 +1,44 @@
+// Package recoverx turns panics into ordinary errors.
+package recoverx
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a recovered panic together with the stack where it happened.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it was an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Do runs fn and returns a *PanicError if it panics.
+func Do(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	fn()
+	return nil
+}
+
+// Go runs fn on a new goroutine. If fn panics, onPanic receives the
+// *PanicError instead of the whole program crashing.
+func Go(fn func(), onPanic func(error)) {
+	go func() {
+		if err := Do(fn); err != nil && onPanic != nil {
+			onPanic(err)
+		}
+	}()
+}
@@ -0,0 +1,12 @@
+package main
+
+// Exercise: implement SumDigits recursively, then check it with
+//
+//	go run ./cmd/learn check recursion
+
+// SumDigits returns the sum of the decimal digits of n, ignoring the
+// sign.
+func SumDigits(n int) int {
+	// TODO: your code here
+	return 0
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/armaanepiic/Golang/memo"
+)
+
+// plain recursion: fib(n) calls fib(n-1) and fib(n-2) again and again
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+var calls int
+
+func main() {
+	fmt.Println("fib(30) =", fib(30))
+
+	// memoized recursion: every n is computed only once
+	var fastFib *memo.Memo[int, int]
+	fastFib = memo.Func(func(n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return fastFib.Call(n-1) + fastFib.Call(n-2)
+	})
+
+	fmt.Println("fastFib(90) =", fastFib.Call(90))
+	fmt.Println("calls =", calls)
+	fmt.Printf("stats = %+v\n", fastFib.Stats())
+}
+
+/*
+
+	fib(5)
+	├── fib(4)
+	│   ├── fib(3)
+	│   │   ├── fib(2)
+	│   │   └── fib(1)
+	│   └── fib(2)
+	└── fib(3)        <- already computed once, memo returns it
+	    ├── fib(2)
+	    └── fib(1)
+
+	plain recursion  => O(2^n) calls
+	with memo        => O(n) calls
+
+*/
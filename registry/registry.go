@@ -0,0 +1,116 @@
+// Package registry collects the commands of a multi-command program. Each
+// command registers itself from an init function in its own file, so
+// adding one is adding a file: the dispatcher and the usage text are
+// built from whatever was registered.
+//
+//	func init() {
+//		registry.Register(registry.Command{
+//			Name:    "list",
+//			Summary: "list the example topics",
+//			Run:     listCmd,
+//		})
+//	}
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Command is one named command.
+type Command struct {
+	Name    string
+	Aliases []string
+	Args    string // synopsis of the arguments, e.g. "<topic> [args]"
+	Summary string
+	Run     func(ctx context.Context, args []string) error
+}
+
+// ErrUnknown is returned by Run for a name nothing registered.
+var ErrUnknown = errors.New("registry: unknown command")
+
+// Registry holds a set of commands.
+type Registry struct {
+	mu     sync.Mutex
+	byName map[string]Command // names and aliases
+	cmds   []Command
+}
+
+// New returns an empty registry.
+func New() *Registry {
+	return &Registry{byName: make(map[string]Command)}
+}
+
+// Default is the registry used by the package level helpers.
+var Default = New()
+
+// Register adds c. It panics if c has no name or Run, or if its name or
+// an alias is taken: both are mistakes in the program, not in its input.
+func (r *Registry) Register(c Command) {
+	if c.Name == "" || c.Run == nil {
+		panic(fmt.Sprintf("registry: command %q needs a name and a Run func", c.Name))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range append([]string{c.Name}, c.Aliases...) {
+		if _, dup := r.byName[name]; dup {
+			panic("registry: duplicate command " + name)
+		}
+		r.byName[name] = c
+	}
+	r.cmds = append(r.cmds, c)
+}
+
+// Lookup finds a command by name or alias.
+func (r *Registry) Lookup(name string) (Command, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.byName[name]
+	return c, ok
+}
+
+// All returns the commands sorted by name.
+func (r *Registry) All() []Command {
+	r.mu.Lock()
+	cmds := slices.Clone(r.cmds)
+	r.mu.Unlock()
+	slices.SortFunc(cmds, func(a, b Command) int { return strings.Compare(a.Name, b.Name) })
+	return cmds
+}
+
+// Run runs the command named by args[0] with the rest of args.
+func (r *Registry) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: none given", ErrUnknown)
+	}
+	c, ok := r.Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknown, args[0])
+	}
+	return c.Run(ctx, args[1:])
+}
+
+// Usage writes a usage message for prog listing every command.
+func (r *Registry) Usage(w io.Writer, prog string) error {
+	fmt.Fprintf(w, "usage: %s <command> [arguments]\n\ncommands:\n", prog)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r.All() {
+		fmt.Fprintf(tw, "  %s\t%s\n", strings.TrimSpace(c.Name+" "+c.Args), c.Summary)
+	}
+	return tw.Flush()
+}
+
+// Register adds c to Default.
+func Register(c Command) { Default.Register(c) }
+
+// Lookup finds a command in Default.
+func Lookup(name string) (Command, bool) { return Default.Lookup(name) }
+
+// All returns the commands in Default.
+func All() []Command { return Default.All() }
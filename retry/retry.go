@@ -0,0 +1,128 @@
+// Package retry re-runs fallible operations with exponential backoff.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff returns how long to wait before the given retry (1 for the first
+// retry, 2 for the second and so on).
+type Backoff func(retry int) time.Duration
+
+// Sleeper waits for d or until ctx is done, whichever comes first.
+type Sleeper func(ctx context.Context, d time.Duration) error
+
+type config struct {
+	attempts int
+	backoff  Backoff
+	sleep    Sleeper
+}
+
+// Option configures Do.
+type Option func(*config)
+
+// WithMaxAttempts sets the total number of calls, including the first one.
+// The default is 3.
+func WithMaxAttempts(n int) Option {
+	return func(c *config) { c.attempts = n }
+}
+
+// WithBackoff sets the delay policy. The default is
+// Exponential(100*time.Millisecond, 5*time.Second).
+func WithBackoff(b Backoff) Option {
+	return func(c *config) { c.backoff = b }
+}
+
+// WithSleeper replaces the real sleep, so tests can run without waiting.
+func WithSleeper(s Sleeper) Option {
+	return func(c *config) { c.sleep = s }
+}
+
+// Exponential doubles the delay on every retry starting at base, caps it at
+// max and applies "full jitter": the actual delay is uniform in [0, d).
+func Exponential(base, max time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		d := base
+		for i := 1; i < retry && d < max; i++ {
+			d *= 2
+		}
+		d = min(d, max)
+		if d <= 0 {
+			return 0
+		}
+		return rand.N(d)
+	}
+}
+
+// Constant waits d between every attempt.
+func Constant(d time.Duration) Backoff {
+	return func(int) time.Duration { return d }
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying; Do returns it immediately.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Do calls fn until it succeeds, returns a Permanent error, the attempts
+// run out or ctx is done. It returns the last error from fn, unwrapped from
+// Permanent, or ctx.Err() if the context ended first.
+func Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	cfg := config{
+		attempts: 3,
+		backoff:  Exponential(100*time.Millisecond, 5*time.Second),
+		sleep:    sleepContext,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+		var p *permanentError
+		if errors.As(err, &p) {
+			return p.err
+		}
+		if attempt >= cfg.attempts {
+			return err
+		}
+		if serr := cfg.sleep(ctx, cfg.backoff(attempt)); serr != nil {
+			return serr
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/retry"
+)
+
+var errFlaky = errors.New("flaky")
+
+// recorder is a Sleeper that returns at once and keeps the delays asked for.
+type recorder struct{ delays []time.Duration }
+
+func (r *recorder) sleep(ctx context.Context, d time.Duration) error {
+	r.delays = append(r.delays, d)
+	return ctx.Err()
+}
+
+func TestSucceedsAfterRetries(t *testing.T) {
+	var rec recorder
+	calls := 0
+	err := retry.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errFlaky
+		}
+		return nil
+	}, retry.WithMaxAttempts(5), retry.WithBackoff(retry.Constant(time.Second)), retry.WithSleeper(rec.sleep))
+	if err != nil || calls != 3 {
+		t.Fatalf("Do = %v after %d calls", err, calls)
+	}
+	if want := []time.Duration{time.Second, time.Second}; !slices.Equal(rec.delays, want) {
+		t.Fatalf("delays %v, want %v", rec.delays, want)
+	}
+}
+
+func TestAttemptsExhausted(t *testing.T) {
+	var rec recorder
+	calls := 0
+	err := retry.Do(context.Background(), func(context.Context) error {
+		calls++
+		return fmt.Errorf("call %d: %w", calls, errFlaky)
+	}, retry.WithMaxAttempts(4), retry.WithBackoff(retry.Constant(time.Millisecond)), retry.WithSleeper(rec.sleep))
+	if calls != 4 || len(rec.delays) != 3 {
+		t.Fatalf("%d calls and %d sleeps, want 4 and 3", calls, len(rec.delays))
+	}
+	if !errors.Is(err, errFlaky) || err.Error() != "call 4: flaky" {
+		t.Fatalf("Do = %v, want the last error", err)
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	var rec recorder
+	calls := 0
+	err := retry.Do(context.Background(), func(context.Context) error {
+		calls++
+		return retry.Permanent(errFlaky)
+	}, retry.WithSleeper(rec.sleep))
+	if calls != 1 || len(rec.delays) != 0 {
+		t.Fatalf("%d calls and %d sleeps after a permanent error", calls, len(rec.delays))
+	}
+	if err != errFlaky || retry.IsPermanent(err) {
+		t.Fatalf("Do = %#v, want the unwrapped error", err)
+	}
+	if retry.Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+	if !retry.IsPermanent(fmt.Errorf("wrapped: %w", retry.Permanent(errFlaky))) {
+		t.Error("IsPermanent misses a wrapped Permanent")
+	}
+}
+
+func TestCancelDuringSleep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retry.Do(ctx, func(context.Context) error {
+		calls++
+		return errFlaky
+	}, retry.WithMaxAttempts(10), retry.WithSleeper(func(ctx context.Context, d time.Duration) error {
+		cancel() // the context ends while we sleep
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Fatalf("Do = %v after %d calls, want Canceled after 1", err, calls)
+	}
+}
+
+func TestRealSleepCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := retry.Do(ctx, func(context.Context) error { return errFlaky },
+		retry.WithBackoff(retry.Constant(time.Hour)))
+	if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 5*time.Second {
+		t.Fatalf("Do = %v after %v", err, time.Since(start))
+	}
+}
+
+func TestExponential(t *testing.T) {
+	b := retry.Exponential(100*time.Millisecond, time.Second)
+	// ceilings double from base and stop at max
+	ceilings := []time.Duration{100, 200, 400, 800, 1000, 1000, 1000}
+	for i, c := range ceilings {
+		ceiling := c * time.Millisecond
+		for range 200 {
+			d := b(i + 1)
+			if d < 0 || d >= ceiling {
+				t.Fatalf("retry %d: delay %v outside [0, %v)", i+1, d, ceiling)
+			}
+		}
+	}
+	// jitter spreads the delays rather than pinning them to the ceiling
+	var low, high int
+	for range 1000 {
+		if d := b(10); d < 500*time.Millisecond {
+			low++
+		} else {
+			high++
+		}
+	}
+	if low < 300 || high < 300 {
+		t.Errorf("full jitter looks skewed: %d below half the cap, %d above", low, high)
+	}
+	if d := retry.Exponential(0, time.Second)(3); d != 0 {
+		t.Errorf("zero base gave %v", d)
+	}
+}
@@ -0,0 +1,165 @@
+package rpcuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"strings"
+	"sync"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/retry"
+)
+
+// DialFunc opens a connection to the server.
+type DialFunc func(ctx context.Context) (io.ReadWriteCloser, error)
+
+// Client is a typed client for the user service. It dials lazily,
+// redials after the connection breaks and retries with the retry
+// package's backoff. It is safe for concurrent use.
+//
+// Calls that are safe to repeat (Get, List, Update, Delete) are retried
+// on any connection error. Create is retried only when the request
+// cannot have reached the server, so it never creates a user twice.
+type Client struct {
+	dial DialFunc
+	opts []retry.Option
+
+	mu sync.Mutex
+	rc *rpc.Client
+}
+
+// NewClient returns a client that connects with dial. opts configure the
+// retries; the retry package's defaults apply otherwise.
+func NewClient(dial DialFunc, opts ...retry.Option) *Client {
+	return &Client{dial: dial, opts: opts}
+}
+
+// Dial returns a client for the TCP address addr and checks that the
+// server is reachable, retrying per opts.
+func Dial(ctx context.Context, addr string, opts ...retry.Option) (*Client, error) {
+	c := NewClient(func(ctx context.Context) (io.ReadWriteCloser, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", addr)
+	}, opts...)
+	err := retry.Do(ctx, func(ctx context.Context) error {
+		_, err := c.conn(ctx)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close closes the current connection, if any.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rc == nil {
+		return nil
+	}
+	err := c.rc.Close()
+	c.rc = nil
+	return err
+}
+
+func (c *Client) Get(ctx context.Context, id int) (userstore.User, error) {
+	var u userstore.User
+	err := c.call(ctx, "Get", IDArgs{ID: id}, &u, true)
+	return u, err
+}
+
+func (c *Client) List(ctx context.Context) ([]userstore.User, error) {
+	var users []userstore.User
+	err := c.call(ctx, "List", Empty{}, &users, true)
+	return users, err
+}
+
+func (c *Client) Create(ctx context.Context, u userstore.User) (userstore.User, error) {
+	var created userstore.User
+	err := c.call(ctx, "Create", u, &created, false)
+	return created, err
+}
+
+func (c *Client) Update(ctx context.Context, u userstore.User) error {
+	return c.call(ctx, "Update", u, &Empty{}, true)
+}
+
+func (c *Client) Delete(ctx context.Context, id int) error {
+	return c.call(ctx, "Delete", IDArgs{ID: id}, &Empty{}, true)
+}
+
+// conn returns the live rpc client, dialing if there is none.
+func (c *Client) conn(ctx context.Context) (*rpc.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rc == nil {
+		rwc, err := c.dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.rc = rpc.NewClient(rwc)
+	}
+	return c.rc, nil
+}
+
+// drop forgets rc after it failed, so the next call redials.
+func (c *Client) drop(rc *rpc.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rc == rc {
+		rc.Close()
+		c.rc = nil
+	}
+}
+
+func (c *Client) call(ctx context.Context, method string, args, reply any, idempotent bool) error {
+	return retry.Do(ctx, func(ctx context.Context) error {
+		rc, err := c.conn(ctx)
+		if err != nil {
+			return err
+		}
+		call := rc.Go(ServiceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+		select {
+		case <-call.Done:
+		case <-ctx.Done():
+			return retry.Permanent(ctx.Err())
+		}
+
+		var serr rpc.ServerError
+		switch err := call.Error; {
+		case err == nil:
+			return nil
+		case errors.As(err, &serr):
+			// the server ran the method and said no; retrying won't help
+			return retry.Permanent(serverError(serr))
+		case err == rpc.ErrShutdown:
+			// the connection was already broken; nothing was sent
+			c.drop(rc)
+			return err
+		default:
+			c.drop(rc)
+			if !idempotent {
+				return retry.Permanent(err)
+			}
+			return err
+		}
+	}, c.opts...)
+}
+
+// serverError turns the message of an error returned by the service back
+// into the package's sentinel errors, so errors.Is works on the client.
+func serverError(e rpc.ServerError) error {
+	msg := string(e)
+	switch {
+	case msg == userstore.ErrNotFound.Error():
+		return userstore.ErrNotFound
+	case strings.HasPrefix(msg, ErrInvalid.Error()):
+		return fmt.Errorf("%w%s", ErrInvalid, strings.TrimPrefix(msg, ErrInvalid.Error()))
+	}
+	return e
+}
@@ -0,0 +1,157 @@
+package rpcuser_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/retry"
+	"github.com/armaanepiic/Golang/rpcuser"
+)
+
+func noSleep(ctx context.Context, d time.Duration) error { return ctx.Err() }
+
+// pipeClient returns a client whose every dial gets a fresh net.Pipe with
+// the server on the other end, and a counter of those dials.
+func pipeClient(t *testing.T, store *userstore.Store) (*rpcuser.Client, *atomic.Int32) {
+	t.Helper()
+	srv := rpcuser.NewServer(store)
+	var dials atomic.Int32
+	c := rpcuser.NewClient(func(context.Context) (io.ReadWriteCloser, error) {
+		dials.Add(1)
+		serverEnd, clientEnd := net.Pipe()
+		go srv.ServeConn(serverEnd)
+		return clientEnd, nil
+	}, retry.WithSleeper(noSleep))
+	t.Cleanup(func() { c.Close() })
+	return c, &dials
+}
+
+func TestCRUD(t *testing.T) {
+	store := userstore.New()
+	store.Create(userstore.User{Name: "Arman", Age: 30})
+	c, dials := pipeClient(t, store)
+	ctx := context.Background()
+
+	u, err := c.Create(ctx, userstore.User{Name: "Nusrat", Age: 28})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.ID == 0 || u.Name != "Nusrat" {
+		t.Fatalf("Create = %+v", u)
+	}
+	u.Age++
+	if err := c.Update(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.Get(ctx, u.ID)
+	if err != nil || got != u {
+		t.Fatalf("Get = %+v, %v; want %+v", got, err, u)
+	}
+	users, err := c.List(ctx)
+	if err != nil || len(users) != 2 {
+		t.Fatalf("List = %v, %v", users, err)
+	}
+	if err := c.Delete(ctx, u.ID); err != nil {
+		t.Fatal(err)
+	}
+	if store.Len() != 1 {
+		t.Fatalf("store has %d users after Delete, want 1", store.Len())
+	}
+	if n := dials.Load(); n != 1 {
+		t.Fatalf("%d dials, want 1: the connection should be reused", n)
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	c, _ := pipeClient(t, userstore.New())
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, 999); !errors.Is(err, userstore.ErrNotFound) {
+		t.Errorf("Get(999) = %v, want ErrNotFound", err)
+	}
+	if err := c.Delete(ctx, 999); !errors.Is(err, userstore.ErrNotFound) {
+		t.Errorf("Delete(999) = %v, want ErrNotFound", err)
+	}
+	for _, u := range []userstore.User{{Name: "  "}, {Name: "x", Age: -1}} {
+		if _, err := c.Create(ctx, u); !errors.Is(err, rpcuser.ErrInvalid) {
+			t.Errorf("Create(%+v) = %v, want ErrInvalid", u, err)
+		}
+	}
+}
+
+func TestRedial(t *testing.T) {
+	store := userstore.New()
+	store.Create(userstore.User{Name: "Arman", Age: 30})
+	c, dials := pipeClient(t, store)
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	if _, err := c.Get(ctx, 1); err != nil {
+		t.Fatalf("Get after Close: %v", err)
+	}
+	if n := dials.Load(); n != 2 {
+		t.Fatalf("%d dials, want 2", n)
+	}
+}
+
+// TestCreateNotRetried talks to a server that drops every connection as
+// soon as a request starts to arrive. Get may be sent again; Create may
+// not, since the server could have acted on it.
+func TestCreateNotRetried(t *testing.T) {
+	var dials atomic.Int32
+	c := rpcuser.NewClient(func(context.Context) (io.ReadWriteCloser, error) {
+		dials.Add(1)
+		serverEnd, clientEnd := net.Pipe()
+		go func() {
+			serverEnd.Read(make([]byte, 1))
+			serverEnd.Close()
+		}()
+		return clientEnd, nil
+	}, retry.WithMaxAttempts(3), retry.WithSleeper(noSleep))
+	defer c.Close()
+	ctx := context.Background()
+
+	if _, err := c.Create(ctx, userstore.User{Name: "Once", Age: 1}); err == nil {
+		t.Fatal("Create on a broken connection succeeded")
+	}
+	if n := dials.Swap(0); n != 1 {
+		t.Fatalf("Create dialed %d times, want 1", n)
+	}
+	if _, err := c.Get(ctx, 1); err == nil {
+		t.Fatal("Get on a broken connection succeeded")
+	}
+	if n := dials.Load(); n != 3 {
+		t.Fatalf("Get dialed %d times, want 3", n)
+	}
+}
+
+func TestDialTCP(t *testing.T) {
+	store := userstore.New()
+	store.Create(userstore.User{Name: "Arman", Age: 30})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go rpcuser.NewServer(store).Accept(ln)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := rpcuser.Dial(ctx, ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if u, err := c.Get(ctx, 1); err != nil || u.Name != "Arman" {
+		t.Fatalf("Get = %+v, %v", u, err)
+	}
+}
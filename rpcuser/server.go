@@ -0,0 +1,90 @@
+// Package rpcuser serves a userstore.Repository with the standard
+// library's net/rpc (gob encoded), as a dependency-free alternative to
+// userapi (REST) and usergrpc (gRPC).
+//
+// net/rpc has no IDL: any exported method of the form
+//
+//	func (t *T) Name(args A, reply *R) error
+//
+// is callable as "Service.Name". Client wraps those stringly-typed calls
+// in ordinary Go methods.
+package rpcuser
+
+import (
+	"errors"
+	"fmt"
+	"net/rpc"
+	"strings"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+)
+
+// ServiceName is the name the service is registered under.
+const ServiceName = "UserService"
+
+// ErrInvalid is returned for users that fail validation.
+var ErrInvalid = errors.New("rpcuser: invalid user")
+
+type (
+	// IDArgs selects a user by ID.
+	IDArgs struct{ ID int }
+	// Empty is the argument or reply of calls that have none.
+	Empty struct{}
+)
+
+// Service holds the RPC methods. It is exported only because net/rpc
+// requires it; use NewServer.
+type Service struct {
+	repo userstore.Repository
+}
+
+// NewServer returns an rpc.Server with the user service registered. Serve
+// it with Accept(listener) or ServeConn(conn).
+func NewServer(repo userstore.Repository) *rpc.Server {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(ServiceName, &Service{repo: repo}); err != nil {
+		panic(err) // only possible if Service's methods are malformed
+	}
+	return srv
+}
+
+func (s *Service) Get(args IDArgs, reply *userstore.User) error {
+	u, err := s.repo.Get(args.ID)
+	*reply = u
+	return err
+}
+
+func (s *Service) List(_ Empty, reply *[]userstore.User) error {
+	*reply = s.repo.List()
+	return nil
+}
+
+func (s *Service) Create(u userstore.User, reply *userstore.User) error {
+	if err := validate(&u); err != nil {
+		return err
+	}
+	*reply = s.repo.Create(u)
+	return nil
+}
+
+func (s *Service) Update(u userstore.User, _ *Empty) error {
+	if err := validate(&u); err != nil {
+		return err
+	}
+	return s.repo.Update(u)
+}
+
+func (s *Service) Delete(args IDArgs, _ *Empty) error {
+	return s.repo.Delete(args.ID)
+}
+
+func validate(u *userstore.User) error {
+	u.Name = strings.TrimSpace(u.Name)
+	switch {
+	case u.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalid)
+	case u.Age < 0:
+		return fmt.Errorf("%w: age must not be negative", ErrInvalid)
+	}
+	return nil
+}
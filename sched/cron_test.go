@@ -0,0 +1,85 @@
+package sched
+
+import (
+	"testing"
+	"time"
+)
+
+// at parses a time in UTC for the tests; the cron fields use the
+// location of the time they are given.
+func at(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		expr, from string
+		want       []string // successive firings
+	}{
+		{"* * * * *", "2024-03-10 12:00", []string{"2024-03-10 12:01", "2024-03-10 12:02"}},
+		{"*/15 * * * *", "2024-03-10 12:07", []string{"2024-03-10 12:15", "2024-03-10 12:30", "2024-03-10 12:45", "2024-03-10 13:00"}},
+		{"30 9-17/4 * * *", "2024-03-10 10:00", []string{"2024-03-10 13:30", "2024-03-10 17:30", "2024-03-11 09:30"}},
+		// Friday the 8th, 17:00 -> Monday 9:00
+		{"0 9 * * 1-5", "2024-03-08 17:00", []string{"2024-03-11 09:00", "2024-03-12 09:00"}},
+		{"0 0 29 2 *", "2024-03-01 00:00", []string{"2028-02-29 00:00"}},
+		{"0 0 31 * *", "2024-04-01 00:00", []string{"2024-05-31 00:00", "2024-07-31 00:00"}},
+		{"5,10 0 1 1,7 *", "2024-01-01 00:05", []string{"2024-01-01 00:10", "2024-07-01 00:05"}},
+		// both day fields restricted: the 1st OR a Monday (March 1st 2024 is a Friday)
+		{"0 0 1 * 1", "2024-02-28 12:00", []string{"2024-03-01 00:00", "2024-03-04 00:00", "2024-03-11 00:00"}},
+		// one day field is "*": only the other one counts
+		{"0 0 * * 1", "2024-02-28 12:00", []string{"2024-03-04 00:00"}},
+		{"0 0 1 * *", "2024-02-28 12:00", []string{"2024-03-01 00:00", "2024-04-01 00:00"}},
+		// "*/2" restricts nothing useful but starts with "*", so it is ANDed
+		{"0 0 */2 * 1", "2024-03-01 00:00", []string{"2024-03-11 00:00"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		now := at(t, tt.from)
+		for _, w := range tt.want {
+			now = c.Next(now)
+			if want := at(t, w); !now.Equal(want) {
+				t.Errorf("%q from %s: got %s, want %s", tt.expr, tt.from, now.Format("2006-01-02 15:04 Mon"), w)
+				break
+			}
+		}
+	}
+}
+
+func TestCronNever(t *testing.T) {
+	c, err := ParseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Next(at(t, "2024-01-01 00:00")); !got.IsZero() {
+		t.Errorf("February 31st: Next = %v, want the zero time", got)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1-x * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Package sched runs jobs periodically inside the current process.
+//
+// Jobs run on their own goroutines, either every fixed interval or on a
+// cron schedule. A panicking job is recovered and reported instead of
+// crashing the program, and Run returns only after every job in flight has
+// finished, which gives a graceful shutdown when its context is cancelled.
+package sched
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Schedule decides when a job runs next.
+type Schedule interface {
+	// Next returns the next run time after t, or the zero time for never.
+	Next(t time.Time) time.Time
+}
+
+type interval time.Duration
+
+func (d interval) Next(t time.Time) time.Time { return t.Add(time.Duration(d)) }
+
+// Job is the work a scheduler runs.
+type Job func(ctx context.Context) error
+
+// ErrorHandler receives the errors and recovered panics of jobs.
+type ErrorHandler func(name string, err error)
+
+type entry struct {
+	name  string
+	sched Schedule
+	job   Job
+}
+
+// Scheduler holds a set of jobs. Register jobs before calling Run.
+type Scheduler struct {
+	mu      sync.Mutex
+	entries []entry
+	running bool
+	onError ErrorHandler
+}
+
+// New returns an empty scheduler. A nil onError logs with slog.
+func New(onError ErrorHandler) *Scheduler {
+	if onError == nil {
+		onError = func(name string, err error) {
+			slog.Error("sched: job failed", "job", name, "err", err)
+		}
+	}
+	return &Scheduler{onError: onError}
+}
+
+// Every registers job to run every d, the first time d after Run starts.
+func (s *Scheduler) Every(name string, d time.Duration, job Job) error {
+	if d <= 0 {
+		return fmt.Errorf("sched: job %q: interval must be positive", name)
+	}
+	return s.Add(name, interval(d), job)
+}
+
+// Cron registers job to run on a cron expression (see ParseCron).
+func (s *Scheduler) Cron(name, expr string, job Job) error {
+	c, err := ParseCron(expr)
+	if err != nil {
+		return err
+	}
+	return s.Add(name, c, job)
+}
+
+// Add registers job with an arbitrary schedule.
+func (s *Scheduler) Add(name string, sched Schedule, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return errors.New("sched: cannot add jobs while running")
+	}
+	s.entries = append(s.entries, entry{name: name, sched: sched, job: job})
+	return nil
+}
+
+// Run starts every job and blocks until ctx is done and all running jobs
+// have returned. Jobs receive ctx, so long jobs should watch it too.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("sched: already running")
+	}
+	s.running = true
+	entries := append([]entry(nil), s.entries...)
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, e)
+		}()
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
+	return ctx.Err()
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	for {
+		next := e.sched.Next(time.Now())
+		if next.IsZero() {
+			return
+		}
+		t := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		if err := s.runOnce(ctx, e); err != nil {
+			s.onError(e.name, err)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, e entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return e.job(ctx)
+}
@@ -0,0 +1,128 @@
+package sched
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+// The tests run in a synctest bubble, whose clock only moves when every
+// goroutine in it is blocked: a fake clock for time.Now and timers alike.
+
+func TestEvery(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		s := New(nil)
+		var mu sync.Mutex
+		var runs []time.Duration
+		start := time.Now()
+		s.Every("tick", time.Minute, func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			runs = append(runs, time.Since(start))
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute+30*time.Second)
+		defer cancel()
+		if err := s.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Run = %v, want DeadlineExceeded", err)
+		}
+		want := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}
+		if len(runs) != len(want) {
+			t.Fatalf("ran at %v, want %v", runs, want)
+		}
+		for i := range want {
+			if runs[i] != want[i] {
+				t.Fatalf("ran at %v, want %v", runs, want)
+			}
+		}
+	})
+}
+
+func TestCronJob(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		s := New(nil)
+		var fired []string
+		// the bubble's clock starts at midnight UTC, 2000-01-01
+		s.Cron("quarter", "*/15 * * * *", func(ctx context.Context) error {
+			fired = append(fired, time.Now().UTC().Format("15:04"))
+			return nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour+time.Minute)
+		defer cancel()
+		s.Run(ctx)
+		if got := strings.Join(fired, " "); got != "00:15 00:30 00:45 01:00" {
+			t.Fatalf("fired at %s", got)
+		}
+	})
+}
+
+func TestErrorsAndPanics(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var mu sync.Mutex
+		reported := map[string]string{}
+		s := New(func(name string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported[name] = err.Error()
+		})
+		s.Every("fails", time.Second, func(ctx context.Context) error { return errors.New("no luck") })
+		s.Every("panics", time.Second, func(ctx context.Context) error { panic("oops") })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+		defer cancel()
+		s.Run(ctx)
+		if reported["fails"] != "no luck" {
+			t.Errorf("error of fails: %q", reported["fails"])
+		}
+		if reported["panics"] != "panic: oops" {
+			t.Errorf("error of panics: %q", reported["panics"])
+		}
+	})
+}
+
+func TestRunWaitsForJobs(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		s := New(nil)
+		finished := false
+		s.Every("slow", time.Second, func(ctx context.Context) error {
+			time.Sleep(10 * time.Second) // ignores ctx on purpose
+			finished = true
+			return nil
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(2 * time.Second) // the job is now running
+			cancel()
+		}()
+		s.Run(ctx)
+		if !finished {
+			t.Fatal("Run returned before the running job finished")
+		}
+	})
+}
+
+func TestAddWhileRunning(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		s := New(nil)
+		s.Every("tick", time.Hour, func(context.Context) error { return nil })
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- s.Run(ctx) }()
+		synctest.Wait()
+		if err := s.Every("late", time.Second, func(context.Context) error { return nil }); err == nil {
+			t.Error("Every while running succeeded, want an error")
+		}
+		if err := s.Run(ctx); err == nil {
+			t.Error("second Run succeeded, want an error")
+		}
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Run = %v, want Canceled", err)
+		}
+	})
+}
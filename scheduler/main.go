@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/sched"
+)
+
+func main() {
+	store := userstore.New()
+	store.Create(userstore.User{Name: "Arman", Age: 30})
+	store.Create(userstore.User{Name: "Nusrat", Age: 28})
+
+	snapshot := filepath.Join(os.TempDir(), "users.json")
+
+	s := sched.New(nil)
+
+	// add a user every 300ms so the snapshots change
+	s.Every("signup", 300*time.Millisecond, func(ctx context.Context) error {
+		u := store.Create(userstore.User{Name: "Guest", Age: 20})
+		fmt.Println("created user", u.ID)
+		return nil
+	})
+
+	// save the whole store to disk every second
+	s.Every("snapshot", time.Second, func(ctx context.Context) error {
+		if err := store.Save(snapshot); err != nil {
+			return err
+		}
+		fmt.Println("snapshot:", store.Len(), "users ->", snapshot)
+		return nil
+	})
+
+	// a broken job: the panic is recovered and reported, nothing crashes
+	s.Every("broken", 1500*time.Millisecond, func(ctx context.Context) error {
+		var m map[string]int
+		m["boom"]++
+		return nil
+	})
+
+	// stop after 3 seconds or on Ctrl+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	s.Run(ctx)
+	fmt.Println("scheduler stopped")
+}
@@ -0,0 +1,78 @@
+// Package set provides a generic set type built on a map.
+package set
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Set is an unordered collection of unique values. The zero value is not
+// usable; call New. A Set is not safe for concurrent use.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// New returns a set holding items.
+func New[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(items))}
+	for _, it := range items {
+		s.m[it] = struct{}{}
+	}
+	return s
+}
+
+// Add inserts v and reports whether it was not already present.
+func (s *Set[T]) Add(v T) bool {
+	if _, ok := s.m[v]; ok {
+		return false
+	}
+	s.m[v] = struct{}{}
+	return true
+}
+
+// Has reports whether v is in the set.
+func (s *Set[T]) Has(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Remove deletes v from the set.
+func (s *Set[T]) Remove(v T) {
+	delete(s.m, v)
+}
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// All iterates over the values in no particular order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.m)
+}
+
+// Union returns a new set with the values of s and o.
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	u := New[T]()
+	maps.Copy(u.m, s.m)
+	maps.Copy(u.m, o.m)
+	return u
+}
+
+// Intersect returns a new set with the values present in both s and o.
+func (s *Set[T]) Intersect(o *Set[T]) *Set[T] {
+	in := New[T]()
+	for v := range s.m {
+		if o.Has(v) {
+			in.m[v] = struct{}{}
+		}
+	}
+	return in
+}
+
+// Sorted returns the values of s in ascending order.
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	return slices.Sorted(s.All())
+}
@@ -0,0 +1,55 @@
+package set_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/armaanepiic/Golang/internal/slicesx"
+	"github.com/armaanepiic/Golang/prop"
+	"github.com/armaanepiic/Golang/set"
+)
+
+var (
+	ints  = prop.SliceOf(prop.Int())
+	pairs = prop.PairOf(prop.SliceOf(prop.IntRange(0, 9)), prop.SliceOf(prop.IntRange(0, 9)))
+)
+
+func TestAddHasRemove(t *testing.T) {
+	s := set.New(1, 2)
+	if s.Add(2) || !s.Add(3) {
+		t.Fatal("Add reports the wrong novelty")
+	}
+	s.Remove(1)
+	if s.Has(1) || !s.Has(3) || s.Len() != 2 {
+		t.Fatalf("got %v", set.Sorted(s))
+	}
+}
+
+func TestInclusionExclusion(t *testing.T) {
+	prop.ForAll(t, pairs, func(p prop.Pair[[]int, []int]) bool {
+		a, b := set.New(p.First...), set.New(p.Second...)
+		return a.Union(b).Len()+a.Intersect(b).Len() == a.Len()+b.Len()
+	})
+}
+
+func TestUnionAndIntersect(t *testing.T) {
+	prop.ForAll(t, pairs, func(p prop.Pair[[]int, []int]) bool {
+		a, b := set.New(p.First...), set.New(p.Second...)
+		for v := range a.Intersect(b).All() {
+			if !a.Has(v) || !b.Has(v) {
+				return false
+			}
+		}
+		u := a.Union(b)
+		return !slices.ContainsFunc(p.First, func(v int) bool { return !u.Has(v) }) &&
+			!slices.ContainsFunc(p.Second, func(v int) bool { return !u.Has(v) })
+	})
+}
+
+func TestSorted(t *testing.T) {
+	prop.ForAll(t, ints, func(s []int) bool {
+		want := slicesx.Uniq(slices.Clone(s))
+		slices.Sort(want)
+		return slices.Equal(set.Sorted(set.New(s...)), want)
+	})
+}
@@ -0,0 +1,124 @@
+// Package shortener implements a URL shortening service: POST /shorten
+// hands out short codes and GET /{code} redirects to the original URL.
+package shortener
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/armaanepiic/Golang/encx"
+)
+
+// Encode returns the base62 short code for n.
+func Encode(n uint64) string {
+	return encx.EncodeBase62(n)
+}
+
+// Decode parses a short code produced by Encode.
+func Decode(s string) (uint64, error) {
+	n, err := encx.DecodeBase62(s)
+	if err != nil {
+		return 0, fmt.Errorf("shortener: invalid code %q: %w", s, err)
+	}
+	return n, nil
+}
+
+// Server serves the shortener API on top of a Store.
+type Server struct {
+	store Store
+	base  string // public base URL used to build short links
+	mux   *http.ServeMux
+}
+
+// NewServer returns a Server. base is the public URL prefix of short links,
+// e.g. "http://localhost:8080".
+func NewServer(store Store, base string) *Server {
+	s := &Server{store: store, base: strings.TrimSuffix(base, "/"), mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /shorten", s.handleShorten)
+	s.mux.HandleFunc("GET /stats/{code}", s.handleStats)
+	s.mux.HandleFunc("GET /{code}", s.handleRedirect)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type shortenRequest struct {
+	URL string `json:"url"`
+}
+
+type shortenResponse struct {
+	Code     string `json:"code"`
+	ShortURL string `json:"short_url"`
+}
+
+func (s *Server) handleShorten(w http.ResponseWriter, r *http.Request) {
+	var req shortenRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		http.Error(w, "url must be an absolute http(s) URL", http.StatusBadRequest)
+		return
+	}
+
+	id, err := s.store.NextID()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	link := Link{Code: Encode(id), URL: u.String(), Created: time.Now().UTC()}
+	if err := s.store.Put(link); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, shortenResponse{Code: link.Code, ShortURL: s.base + "/" + link.Code})
+}
+
+func (s *Server) handleRedirect(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	link, err := s.store.Get(code)
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// the redirect matters more than the counter, so a failed Hit is
+	// only logged
+	if err := s.store.Hit(code); err != nil {
+		slog.Error("shortener: counting hit failed", "code", code, "err", err)
+	}
+	http.Redirect(w, r, link.URL, http.StatusFound)
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	link, err := s.store.Get(r.PathValue("code"))
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, link)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
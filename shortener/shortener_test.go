@@ -0,0 +1,138 @@
+package shortener
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func do(t *testing.T, h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+// shorten posts url and returns the code handed out.
+func shorten(t *testing.T, h http.Handler, url string) string {
+	t.Helper()
+	rec := do(t, h, http.MethodPost, "/shorten", `{"url":"`+url+`"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /shorten: %d %s", rec.Code, rec.Body)
+	}
+	var resp shortenResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.ShortURL != "http://sho.rt/"+resp.Code {
+		t.Fatalf("short_url = %q for code %q", resp.ShortURL, resp.Code)
+	}
+	return resp.Code
+}
+
+func TestShortenAndRedirect(t *testing.T) {
+	s := NewServer(NewMemoryStore(), "http://sho.rt/")
+	code := shorten(t, s, "https://go.dev/doc")
+	if other := shorten(t, s, "https://go.dev/doc"); other == code {
+		t.Fatalf("second link got the same code %q", code)
+	}
+
+	for range 3 {
+		rec := do(t, s, http.MethodGet, "/"+code, "")
+		if rec.Code != http.StatusFound || rec.Header().Get("Location") != "https://go.dev/doc" {
+			t.Fatalf("GET /%s: %d to %q", code, rec.Code, rec.Header().Get("Location"))
+		}
+	}
+
+	rec := do(t, s, http.MethodGet, "/stats/"+code, "")
+	var link Link
+	if err := json.Unmarshal(rec.Body.Bytes(), &link); err != nil {
+		t.Fatal(err)
+	}
+	if link.Hits != 3 || link.URL != "https://go.dev/doc" {
+		t.Fatalf("stats = %+v, want 3 hits", link)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	s := NewServer(NewMemoryStore(), "http://sho.rt")
+	tests := []struct {
+		method, target, body string
+		want                 int
+	}{
+		{http.MethodPost, "/shorten", `not json`, http.StatusBadRequest},
+		{http.MethodPost, "/shorten", `{"url":"ftp://example.com"}`, http.StatusBadRequest},
+		{http.MethodPost, "/shorten", `{"url":"/relative"}`, http.StatusBadRequest},
+		{http.MethodPost, "/shorten", `{"url":"` + strings.Repeat("a", 1<<17) + `"}`, http.StatusBadRequest},
+		{http.MethodGet, "/nope", "", http.StatusNotFound},
+		{http.MethodGet, "/stats/nope", "", http.StatusNotFound},
+		{http.MethodGet, "/shorten", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rec := do(t, s, tt.method, tt.target, tt.body); rec.Code != tt.want {
+			t.Errorf("%s %s: %d, want %d", tt.method, tt.target, rec.Code, tt.want)
+		}
+	}
+}
+
+// brokenHits is a store whose hit counter always fails.
+type brokenHits struct{ *MemoryStore }
+
+func (brokenHits) Hit(string) error { return errors.New("disk full") }
+
+func TestHitErrorIsLogged(t *testing.T) {
+	var logs bytes.Buffer
+	old := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(old)
+
+	s := NewServer(brokenHits{NewMemoryStore()}, "http://sho.rt")
+	code := shorten(t, s, "https://go.dev")
+	rec := do(t, s, http.MethodGet, "/"+code, "")
+	if rec.Code != http.StatusFound {
+		t.Fatalf("GET /%s: %d, want the redirect despite the failed hit", code, rec.Code)
+	}
+	if !strings.Contains(logs.String(), "disk full") || !strings.Contains(logs.String(), "code="+code) {
+		t.Fatalf("log = %q, want the hit error", logs.String())
+	}
+}
+
+func TestFileStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.json")
+	fs, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := shorten(t, NewServer(fs, "http://sho.rt"), "https://go.dev")
+	fs.Hit(code)
+
+	fs, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, err := fs.Get(code)
+	if err != nil || link.Hits != 1 {
+		t.Fatalf("reopened store: %+v, %v", link, err)
+	}
+	if id, _ := fs.NextID(); Encode(id) == code {
+		t.Fatal("reopened store reuses IDs")
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, n := range []uint64{0, 1, 61, 62, 1 << 40, 1<<64 - 1} {
+		got, err := Decode(Encode(n))
+		if err != nil || got != n {
+			t.Errorf("Decode(Encode(%d)) = %d, %v", n, got, err)
+		}
+	}
+	if _, err := Decode("not-base62!"); err == nil {
+		t.Error("Decode of an invalid code succeeded")
+	}
+}
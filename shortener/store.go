@@ -0,0 +1,150 @@
+package shortener
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned for unknown codes.
+var ErrNotFound = errors.New("shortener: code not found")
+
+// Link is a shortened URL.
+type Link struct {
+	Code    string    `json:"code"`
+	URL     string    `json:"url"`
+	Hits    int       `json:"hits"`
+	Created time.Time `json:"created"`
+}
+
+// Store persists links. Implementations must be safe for concurrent use.
+type Store interface {
+	// NextID returns a fresh, never before returned number.
+	NextID() (uint64, error)
+	Put(l Link) error
+	Get(code string) (Link, error)
+	// Hit increments the hit counter of code.
+	Hit(code string) error
+}
+
+// MemoryStore keeps links in a map.
+type MemoryStore struct {
+	mu    sync.Mutex
+	links map[string]Link
+	next  uint64
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{links: make(map[string]Link)}
+}
+
+func (m *MemoryStore) NextID() (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.next++
+	return m.next, nil
+}
+
+func (m *MemoryStore) Put(l Link) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.links[l.Code] = l
+	return nil
+}
+
+func (m *MemoryStore) Get(code string) (Link, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.links[code]
+	if !ok {
+		return Link{}, ErrNotFound
+	}
+	return l, nil
+}
+
+func (m *MemoryStore) Hit(code string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.links[code]
+	if !ok {
+		return ErrNotFound
+	}
+	l.Hits++
+	m.links[code] = l
+	return nil
+}
+
+// FileStore is a MemoryStore that rewrites a JSON file after every change.
+type FileStore struct {
+	path string
+	mem  *MemoryStore
+	mu   sync.Mutex // serialises writes to the file
+}
+
+type fileData struct {
+	Next  uint64          `json:"next"`
+	Links map[string]Link `json:"links"`
+}
+
+// OpenFileStore loads path if it exists, or starts empty otherwise.
+func OpenFileStore(path string) (*FileStore, error) {
+	fs := &FileStore{path: path, mem: NewMemoryStore()}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var fd fileData
+	if err := json.Unmarshal(data, &fd); err != nil {
+		return nil, err
+	}
+	fs.mem.next = fd.Next
+	if fd.Links != nil {
+		fs.mem.links = fd.Links
+	}
+	return fs, nil
+}
+
+func (f *FileStore) NextID() (uint64, error) {
+	id, _ := f.mem.NextID()
+	return id, f.flush()
+}
+
+func (f *FileStore) Put(l Link) error {
+	f.mem.Put(l)
+	return f.flush()
+}
+
+func (f *FileStore) Get(code string) (Link, error) {
+	return f.mem.Get(code)
+}
+
+func (f *FileStore) Hit(code string) error {
+	if err := f.mem.Hit(code); err != nil {
+		return err
+	}
+	return f.flush()
+}
+
+func (f *FileStore) flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.mem.mu.Lock()
+	data, err := json.MarshalIndent(fileData{Next: f.mem.next, Links: f.mem.links}, "", "  ")
+	f.mem.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
@@ -0,0 +1,95 @@
+// Package shutdown coordinates a graceful stop of long running programs:
+// it turns SIGINT/SIGTERM into context cancellation and drains HTTP
+// servers before they exit.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// OnSignal returns a context that is cancelled on SIGINT or SIGTERM. Call
+// stop to release the signal handler; a second signal then kills the
+// process the usual way.
+func OnSignal(parent context.Context) (ctx context.Context, stop context.CancelFunc) {
+	return signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+}
+
+// Tracker counts the requests currently being served.
+type Tracker struct {
+	n atomic.Int64
+}
+
+// Middleware counts every request passing through it.
+func (t *Tracker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.n.Add(1)
+		defer t.n.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// InFlight returns the number of requests being served right now.
+func (t *Tracker) InFlight() int64 {
+	return t.n.Load()
+}
+
+// Serve runs srv on ln until ctx is done, then stops accepting new
+// connections and waits up to drain for in-flight requests to finish.
+// Connections still busy after drain are closed forcibly. tracker may be
+// nil; if set, its count is logged when the drain starts and ends.
+func Serve(ctx context.Context, srv *http.Server, ln net.Listener, drain time.Duration, tracker *Tracker) error {
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err // failed before any shutdown was requested
+	case <-ctx.Done():
+	}
+
+	log := slog.With("addr", ln.Addr().String())
+	if tracker != nil {
+		log.Info("shutting down, draining requests", "in_flight", tracker.InFlight(), "timeout", drain)
+	} else {
+		log.Info("shutting down, draining requests", "timeout", drain)
+	}
+
+	dctx, cancel := context.WithTimeout(context.Background(), drain)
+	defer cancel()
+	err := srv.Shutdown(dctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		if tracker != nil {
+			log.Warn("drain timed out, closing connections", "in_flight", tracker.InFlight())
+		}
+		srv.Close()
+	}
+	if serr := <-errc; !errors.Is(serr, http.ErrServerClosed) && err == nil {
+		err = serr
+	}
+	if err == nil {
+		log.Info("shutdown complete")
+	}
+	return err
+}
+
+// ListenAndServe listens on srv.Addr and calls Serve.
+func ListenAndServe(ctx context.Context, srv *http.Server, drain time.Duration, tracker *Tracker) error {
+	addr := srv.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return Serve(ctx, srv, ln, drain, tracker)
+}
@@ -0,0 +1,146 @@
+package shutdown_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/shutdown"
+)
+
+// slowServer serves a handler that blocks until release is closed. It
+// returns the address, Serve's result and a channel that receives once a
+// request has started.
+func slowServer(t *testing.T, ctx context.Context, drain time.Duration, release <-chan struct{}) (string, <-chan error, <-chan struct{}, *shutdown.Tracker) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{}, 1)
+	tracker := &shutdown.Tracker{}
+	srv := &http.Server{Handler: tracker.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		io.WriteString(w, "done")
+	}))}
+	errc := make(chan error, 1)
+	go func() { errc <- shutdown.Serve(ctx, srv, ln, drain, tracker) }()
+	return ln.Addr().String(), errc, started, tracker
+}
+
+type result struct {
+	status int
+	body   string
+	err    error
+}
+
+func get(client *http.Client, url string) <-chan result {
+	c := make(chan result, 1)
+	go func() {
+		res, err := client.Get(url)
+		if err != nil {
+			c <- result{err: err}
+			return
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		c <- result{res.StatusCode, string(b), err}
+	}()
+	return c
+}
+
+func TestServeDrains(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	release := make(chan struct{})
+	addr, errc, started, tracker := slowServer(t, ctx, 5*time.Second, release)
+	client := &http.Client{Transport: &http.Transport{}}
+	defer client.CloseIdleConnections()
+
+	inFlight := get(client, "http://"+addr)
+	<-started
+	if n := tracker.InFlight(); n != 1 {
+		t.Fatalf("InFlight = %d, want 1", n)
+	}
+	cancel()
+
+	// the listener closes at once; new connections are refused
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("new connections still accepted after shutdown began")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case err := <-errc:
+		t.Fatalf("Serve returned %v with a request in flight", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	res := <-inFlight
+	if res.err != nil || res.status != http.StatusOK || res.body != "done" {
+		t.Fatalf("in-flight request = %d %q, %v", res.status, res.body, res.err)
+	}
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("Serve = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after the drain")
+	}
+	if n := tracker.InFlight(); n != 0 {
+		t.Errorf("InFlight = %d after the drain", n)
+	}
+}
+
+func TestServeDrainTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+	addr, errc, started, _ := slowServer(t, ctx, 50*time.Millisecond, release)
+	client := &http.Client{Transport: &http.Transport{}}
+	defer client.CloseIdleConnections()
+
+	inFlight := get(client, "http://"+addr)
+	<-started
+	cancel()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Serve = %v, want DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not give up after the drain timeout")
+	}
+	// the stuck connection was closed under the request
+	if res := <-inFlight; res.err == nil {
+		t.Fatalf("request survived a forced close: %d %q", res.status, res.body)
+	}
+}
+
+func TestServeListenError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.Close()
+	err = shutdown.Serve(context.Background(), &http.Server{}, ln, time.Second, nil)
+	if err == nil || errors.Is(err, http.ErrServerClosed) {
+		t.Fatalf("Serve on a closed listener = %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package main
+
+// Exercise: implement Reverse, then check it with
+//
+//	go run ./cmd/learn check slice
+
+// Reverse returns a new slice with the elements of s in reverse order,
+// leaving s unchanged.
+func Reverse(s []int) []int {
+	// TODO: your code here
+	return s
+}
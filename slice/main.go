@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/armaanepiic/Golang/internal/slicesx"
+)
 
 func changeSlice(p []int) []int {
 	p[0] = 10
@@ -37,6 +41,13 @@ func main() {
 	fmt.Println(y)
 	fmt.Println(x[0:8])
 
+	// growth rule in numbers: how cap changes when append runs out of room
+	for _, c := range []int{1, 4, 128, 256, 512, 1024, 2048} {
+		fmt.Println("cap", c, "->", slicesx.Cap(c, c+1))
+	}
+	squares := slicesx.Map(x, func(n int) int { return n * n })
+	fmt.Println(squares, slicesx.Filter(squares, func(n int) bool { return n%2 == 0 }))
+
 
 
 
@@ -93,6 +104,7 @@ func main() {
 	
 	rule of expanding space: slice underlying array rule => till 1024 (100% increase)
 	after 1024 it will increase by 25%
+	(Go 1.18+: doubles till 256, then grows ~25% + 192 => see slicesx.Cap)
 */
 
 
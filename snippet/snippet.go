@@ -0,0 +1,258 @@
+// Package snippet compiles and runs small pieces of Go code: a whole
+// program, or just the statements of a main function. Each run gets a
+// fresh temporary module, is built with go build and then executed
+// directly, so a timeout stops the program itself and build errors are
+// told apart from a program that fails.
+//
+//	r, err := snippet.Run(ctx, `fmt.Println(len("héllo"))`)
+//	// r.Stdout == "6\n"
+package snippet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults for Run.
+const (
+	DefaultTimeout   = 10 * time.Second
+	DefaultMaxOutput = 1 << 20 // bytes kept of each of stdout and stderr
+)
+
+// Errors reported by Result.Err.
+var (
+	ErrBuild   = errors.New("snippet: does not build")
+	ErrTimeout = errors.New("snippet: timed out")
+	ErrExit    = errors.New("snippet: exited with an error")
+)
+
+// Result is what a run produced.
+type Result struct {
+	Stdout      string
+	Stderr      string // the compiler's errors if the build failed
+	ExitCode    int    // -1 if it did not build or was killed
+	BuildFailed bool
+	TimedOut    bool
+	Truncated   bool          // output went over the limit and was cut
+	Elapsed     time.Duration // running time, not counting the build
+}
+
+// Err summarises r as an error: nil if the program built and exited 0,
+// otherwise one wrapping ErrBuild, ErrTimeout or ErrExit with the
+// program's stderr.
+func (r *Result) Err() error {
+	var err error
+	switch {
+	case r.BuildFailed:
+		err = ErrBuild
+	case r.TimedOut:
+		err = fmt.Errorf("%w after %v", ErrTimeout, r.Elapsed.Round(time.Millisecond))
+	case r.ExitCode != 0:
+		err = fmt.Errorf("%w: status %d", ErrExit, r.ExitCode)
+	default:
+		return nil
+	}
+	if s := strings.TrimSpace(r.Stderr); s != "" {
+		err = fmt.Errorf("%w\n%s", err, s)
+	}
+	return err
+}
+
+type config struct {
+	timeout   time.Duration
+	stdin     string
+	maxOutput int
+}
+
+// Option configures Run.
+type Option func(*config)
+
+// WithTimeout limits how long the program may run; the build is bounded
+// only by the context. The default is DefaultTimeout.
+func WithTimeout(d time.Duration) Option { return func(c *config) { c.timeout = d } }
+
+// WithStdin gives the program input.
+func WithStdin(s string) Option { return func(c *config) { c.stdin = s } }
+
+// WithMaxOutput sets how many bytes of stdout and of stderr are kept.
+func WithMaxOutput(n int) Option { return func(c *config) { c.maxOutput = n } }
+
+// Program turns code into a complete program. Code that starts with a
+// package clause is used as is. Anything else becomes the body of main,
+// with fmt imported; leading import lines are moved above main.
+//
+//	import "strings"
+//	fmt.Println(strings.ToUpper("go"))
+func Program(code string) string {
+	src, _ := program(code)
+	return src
+}
+
+// program returns Program(code) and how many lines the body of main was
+// moved down by, to map compiler errors back to lines of code.
+func program(code string) (src string, shift int) {
+	if strings.HasPrefix(strings.TrimSpace(code), "package ") {
+		return code, 0
+	}
+	lines := strings.Split(code, "\n")
+	var imports []string
+	first := len(lines) // first line of the body
+	for i, line := range lines {
+		t := strings.TrimSpace(line)
+		if t != "" && !strings.HasPrefix(t, "import ") {
+			first = i
+			break
+		}
+		if t != "" {
+			imports = append(imports, t)
+		}
+	}
+	if !slices.ContainsFunc(imports, func(s string) bool { return strings.Contains(s, `"fmt"`) }) {
+		imports = append([]string{`import "fmt"`}, imports...)
+		imports = append(imports, "", "var _ = fmt.Println")
+	}
+	header := "package main\n\n" + strings.Join(imports, "\n") + "\n\nfunc main() {\n"
+	shift = strings.Count(header, "\n") - first
+	return header + strings.TrimRight(strings.Join(lines[first:], "\n"), "\n") + "\n}\n", shift
+}
+
+// Run builds and runs Program(code) in a temporary module. The error is
+// for problems running the go tool and for ctx ending; a snippet that
+// does not build, fails or times out is described by the Result.
+func Run(ctx context.Context, code string, opts ...Option) (*Result, error) {
+	cfg := config{timeout: DefaultTimeout, maxOutput: DefaultMaxOutput}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	dir, err := os.MkdirTemp("", "snippet-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	src, shift := program(code)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		return nil, err
+	}
+	// go mod init records the go tool's own version, so the snippet gets
+	// the current language (loop variables, range over int ...)
+	if out, err := goCmd(ctx, dir, "mod", "init", "snippet").CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("snippet: go mod init: %w\n%s", contextErr(ctx, err), out)
+	}
+
+	r := &Result{ExitCode: -1}
+	bin := filepath.Join(dir, "snippet")
+	var buildOut bytes.Buffer
+	build := goCmd(ctx, dir, "build", "-o", bin, ".")
+	build.Stdout, build.Stderr = &buildOut, &buildOut
+	if err := build.Run(); err != nil {
+		var exit *exec.ExitError
+		if ctx.Err() != nil || !errors.As(err, &exit) {
+			return nil, fmt.Errorf("snippet: go build: %w", contextErr(ctx, err))
+		}
+		r.BuildFailed = true
+		r.Stderr = buildErrors(buildOut.String(), shift)
+		return r, nil
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, bin)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(cfg.stdin)
+	stdout := &limitedBuffer{max: cfg.maxOutput}
+	stderr := &limitedBuffer{max: cfg.maxOutput}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	cmd.WaitDelay = time.Second
+	start := time.Now()
+	err = cmd.Run()
+	r.Elapsed = time.Since(start)
+	r.Stdout, r.Stderr = stdout.String(), stderr.String()
+	r.Truncated = stdout.truncated || stderr.truncated
+
+	var exit *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case runCtx.Err() != nil:
+		r.TimedOut = true
+	case err == nil:
+		r.ExitCode = 0
+	case errors.As(err, &exit):
+		r.ExitCode = exit.ExitCode()
+	default:
+		return nil, fmt.Errorf("snippet: %w", err)
+	}
+	return r, nil
+}
+
+// goCmd runs the go tool outside any workspace, so the temporary module
+// stands on its own wherever the caller is.
+func goCmd(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	return cmd
+}
+
+var position = regexp.MustCompile(`^(?:\./)?main\.go:(\d+)(:\d+)?: `)
+
+// buildErrors drops the "# snippet" header go build prints and, for a
+// fragment, turns "main.go:9:3:" into "line 2:3:", counted in the code
+// that was given.
+func buildErrors(out string, shift int) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if m := position.FindStringSubmatchIndex(line); m != nil && shift > 0 {
+			n, _ := strconv.Atoi(line[m[2]:m[3]])
+			col := ""
+			if m[4] >= 0 {
+				col = line[m[4]:m[5]]
+			}
+			line = fmt.Sprintf("line %d%s: %s", n-shift, col, line[m[1]:])
+		}
+		lines = append(lines, strings.TrimPrefix(line, "./"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// contextErr prefers the context's error over the "signal: killed" it
+// causes.
+func contextErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// limitedBuffer keeps the first max bytes written to it and discards the
+// rest, so a runaway loop can't fill memory.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); len(p) > room {
+		b.buf.Write(p[:max(room, 0)])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string { return b.buf.String() }
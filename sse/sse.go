@@ -0,0 +1,144 @@
+// Package sse reads and writes the text/event-stream format used by
+// server-sent events (https://html.spec.whatwg.org/multipage/server-sent-events.html).
+package sse
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of an event stream.
+const ContentType = "text/event-stream"
+
+// Event is one server-sent event. Only Data is required.
+type Event struct {
+	ID    string
+	Event string        // event type; empty means "message"
+	Data  string        // may contain newlines
+	Retry time.Duration // reconnection delay hint, 0 = not sent
+}
+
+// Write writes e to w in event-stream format.
+func Write(w io.Writer, e Event) error {
+	var b strings.Builder
+	if e.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", e.ID)
+	}
+	if e.Event != "" {
+		fmt.Fprintf(&b, "event: %s\n", e.Event)
+	}
+	if e.Retry > 0 {
+		fmt.Fprintf(&b, "retry: %d\n", e.Retry.Milliseconds())
+	}
+	for line := range strings.SplitSeq(e.Data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteComment writes a comment line. Clients ignore it, which makes it a
+// cheap heartbeat that keeps proxies from closing an idle stream.
+func WriteComment(w io.Writer, text string) error {
+	_, err := fmt.Fprintf(w, ": %s\n\n", text)
+	return err
+}
+
+// Reader parses events from an event stream.
+type Reader struct {
+	s      *bufio.Scanner
+	lastID string
+}
+
+// NewReader returns a Reader reading from r.
+func NewReader(r io.Reader) *Reader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 4096), 1<<20)
+	return &Reader{s: s}
+}
+
+// Next returns the next event, skipping comments. It returns io.EOF when
+// the stream ends.
+func (r *Reader) Next() (Event, error) {
+	var e Event
+	var data []string
+	seen := false
+	for r.s.Scan() {
+		line := r.s.Text()
+		if line == "" {
+			if !seen {
+				continue // comment-only block
+			}
+			e.Data = strings.Join(data, "\n")
+			if e.ID == "" {
+				e.ID = r.lastID // the last ID carries over, as in browsers
+			}
+			r.lastID = e.ID
+			return e, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			e.ID = value
+		case "event":
+			e.Event = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				e.Retry = time.Duration(ms) * time.Millisecond
+			}
+		default:
+			continue // unknown fields are ignored
+		}
+		seen = true
+	}
+	if err := r.s.Err(); err != nil {
+		return Event{}, err
+	}
+	return Event{}, io.EOF
+}
+
+// LastID returns the ID of the last event returned by Next.
+func (r *Reader) LastID() string {
+	return r.lastID
+}
+
+// Connect opens an event stream at url, resuming after lastID if it is
+// not empty. The caller reads events from the returned Reader and closes
+// the body when done. A nil client uses http.DefaultClient.
+func Connect(ctx context.Context, client *http.Client, url, lastID string) (*Reader, io.Closer, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", ContentType)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("sse: %s: %s", url, resp.Status)
+	}
+	r := NewReader(resp.Body)
+	r.lastID = lastID
+	return r, resp.Body, nil
+}
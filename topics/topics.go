@@ -0,0 +1,169 @@
+// Package topics finds the runnable example programs in this repository
+// (every directory holding a main package outside cmd/) and runs them
+// with go run, so tools like cmd/learn don't need to cd anywhere.
+package topics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/doc"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ModulePath identifies the repository's root go.mod.
+const ModulePath = "github.com/armaanepiic/Golang"
+
+// Topic is one example program.
+type Topic struct {
+	Name     string // slash-separated path from the root, e.g. "net/tcp-echo"
+	Dir      string // absolute directory
+	Synopsis string // first sentence of the package doc, if any
+	Module   bool   // the directory has its own go.mod
+}
+
+// FindRoot returns the repository root: the nearest directory at or above
+// start whose go.mod declares ModulePath.
+func FindRoot(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if modulePath(filepath.Join(dir, "go.mod")) == ModulePath {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("topics: no go.mod for %s at or above %s", ModulePath, start)
+		}
+		dir = parent
+	}
+}
+
+func modulePath(gomod string) string {
+	f, err := os.Open(gomod)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(sc.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// Discover lists the topics under root, sorted by name. Hidden
+// directories, testdata and the top-level cmd/ (tools, not lessons) are
+// skipped.
+func Discover(root string) ([]Topic, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	var topics []Topic
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata") {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(root, path)
+		if rel == "cmd" {
+			return filepath.SkipDir
+		}
+
+		pkg, err := build.ImportDir(path, build.ImportComment)
+		if err != nil || pkg.Name != "main" || path == root {
+			return nil // no Go files, not a main package, or doesn't parse
+		}
+		_, statErr := os.Stat(filepath.Join(path, "go.mod"))
+		topics = append(topics, Topic{
+			Name:     filepath.ToSlash(rel),
+			Dir:      path,
+			Synopsis: doc.Synopsis(pkg.Doc),
+			Module:   statErr == nil,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(topics, func(a, b Topic) int { return strings.Compare(a.Name, b.Name) })
+	return topics, nil
+}
+
+// ErrNotFound and ErrAmbiguous are returned by Lookup.
+var (
+	ErrNotFound  = errors.New("topics: no such topic")
+	ErrAmbiguous = errors.New("topics: ambiguous topic")
+)
+
+// Lookup finds a topic by exact name, or by a prefix or final path element
+// that matches exactly one topic ("tcp" finds "net/tcp-echo").
+func Lookup(topics []Topic, name string) (Topic, error) {
+	name = strings.Trim(filepath.ToSlash(name), "/")
+	var matches []Topic
+	for _, t := range topics {
+		if t.Name == name {
+			return t, nil
+		}
+		if strings.HasPrefix(t.Name, name) || strings.HasPrefix(t.Name[strings.LastIndex(t.Name, "/")+1:], name) {
+			matches = append(matches, t)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Topic{}, fmt.Errorf("%w %q", ErrNotFound, name)
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, t := range matches {
+		names[i] = t.Name
+	}
+	return Topic{}, fmt.Errorf("%w %q: could be %s", ErrAmbiguous, name, strings.Join(names, ", "))
+}
+
+// Command returns an exec.Cmd that builds and runs t with args. It runs
+// go run . inside t.Dir, which works both for packages of the root module
+// and for topics that are modules of their own.
+func (t Topic) Command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "go", append([]string{"run", "."}, args...)...)
+	cmd.Dir = t.Dir
+	return cmd
+}
+
+// BuildCommand returns an exec.Cmd that compiles t into the executable
+// out. Running the result directly, rather than through go run, means
+// cancelling it stops the program itself and not just the go tool.
+func (t Topic) BuildCommand(ctx context.Context, out string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", out, ".")
+	cmd.Dir = t.Dir
+	return cmd
+}
+
+// Run runs t with stdin, stdout and stderr connected, streaming its
+// output as it is produced. A non-zero exit is returned as an
+// *exec.ExitError.
+func (t Topic) Run(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, args ...string) error {
+	cmd := t.Command(ctx, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+	return cmd.Run()
+}
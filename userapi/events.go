@@ -0,0 +1,126 @@
+package userapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/armaanepiic/Golang/sse"
+)
+
+// ring holds the last n events in order.
+type ring struct {
+	buf   []Event
+	start int // index of the oldest event
+	n     int
+}
+
+func newRing(size int) *ring {
+	if size <= 0 {
+		size = 256
+	}
+	return &ring{buf: make([]Event, size)}
+}
+
+func (r *ring) add(e Event) {
+	if r.n < len(r.buf) {
+		r.buf[(r.start+r.n)%len(r.buf)] = e
+		r.n++
+		return
+	}
+	r.buf[r.start] = e
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+// since returns the events after seq. complete is false when some of
+// them have already been overwritten.
+func (r *ring) since(seq int64) (events []Event, complete bool) {
+	complete = r.n == 0 || r.buf[r.start].Seq <= seq+1
+	for i := range r.n {
+		if e := r.buf[(r.start+i)%len(r.buf)]; e.Seq > seq {
+			events = append(events, e)
+		}
+	}
+	return events, complete
+}
+
+// events streams changes as server-sent events. A client that reconnects
+// with Last-Event-ID first gets the changes it missed; if they are no
+// longer in the history, or the ID is not one this server has handed
+// out, it gets a "reset" event and should reload the list. A client too slow to keep up is disconnected so that it
+// reconnects and catches up from the history.
+func (a *API) events(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+
+	a.mu.Lock()
+	sub, err := a.broker.Subscribe(topic, 64)
+	if err != nil {
+		a.mu.Unlock()
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	var backlog []Event
+	complete := true
+	if last := r.Header.Get("Last-Event-ID"); last != "" {
+		switch seq, err := strconv.ParseInt(last, 10, 64); {
+		case err != nil || seq > a.seq:
+			complete = false // not an ID this server handed out, e.g. before a restart
+		case seq < a.seq:
+			backlog, complete = a.recent.since(seq)
+		}
+	}
+	a.mu.Unlock()
+	defer sub.Unsubscribe()
+
+	w.Header().Set("Content-Type", sse.ContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	if !complete {
+		sse.Write(w, sse.Event{Event: "reset", Data: "history lost, reload /users"})
+	}
+	for _, e := range backlog {
+		if writeEvent(w, e) != nil {
+			return
+		}
+	}
+	if rc.Flush() != nil {
+		return // streaming not supported by this writer
+	}
+
+	heartbeat := time.NewTicker(a.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.C():
+			if !ok || sub.Dropped() > 0 {
+				return
+			}
+			if writeEvent(w, e) != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if sse.WriteComment(w, "heartbeat") != nil {
+				return
+			}
+		}
+		if rc.Flush() != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return sse.Write(w, sse.Event{
+		ID:    strconv.FormatInt(e.Seq, 10),
+		Event: e.Type,
+		Data:  string(data),
+	})
+}
@@ -0,0 +1,172 @@
+package userapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/leaktest"
+	"github.com/armaanepiic/Golang/pubsub"
+	"github.com/armaanepiic/Golang/sse"
+)
+
+// newServer serves a fresh API keeping history events for replay.
+func newServer(t *testing.T, history int) *httptest.Server {
+	t.Helper()
+	broker := pubsub.New[Event](pubsub.Drop)
+	mux := http.NewServeMux()
+	New(userstore.New(), broker, history).Register(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		broker.Close() // ends the open event streams
+		srv.Close()
+	})
+	return srv
+}
+
+// createUsers posts n users named u1, u2, ...
+func createUsers(t *testing.T, srv *httptest.Server, from, to int) {
+	t.Helper()
+	for i := from; i <= to; i++ {
+		body := `{"name":"u` + strconv.Itoa(i) + `","age":30}`
+		res, err := http.Post(srv.URL+"/users", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusCreated {
+			t.Fatalf("POST /users: %s", res.Status)
+		}
+	}
+}
+
+// stream opens the event stream resuming after lastID.
+func stream(t *testing.T, srv *httptest.Server, lastID string) *sse.Reader {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	r, body, err := sse.Connect(ctx, nil, srv.URL+"/users/events", lastID)
+	if err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		body.Close()
+	})
+	return r
+}
+
+// expect reads events from r and compares them with want, written as
+// "type:name" for user events and "reset" for a reset.
+func expect(t *testing.T, r *sse.Reader, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		e, err := r.Next()
+		if err != nil {
+			t.Fatalf("reading %s: %v", w, err)
+		}
+		got := e.Event
+		if e.Event != "reset" {
+			var ev Event
+			if err := json.Unmarshal([]byte(e.Data), &ev); err != nil {
+				t.Fatal(err)
+			}
+			if e.ID != strconv.FormatInt(ev.Seq, 10) {
+				t.Errorf("event ID %q for seq %d", e.ID, ev.Seq)
+			}
+			got += ":" + ev.User.Name
+		}
+		if got != w {
+			t.Fatalf("got event %s, want %s", got, w)
+		}
+	}
+}
+
+func TestEventsLive(t *testing.T) {
+	srv := newServer(t, 8)
+	r := stream(t, srv, "")
+	createUsers(t, srv, 1, 2)
+	expect(t, r, "created:u1", "created:u2")
+}
+
+func TestEventsReplay(t *testing.T) {
+	srv := newServer(t, 8)
+	createUsers(t, srv, 1, 3)
+	r := stream(t, srv, "1")
+	createUsers(t, srv, 4, 4)
+	expect(t, r, "created:u2", "created:u3", "created:u4")
+}
+
+func TestEventsUpToDate(t *testing.T) {
+	srv := newServer(t, 8)
+	createUsers(t, srv, 1, 3)
+	r := stream(t, srv, "3")
+	createUsers(t, srv, 4, 4)
+	expect(t, r, "created:u4")
+}
+
+func TestEventsHistoryLost(t *testing.T) {
+	srv := newServer(t, 2)
+	createUsers(t, srv, 1, 5)
+	r := stream(t, srv, "1")
+	expect(t, r, "reset", "created:u4", "created:u5")
+}
+
+func TestEventsUnknownID(t *testing.T) {
+	for _, id := range []string{"99", "garbage"} {
+		t.Run(id, func(t *testing.T) {
+			srv := newServer(t, 8)
+			createUsers(t, srv, 1, 2)
+			// e.g. an ID from before a server restart
+			r := stream(t, srv, id)
+			createUsers(t, srv, 3, 3)
+			expect(t, r, "reset", "created:u3")
+		})
+	}
+}
+
+// TestEventStreamEnds checks that a client leaving ends the handler and
+// its subscription. The cleanup is spelled out with defers instead of the
+// helpers above, so it runs before the leak check.
+func TestEventStreamEnds(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	broker := pubsub.New[Event](pubsub.Drop)
+	defer broker.Close()
+	mux := http.NewServeMux()
+	New(userstore.New(), broker, 16).Register(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := &http.Client{Transport: &http.Transport{}}
+	defer client.CloseIdleConnections()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r, body, err := sse.Connect(ctx, client, srv.URL+"/users/events", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Post(srv.URL+"/users", "application/json", strings.NewReader(`{"name":"Arman","age":30}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if e, err := r.Next(); err != nil || !strings.Contains(e.Data, "Arman") {
+		t.Fatalf("Next = %+v, %v", e, err)
+	}
+
+	cancel()
+	body.Close()
+	// the handler notices the disconnect and unsubscribes
+	for i := 0; broker.Subscribers(topic) > 0; i++ {
+		if i == 100 {
+			t.Fatal("the event stream is still subscribed after the client left")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,60 @@
+package userapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/armaanepiic/Golang/csvx"
+	"github.com/armaanepiic/Golang/internal/userstore"
+)
+
+// maxImportErrors stops an import that is clearly the wrong file.
+const maxImportErrors = 100
+
+type importRow struct {
+	Name string `csv:"name,required"`
+	Age  int    `csv:"age"`
+}
+
+type importError struct {
+	Line   int    `json:"line"`
+	Column string `json:"column,omitempty"`
+	Error  string `json:"error"`
+}
+
+type importResult struct {
+	Rows     int           `json:"rows"`
+	Imported int           `json:"imported"`
+	Errors   []importError `json:"errors"`
+}
+
+// importCSV creates one user per CSV row. Bad rows are skipped and
+// reported with their line numbers; the good ones are kept.
+func (a *API) importCSV(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, 64<<20)
+	res, err := csvx.Decode(body, csvx.Options{MaxErrors: maxImportErrors}, func(row importRow) error {
+		if row.Name == "" {
+			return errors.New("name is empty")
+		}
+		if row.Age < 0 {
+			return errors.New("age is negative")
+		}
+		u := a.repo.Create(userstore.User{Name: row.Name, Age: row.Age})
+		a.emit(Created, u)
+		return nil
+	})
+	if err != nil && !errors.Is(err, csvx.ErrTooManyErrors) {
+		http.Error(w, "import failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	out := importResult{Rows: res.Rows, Imported: res.OK, Errors: []importError{}}
+	for _, e := range res.Errors {
+		out.Errors = append(out.Errors, importError{Line: e.Line, Column: e.Column, Error: e.Err.Error()})
+	}
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusUnprocessableEntity // gave up part way
+	}
+	writeJSON(w, status, out)
+}
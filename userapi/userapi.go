@@ -0,0 +1,180 @@
+// Package userapi serves the user repository over HTTP as JSON, and
+// streams every change to it as server-sent events.
+//
+//	GET    /users
+//	POST   /users
+//	GET    /users/{id}
+//	PUT    /users/{id}
+//	DELETE /users/{id}
+//	POST   /users/import   (CSV with name and age columns)
+//	GET    /users/events
+package userapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/pubsub"
+)
+
+// Event types.
+const (
+	Created = "created"
+	Updated = "updated"
+	Deleted = "deleted"
+)
+
+// Event describes one change to the repository.
+type Event struct {
+	Seq  int64          `json:"seq"`
+	Type string         `json:"type"`
+	User userstore.User `json:"user"`
+}
+
+const topic = "users"
+
+// API is the HTTP handler set. Create it with New.
+type API struct {
+	repo   userstore.Repository
+	broker *pubsub.Broker[Event]
+
+	// Heartbeat is how often an idle event stream gets a comment line.
+	Heartbeat time.Duration
+
+	mu     sync.Mutex // orders seq, history and publishing
+	seq    int64
+	recent *ring
+}
+
+// New returns an API over repo that publishes changes on broker and keeps
+// the last history events for Last-Event-ID replay. The broker should use
+// the pubsub.Drop policy so a stalled client cannot hold up writes.
+func New(repo userstore.Repository, broker *pubsub.Broker[Event], history int) *API {
+	return &API{
+		repo:      repo,
+		broker:    broker,
+		Heartbeat: 15 * time.Second,
+		recent:    newRing(history),
+	}
+}
+
+// Register adds the routes to mux.
+func (a *API) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /users", a.list)
+	mux.HandleFunc("POST /users", a.create)
+	mux.HandleFunc("GET /users/{id}", a.get)
+	mux.HandleFunc("PUT /users/{id}", a.update)
+	mux.HandleFunc("DELETE /users/{id}", a.delete)
+	mux.HandleFunc("GET /users/events", a.events)
+	mux.HandleFunc("POST /users/import", a.importCSV)
+}
+
+// emit numbers the change, remembers it and publishes it.
+func (a *API) emit(typ string, u userstore.User) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.seq++
+	e := Event{Seq: a.seq, Type: typ, User: u}
+	a.recent.add(e)
+	a.broker.Publish(topic, e)
+}
+
+func (a *API) list(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.repo.List())
+}
+
+func (a *API) get(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	u, err := a.repo.Get(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (a *API) create(w http.ResponseWriter, r *http.Request) {
+	var u userstore.User
+	if !readJSON(w, r, &u) {
+		return
+	}
+	u = a.repo.Create(u)
+	a.emit(Created, u)
+	writeJSON(w, http.StatusCreated, u)
+}
+
+func (a *API) update(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var u userstore.User
+	if !readJSON(w, r, &u) {
+		return
+	}
+	u.ID = id
+	if err := a.repo.Update(u); err != nil {
+		writeError(w, err)
+		return
+	}
+	a.emit(Updated, u)
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (a *API) delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	u, err := a.repo.Get(id)
+	if err == nil {
+		err = a.repo.Delete(id)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	a.emit(Deleted, u)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid user id", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		http.Error(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, userstore.ErrNotFound) {
+		http.Error(w, "user not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,94 @@
+// Package usergrpc serves a userstore.Repository over gRPC using the
+// UserService defined in userpb.
+package usergrpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/userpb"
+)
+
+// Server implements userpb.UserServiceServer.
+type Server struct {
+	userpb.UnimplementedUserServiceServer
+	repo userstore.Repository
+}
+
+// NewServer returns a Server backed by repo.
+func NewServer(repo userstore.Repository) *Server {
+	return &Server{repo: repo}
+}
+
+// Register creates a grpc.Server with the logging interceptor and the user
+// service installed.
+func Register(repo userstore.Repository, logger *log.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(LoggingInterceptor(logger))}, opts...)
+	gs := grpc.NewServer(opts...)
+	userpb.RegisterUserServiceServer(gs, NewServer(repo))
+	return gs
+}
+
+func (s *Server) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.User, error) {
+	u, err := s.repo.Get(int(req.GetId()))
+	if errors.Is(err, userstore.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", req.GetId())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return ToProto(u), nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
+	users := s.repo.List()
+	resp := &userpb.ListUsersResponse{Users: make([]*userpb.User, len(users))}
+	for i, u := range users {
+		resp.Users[i] = ToProto(u)
+	}
+	return resp, nil
+}
+
+func (s *Server) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.User, error) {
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if req.GetAge() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "age must not be negative")
+	}
+	u := s.repo.Create(userstore.User{Name: name, Age: int(req.GetAge())})
+	return ToProto(u), nil
+}
+
+// ToProto converts a store user to its wire form.
+func ToProto(u userstore.User) *userpb.User {
+	return &userpb.User{Id: int64(u.ID), Name: u.Name, Age: int32(u.Age)}
+}
+
+// FromProto converts a wire user to the store form.
+func FromProto(p *userpb.User) userstore.User {
+	return userstore.User{ID: int(p.GetId()), Name: p.GetName(), Age: int(p.GetAge())}
+}
+
+// LoggingInterceptor logs the method, status code and duration of every
+// unary call. A nil logger uses log.Default().
+func LoggingInterceptor(logger *log.Logger) grpc.UnaryServerInterceptor {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logger.Printf("grpc %s %s %v", info.FullMethod, status.Code(err), time.Since(start))
+		return resp, err
+	}
+}
@@ -0,0 +1,113 @@
+package usergrpc
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/armaanepiic/Golang/internal/userstore"
+	"github.com/armaanepiic/Golang/userpb"
+)
+
+// dial serves a fresh store over an in-memory bufconn listener and
+// returns a client for it and the server's log.
+func dial(t *testing.T) (userpb.UserServiceClient, *bytes.Buffer) {
+	t.Helper()
+	var logs bytes.Buffer
+	ln := bufconn.Listen(1 << 20)
+	gs := Register(userstore.New(), log.New(&logs, "", 0))
+	go gs.Serve(ln)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return userpb.NewUserServiceClient(conn), &logs
+}
+
+func TestCreateGetList(t *testing.T) {
+	c, _ := dial(t)
+	ctx := context.Background()
+
+	ada, err := c.CreateUser(ctx, &userpb.CreateUserRequest{Name: "  Ada ", Age: 36})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ada.GetName() != "Ada" || ada.GetAge() != 36 || ada.GetId() == 0 {
+		t.Fatalf("CreateUser = %v", ada)
+	}
+	if _, err := c.CreateUser(ctx, &userpb.CreateUserRequest{Name: "Linus", Age: 54}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.GetUser(ctx, &userpb.GetUserRequest{Id: ada.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetName() != "Ada" || got.GetId() != ada.GetId() {
+		t.Fatalf("GetUser = %v, want %v", got, ada)
+	}
+
+	list, err := c.ListUsers(ctx, &userpb.ListUsersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(list.GetUsers()); n != 2 {
+		t.Fatalf("ListUsers returned %d users, want 2", n)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	c, logs := dial(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"missing user", func() error {
+			_, err := c.GetUser(ctx, &userpb.GetUserRequest{Id: 42})
+			return err
+		}, codes.NotFound},
+		{"blank name", func() error {
+			_, err := c.CreateUser(ctx, &userpb.CreateUserRequest{Name: "  "})
+			return err
+		}, codes.InvalidArgument},
+		{"negative age", func() error {
+			_, err := c.CreateUser(ctx, &userpb.CreateUserRequest{Name: "x", Age: -1})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		if got := status.Code(tt.call()); got != tt.want {
+			t.Errorf("%s: code %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !strings.Contains(logs.String(), "grpc /user.v1.UserService/GetUser NotFound") {
+		t.Errorf("interceptor log:\n%s", logs)
+	}
+}
+
+func TestProtoConversion(t *testing.T) {
+	u := userstore.User{ID: 7, Name: "Grace", Age: 85}
+	if got := FromProto(ToProto(u)); got != u {
+		t.Fatalf("FromProto(ToProto(%v)) = %v", u, got)
+	}
+}
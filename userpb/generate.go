@@ -0,0 +1,6 @@
+// Package userpb holds the protobuf messages and gRPC stubs generated from
+// user.proto. Regenerate them with buf, protoc-gen-go and protoc-gen-go-grpc
+// on your PATH.
+package userpb
+
+//go:generate buf generate
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// struct tags decide how each field looks in XML:
+//
+//	`xml:"id,attr"`        -> <user id="1">
+//	`xml:"name"`           -> <name>Arman</name>
+//	`xml:"contact>email"`  -> <contact><email>..</email></contact>
+//	`xml:",comment"`       -> <!-- .. -->
+//	`xml:"-"`              -> skipped
+type Address struct {
+	City    string `xml:"city"`
+	Country string `xml:"country,attr"`
+}
+
+type User struct {
+	XMLName  xml.Name `xml:"user"`
+	ID       int      `xml:"id,attr"`
+	Active   bool     `xml:"active,attr,omitempty"`
+	Name     string   `xml:"name"`
+	Email    string   `xml:"contact>email"`
+	Phone    string   `xml:"contact>phone,omitempty"`
+	Address  Address  `xml:"address"`
+	Tags     []string `xml:"tags>tag"`
+	Note     string   `xml:",comment"`
+	Password string   `xml:"-"`
+}
+
+type Users struct {
+	XMLName xml.Name `xml:"users"`
+	Users   []User   `xml:"user"`
+}
+
+func main() {
+	users := Users{Users: []User{
+		{ID: 1, Active: true, Name: "Arman", Email: "arman@example.com", Address: Address{City: "Dhaka", Country: "BD"}, Tags: []string{"admin", "go"}, Note: " first user ", Password: "secret"},
+		{ID: 2, Name: "Sara & Co", Email: "sara@example.com", Phone: "+880", Address: Address{City: "Chittagong", Country: "BD"}},
+	}}
+
+	// 1. Marshal -> text
+	out, err := xml.MarshalIndent(users, "", "  ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	doc := xml.Header + string(out)
+	fmt.Println(doc)
+
+	// 2. Unmarshal -> structs again (round trip)
+	var back Users
+	if err := xml.Unmarshal([]byte(doc), &back); err != nil {
+		fmt.Println(err)
+		return
+	}
+	again, _ := xml.MarshalIndent(back, "", "  ")
+	fmt.Println("round trip equal:", string(again) == string(out))
+	fmt.Printf("password after round trip: %q\n", back.Users[0].Password) // xml:"-" is never written
+
+	// 3. Streaming: walk tokens and decode one <user> at a time, so a
+	// document of any size needs memory for a single user only.
+	big := bigDocument(100000)
+	n, cities, err := streamUsers(big)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("streamed users:", n, "cities:", cities)
+
+	// 4. Encoder writes straight to a writer
+	enc := xml.NewEncoder(os.Stdout)
+	enc.Indent("", "  ")
+	enc.Encode(Address{City: "Sylhet", Country: "BD"}) // root name comes from the type: <Address>
+	fmt.Println()
+}
+
+// streamUsers counts <user> elements and the users per city.
+func streamUsers(r io.Reader) (int, map[string]int, error) {
+	dec := xml.NewDecoder(r)
+	cities := map[string]int{}
+	n := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return n, cities, nil
+		}
+		if err != nil {
+			return n, cities, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "user" {
+			continue // <users>, text, comments ...
+		}
+		var u User
+		if err := dec.DecodeElement(&u, &start); err != nil { // consumes up to </user>
+			return n, cities, err
+		}
+		n++
+		cities[u.Address.City]++
+	}
+}
+
+// bigDocument generates a <users> document of n users on the fly through
+// a pipe, so it is never held in memory in full either.
+func bigDocument(n int) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		cities := []string{"Dhaka", "Khulna", "Rajshahi"}
+		enc := xml.NewEncoder(pw)
+		io.WriteString(pw, "<users>")
+		for i := range n {
+			u := User{ID: i, Name: fmt.Sprint("user", i), Email: strings.ToLower(fmt.Sprint("U", i, "@example.com")), Address: Address{City: cities[i%len(cities)]}}
+			if err := enc.Encode(u); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		io.WriteString(pw, "</users>")
+		pw.Close()
+	}()
+	return pr
+}
+
+/*
+	attr        -> attribute on the element
+	a>b         -> nested elements created for you
+	omitempty   -> skip zero values
+	,chardata   -> text inside the element
+	,innerxml   -> raw inner XML, unparsed
+	Token()     -> low level, one token at a time (streaming)
+	DecodeElement -> decode the element you are standing on into a struct
+*/
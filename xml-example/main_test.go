@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	in := Users{Users: []User{
+		{ID: 1, Active: true, Name: "Arman", Email: "arman@example.com", Phone: "+880",
+			Address: Address{City: "Dhaka", Country: "BD"}, Tags: []string{"admin", "go"}, Note: " first ", Password: "secret"},
+		{ID: 2, Name: "Sara & <Co>", Email: "sara@example.com", Address: Address{City: "Chittagong"}},
+	}}
+	out, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(out)
+	for _, want := range []string{
+		`<user id="1" active="true">`,
+		`<user id="2">`, // omitempty drops active="false"
+		`<contact><email>arman@example.com</email><phone>+880</phone></contact>`,
+		`<address country="BD"><city>Dhaka</city></address>`,
+		`<tags><tag>admin</tag><tag>go</tag></tags>`,
+		`<!-- first -->`,
+		`<name>Sara &amp; &lt;Co&gt;</name>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document lacks %s:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "secret") {
+		t.Error(`xml:"-" field was written`)
+	}
+
+	var back Users
+	if err := xml.Unmarshal(out, &back); err != nil {
+		t.Fatal(err)
+	}
+	want := in
+	want.Users = append([]User(nil), in.Users...)
+	want.Users[0].Password = ""
+	want.XMLName = xml.Name{Local: "users"}
+	for i := range want.Users {
+		want.Users[i].XMLName = xml.Name{Local: "user"}
+	}
+	if !reflect.DeepEqual(back, want) {
+		t.Fatalf("round trip:\n got %+v\nwant %+v", back, want)
+	}
+}
+
+func TestStreamMatchesEager(t *testing.T) {
+	const n = 5000
+	doc, err := io.ReadAll(bigDocument(n))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var all Users
+	if err := xml.Unmarshal(doc, &all); err != nil {
+		t.Fatal(err)
+	}
+	eager := map[string]int{}
+	for _, u := range all.Users {
+		eager[u.Address.City]++
+	}
+
+	count, cities, err := streamUsers(bytes.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != n || len(all.Users) != n {
+		t.Fatalf("streamed %d users, eager decode %d, want %d", count, len(all.Users), n)
+	}
+	if !maps.Equal(cities, eager) {
+		t.Fatalf("streamed cities %v, eager %v", cities, eager)
+	}
+	if u := all.Users[n-1]; u.ID != n-1 || u.Email != "u4999@example.com" {
+		t.Errorf("last user %+v", u)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	if _, _, err := streamUsers(strings.NewReader(`<users><user id="x"></user></users>`)); err == nil {
+		t.Error("bad id attribute decoded")
+	}
+	if _, _, err := streamUsers(strings.NewReader(`<users><user>`)); err == nil {
+		t.Error("truncated document decoded")
+	}
+}